@@ -1,6 +1,7 @@
 package context
 
 import (
+	"bufio"
 	"fmt"
 
 	"github.com/tendermint/tendermint/libs/common"
@@ -80,6 +81,23 @@ func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName
 	return
 }
 
+// Simulate runs the signed transaction bytes against the state of the node
+// without committing it, returning its result along with the gas it used
+func (ctx CoreContext) Simulate(cdc *wire.Codec, tx []byte) (res sdk.Result, err error) {
+	resRaw, err := ctx.query("/app/simulate", tx)
+	if err != nil {
+		return res, err
+	}
+	err = cdc.UnmarshalBinary(resRaw, &res)
+	if err != nil {
+		return res, err
+	}
+	if !res.IsOK() {
+		return res, errors.Errorf("simulation failed: (%d) %s", res.Code, res.Log)
+	}
+	return res, nil
+}
+
 // Query from Tendermint with the provided storename and path
 func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err error) {
 	node, err := ctx.GetNode()
@@ -102,6 +120,30 @@ func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err
 	return resp.Value, nil
 }
 
+// QueryStoreWithProof queries the key of the store at the height of the
+// context along with the proof of its value against the app hash
+func (ctx CoreContext) QueryStoreWithProof(key cmn.HexBytes, storeName string) (res []byte, proof []byte, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return res, proof, err
+	}
+
+	path := fmt.Sprintf("/store/%s/key", storeName)
+	opts := rpcclient.ABCIQueryOptions{
+		Height:  ctx.Height,
+		Trusted: false,
+	}
+	result, err := node.ABCIQueryWithOptions(path, key, opts)
+	if err != nil {
+		return res, proof, err
+	}
+	resp := result.Response
+	if resp.Code != uint32(0) {
+		return res, proof, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, nil
+}
+
 // Query from Tendermint with the provided storename and path
 func (ctx CoreContext) queryStore(key cmn.HexBytes, storeName, endPath string) (res []byte, err error) {
 	path := fmt.Sprintf("/store/%s/%s", storeName, endPath)
@@ -111,7 +153,7 @@ func (ctx CoreContext) queryStore(key cmn.HexBytes, storeName, endPath string) (
 // Get the from address from the name flag
 func (ctx CoreContext) GetFromAddress() (from sdk.AccAddress, err error) {
 
-	keybase, err := keys.GetKeyBase()
+	keybase, err := keys.GetKeyBaseWithInput(ctx.input())
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +201,7 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 		Fee:           auth.NewStdFee(ctx.Gas, fee), // TODO run simulate to estimate gas?
 	}
 
-	keybase, err := keys.GetKeyBase()
+	keybase, err := keys.GetKeyBaseWithInput(ctx.input())
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +245,7 @@ func (ctx CoreContext) ensureSignBuild(name string, msgs []sdk.Msg, cdc *wire.Co
 
 	var txBytes []byte
 
-	keybase, err := keys.GetKeyBase()
+	keybase, err := keys.GetKeyBaseWithInput(ctx.input())
 	if err != nil {
 		return nil, err
 	}
@@ -333,9 +375,34 @@ func (ctx CoreContext) NextSequence(address []byte) (int64, error) {
 
 // get passphrase from std input
 func (ctx CoreContext) GetPassphraseFromStdin(name string) (pass string, err error) {
-	buf := client.BufferStdin()
 	prompt := fmt.Sprintf("Password to sign with '%s':", name)
-	return client.GetPassword(prompt, buf)
+	return client.GetPassword(prompt, ctx.input())
+}
+
+// the reader of the prompts, stdin if the context has none
+func (ctx CoreContext) input() *bufio.Reader {
+	if ctx.Input == nil {
+		return client.BufferStdin()
+	}
+	return ctx.Input
+}
+
+// WithLatestHeight returns a copy of the context pinned to the latest block
+// height of the node unless a height is already set, so that successive
+// queries read the same state
+func (ctx CoreContext) WithLatestHeight() (CoreContext, error) {
+	if ctx.Height != 0 {
+		return ctx, nil
+	}
+	node, err := ctx.GetNode()
+	if err != nil {
+		return ctx, err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return ctx, err
+	}
+	return ctx.WithHeight(status.SyncInfo.LatestBlockHeight), nil
 }
 
 // GetNode prepares a simple rpc.Client
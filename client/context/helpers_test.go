@@ -0,0 +1,68 @@
+package context
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	cryptokeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// tests that the passphrases of the file keyring and of the key, piped to
+// stdin one per line, are both read when signing a tx
+func TestSignWithPipedStdin(t *testing.T) {
+	home, err := ioutil.TempDir("", "context")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+	viper.Set(cli.HomeFlag, home)
+	viper.Set(client.FlagKeyringBackend, keys.KeyringBackendFile)
+	viper.Set(client.FlagChainID, "test-chain")
+	viper.Set(client.FlagFrom, "alice")
+	defer viper.Reset()
+	keys.SetKeyBase(nil)
+	defer keys.SetKeyBase(nil)
+
+	keyring := cryptokeys.NewFileKeyring(filepath.Join(home, keys.KeyringFileDirName),
+		func(bool) (string, error) { return "keyringpass", nil })
+	info, _, err := cryptokeys.NewWithKeyring(keyring).CreateMnemonic("alice", cryptokeys.English, "alicepass", cryptokeys.Secp256k1)
+	require.NoError(t, err)
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("keyringpass\nalicepass\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	ctx := NewCoreContextFromViper()
+	from, err := ctx.GetFromAddress()
+	require.NoError(t, err)
+	require.Equal(t, sdk.AccAddress(info.GetPubKey().Address()), from)
+	passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+	require.NoError(t, err)
+	require.Equal(t, "alicepass", passphrase)
+
+	cdc := wire.NewCodec()
+	auth.RegisterWire(cdc)
+	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
+	cdc.RegisterConcrete(&sdk.TestMsg{}, "test/TestMsg", nil)
+	wire.RegisterCrypto(cdc)
+	txBytes, err := ctx.SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{sdk.NewTestMsg(from)}, cdc)
+	require.NoError(t, err)
+	var tx auth.StdTx
+	require.NoError(t, cdc.UnmarshalBinary(txBytes, &tx))
+	require.Equal(t, info.GetPubKey(), tx.Signatures[0].PubKey)
+}
@@ -1,6 +1,8 @@
 package context
 
 import (
+	"bufio"
+
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -25,6 +27,9 @@ type CoreContext struct {
 	Async           bool
 	JSON            bool
 	PrintResponse   bool
+	// reader of the prompts, shared by all of them as readers of a piped
+	// stdin each buffer lines of the others
+	Input *bufio.Reader
 }
 
 // WithChainID - return a copy of the context with an updated chainID
@@ -111,3 +116,9 @@ func (c CoreContext) WithUseLedger(useLedger bool) CoreContext {
 	c.UseLedger = useLedger
 	return c
 }
+
+// WithInput - return a copy of the context with an updated prompt reader
+func (c CoreContext) WithInput(input *bufio.Reader) CoreContext {
+	c.Input = input
+	return c
+}
@@ -56,6 +56,7 @@ func NewCoreContextFromViper() CoreContext {
 		Async:           viper.GetBool(client.FlagAsync),
 		JSON:            viper.GetBool(client.FlagJson),
 		PrintResponse:   viper.GetBool(client.FlagPrintResponse),
+		Input:           client.BufferStdin(),
 	}
 }
 
@@ -74,7 +75,7 @@ func defaultChainID() (string, error) {
 
 // EnsureAccountExists - Make sure account exists
 func EnsureAccountExists(ctx CoreContext, name string) error {
-	keybase, err := keys.GetKeyBase()
+	keybase, err := keys.GetKeyBaseWithInput(ctx.input())
 	if err != nil {
 		return err
 	}
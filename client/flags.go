@@ -1,24 +1,28 @@
 package client
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
 
 // nolint
 const (
-	FlagUseLedger     = "ledger"
-	FlagChainID       = "chain-id"
-	FlagNode          = "node"
-	FlagHeight        = "height"
-	FlagGas           = "gas"
-	FlagTrustNode     = "trust-node"
-	FlagFrom          = "from"
-	FlagName          = "name"
-	FlagAccountNumber = "account-number"
-	FlagSequence      = "sequence"
-	FlagMemo          = "memo"
-	FlagFee           = "fee"
-	FlagAsync         = "async"
-	FlagJson          = "json"
-	FlagPrintResponse = "print-response"
+	FlagUseLedger      = "ledger"
+	FlagChainID        = "chain-id"
+	FlagNode           = "node"
+	FlagHeight         = "height"
+	FlagGas            = "gas"
+	FlagTrustNode      = "trust-node"
+	FlagFrom           = "from"
+	FlagName           = "name"
+	FlagAccountNumber  = "account-number"
+	FlagSequence       = "sequence"
+	FlagMemo           = "memo"
+	FlagFee            = "fee"
+	FlagAsync          = "async"
+	FlagJson           = "json"
+	FlagPrintResponse  = "print-response"
+	FlagKeyringBackend = "keyring-backend"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -54,6 +58,12 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Bool(FlagAsync, false, "broadcast transactions asynchronously")
 		c.Flags().Bool(FlagJson, false, "return output in json format")
 		c.Flags().Bool(FlagPrintResponse, false, "return tx response (only works with async = false)")
+		AddKeyringBackendFlag(c.Flags())
 	}
 	return cmds
 }
+
+// AddKeyringBackendFlag adds the flag selecting the backend storing the keys
+func AddKeyringBackendFlag(flags *pflag.FlagSet) {
+	flags.String(FlagKeyringBackend, "leveldb", "Backend storing the keys (leveldb|file)")
+}
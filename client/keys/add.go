@@ -14,6 +14,7 @@ import (
 
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 
 	"github.com/tendermint/tendermint/libs/cli"
 )
@@ -25,6 +26,7 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+	flagHDPath   = "hd-path"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -32,8 +34,12 @@ func addKeyCommand() *cobra.Command {
 		Use:   "add <name>",
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
-If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+If you select --recover you can recover a key from the seed
+phrase, otherwise, a new key will be generated.
+
+The key is derived at the path 44'/118'/<account>'/0/<index> of the
+--account and --index flags, or at the full BIP 44 path of --hd-path,
+which recovers keys created by other wallets.`,
 		RunE: runAddCmd,
 	}
 	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
@@ -41,18 +47,43 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover existing key instead of creating")
 	cmd.Flags().Bool(flagNoBackup, false, "Don't print out seed phrase (if others are watching the terminal)")
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
+	addHDPathFlags(cmd)
+	return cmd
+}
+
+// add the flags selecting the HD derivation path of a key
+func addHDPathFlags(cmd *cobra.Command) {
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
-	return cmd
+	cmd.Flags().String(flagHDPath, "", "Full BIP 44 derivation path overriding --account and --index, e.g. 44'/118'/0'/0/0")
+}
+
+// get the HD derivation path of a key, the fundraiser path of the account and
+// index flags unless a full path is given
+func getHDPathParams() (hd.BIP44Params, error) {
+	if path := viper.GetString(flagHDPath); path != "" {
+		params, err := hd.NewParamsFromPath(path)
+		if err != nil {
+			return hd.BIP44Params{}, err
+		}
+		return *params, nil
+	}
+	account := uint32(viper.GetInt(flagAccount))
+	index := uint32(viper.GetInt(flagIndex))
+	return *hd.NewFundraiserParams(account, index), nil
 }
 
 // nolint: gocyclo
 // TODO remove the above when addressing #1446
 func runAddCmd(cmd *cobra.Command, args []string) error {
 	var kb keys.Keybase
-	var err error
 	var name, pass string
 
+	params, err := getHDPathParams()
+	if err != nil {
+		return err
+	}
+
 	buf := client.BufferStdin()
 	if viper.GetBool(flagDryRun) {
 		// we throw this away, so don't enforce args,
@@ -65,7 +96,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			return errors.New("you must provide a name for the key")
 		}
 		name = args[0]
-		kb, err = GetKeyBase()
+		kb, err = GetKeyBaseWithInput(buf)
 		if err != nil {
 			return err
 		}
@@ -91,9 +122,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	if viper.GetBool(client.FlagUseLedger) {
-		account := uint32(viper.GetInt(flagAccount))
-		index := uint32(viper.GetInt(flagIndex))
-		path := ccrypto.DerivationPath{44, 118, account, 0, index}
+		path := ccrypto.DerivationPath(params.DerivationPath())
 		algo := keys.SigningAlgo(viper.GetString(flagType))
 		info, err := kb.CreateLedger(name, path, algo)
 		if err != nil {
@@ -106,7 +135,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		info, err := kb.CreateKey(name, seed, pass)
+		info, err := kb.Derive(name, seed, pass, params)
 		if err != nil {
 			return err
 		}
@@ -115,7 +144,12 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		printCreate(info, "")
 	} else {
 		algo := keys.SigningAlgo(viper.GetString(flagType))
-		info, seed, err := kb.CreateMnemonic(name, keys.English, pass, algo)
+		if algo != keys.Secp256k1 {
+			return keys.ErrUnsupportedSigningAlgo
+		}
+		// derive the key of a new seed phrase at the requested path
+		seed := getSeed(algo)
+		info, err := kb.Derive(name, seed, pass, params)
 		if err != nil {
 			return err
 		}
@@ -25,7 +25,8 @@ func deleteKeyCommand() *cobra.Command {
 func runDeleteCmd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	kb, err := GetKeyBase()
+	buf := client.BufferStdin()
+	kb, err := GetKeyBaseWithInput(buf)
 	if err != nil {
 		return err
 	}
@@ -35,7 +36,6 @@ func runDeleteCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	buf := client.BufferStdin()
 	oldpass, err := client.GetPassword(
 		"DANGER - enter password to permanently delete key:", buf)
 	if err != nil {
@@ -0,0 +1,86 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+)
+
+const (
+	flagUnsafe = "unsafe"
+	flagFormat = "format"
+
+	// unencrypted hex of the private key
+	formatHex = "hex"
+	// keystore JSON file of the Web3 Secret Storage definition
+	formatKeystore = "keystore"
+)
+
+func exportKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a private key as unencrypted hex or a keystore JSON file",
+		Long: `Export the private key of a local key in a format read by other wallets:
+the unencrypted hex of the key, or a keystore JSON file encrypted with a new
+passphrase. Anyone who gets hold of the hex controls the key, so the export
+must be confirmed with --unsafe.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportCmd,
+	}
+	cmd.Flags().Bool(flagUnsafe, false, "Confirm the export of the private key")
+	cmd.Flags().String(flagFormat, formatHex, "Format of the exported key (hex|keystore)")
+	return cmd
+}
+
+func runExportCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	format := viper.GetString(flagFormat)
+	if format != formatHex && format != formatKeystore {
+		return fmt.Errorf("unknown key format %s", format)
+	}
+	if !viper.GetBool(flagUnsafe) {
+		return errors.New("exporting a private key is unsafe, confirm it with --unsafe")
+	}
+
+	buf := client.BufferStdin()
+	kb, err := GetKeyBaseWithInput(buf)
+	if err != nil {
+		return err
+	}
+	pass, err := client.GetPassword(
+		"Enter the passphrase of the key:", buf)
+	if err != nil {
+		return err
+	}
+	priv, err := kb.ExportPrivateKeyObject(name, pass)
+	if err != nil {
+		return err
+	}
+
+	if format == formatHex {
+		hexKey, err := keys.ExportUnsafeHex(priv)
+		if err != nil {
+			return err
+		}
+		fmt.Println(hexKey)
+		return nil
+	}
+
+	keystorePass, err := client.GetCheckPassword(
+		"Enter a passphrase for the keystore file:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+	keystore, err := keys.ExportKeystoreJSON(priv, keystorePass)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(keystore))
+	return nil
+}
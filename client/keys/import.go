@@ -0,0 +1,83 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+)
+
+func importKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name> <keyfile>",
+		Short: "Import a private key from unencrypted hex or a keystore JSON file",
+		Long: `Import the private key exported by another wallet as a local key: the
+unencrypted hex of the key, or a keystore JSON file and its passphrase. The key
+file holds the private key, so the import must be confirmed with --unsafe.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runImportCmd,
+	}
+	cmd.Flags().Bool(flagUnsafe, false, "Confirm the import of the private key")
+	cmd.Flags().String(flagFormat, formatHex, "Format of the key file (hex|keystore)")
+	return cmd
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	format := viper.GetString(flagFormat)
+	if format != formatHex && format != formatKeystore {
+		return fmt.Errorf("unknown key format %s", format)
+	}
+	if !viper.GetBool(flagUnsafe) {
+		return errors.New("importing a private key is unsafe, confirm it with --unsafe")
+	}
+
+	bz, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	kb, err := GetKeyBaseWithInput(buf)
+	if err != nil {
+		return err
+	}
+	if _, err := kb.Get(name); err == nil {
+		return fmt.Errorf("a key named %s already exists", name)
+	}
+
+	var priv tmcrypto.PrivKey
+	if format == formatHex {
+		priv, err = keys.ImportUnsafeHex(string(bz))
+	} else {
+		var keystorePass string
+		keystorePass, err = client.GetPassword(
+			"Enter the passphrase of the keystore file:", buf)
+		if err != nil {
+			return err
+		}
+		priv, err = keys.ImportKeystoreJSON(bz, keystorePass)
+	}
+	if err != nil {
+		return err
+	}
+
+	pass, err := client.GetCheckPassword(
+		"Enter a passphrase for your key:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+	info, err := kb.ImportPrivKey(name, priv, pass)
+	if err != nil {
+		return err
+	}
+	printInfo(info)
+	return nil
+}
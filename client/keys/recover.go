@@ -0,0 +1,195 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const flagScan = "scan"
+
+// CoinsQuerier returns the coins an address holds on the chain, the addresses
+// holding none aren't offered for import by a scan
+type CoinsQuerier func(addr sdk.AccAddress) (sdk.Coins, error)
+
+func recoverKeyCommand(querier CoinsQuerier) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover <name>",
+		Short: "Recover keys from a seed phrase",
+		Long: `Recover the key of a seed phrase at the path of the --account and --index
+flags, or at the full BIP 44 path of --hd-path.
+
+With --scan N, the N consecutive addresses starting at that path are derived
+and the chain is queried for their balances. The addresses holding coins are
+offered for import as <name>-<index>.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecoverCmd(args[0], querier)
+		},
+	}
+	addHDPathFlags(cmd)
+	cmd.Flags().Uint32(flagScan, 0, "Number of consecutive addresses to scan for balances")
+	// the node the balances of a scan are queried on
+	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of tendermint node")
+	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	return cmd
+}
+
+// scanned address of a seed phrase
+type scannedKey struct {
+	params hd.BIP44Params
+	info   keys.Info
+	coins  sdk.Coins
+}
+
+func runRecoverCmd(name string, querier CoinsQuerier) error {
+	params, err := getHDPathParams()
+	if err != nil {
+		return err
+	}
+	scan := uint32(viper.GetInt(flagScan))
+	if err := checkScanRange(params, uint64(scan)); err != nil {
+		return err
+	}
+	if scan > 0 && querier == nil {
+		return errors.New("scanning requires a chain to query the balances on")
+	}
+
+	buf := client.BufferStdin()
+	kb, err := GetKeyBaseWithInput(buf)
+	if err != nil {
+		return err
+	}
+
+	seed, err := client.GetSeed("Enter your recovery seed phrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	if scan == 0 {
+		if _, err := kb.Get(name); err == nil {
+			// account exists, ask for user confirmation
+			if response, err := client.GetConfirmation(
+				fmt.Sprintf("override the existing name %s", name), buf); err != nil || !response {
+				return err
+			}
+		}
+		pass, err := client.GetCheckPassword(
+			"Enter a passphrase for your key:",
+			"Repeat the passphrase:", buf)
+		if err != nil {
+			return err
+		}
+		info, err := kb.Derive(name, seed, pass, params)
+		if err != nil {
+			return err
+		}
+		printInfo(info)
+		return nil
+	}
+
+	scanned, err := scanSeed(seed, params, scan, querier)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("PATH:\t\tADDRESS:\t\t\t\t\t\tCOINS:\n")
+	for _, key := range scanned {
+		fmt.Printf("%s\t%s\t%s\n", key.params, sdk.AccAddress(key.info.GetPubKey().Address()), key.coins)
+	}
+
+	// pick the keys to import among the addresses holding coins
+	var picked []scannedKey
+	for _, key := range scanned {
+		if key.coins.IsZero() {
+			continue
+		}
+		keyName := scannedKeyName(name, key.params)
+		if _, err := kb.Get(keyName); err == nil {
+			fmt.Printf("Skipping %s, a key named %s already exists\n", key.params, keyName)
+			continue
+		}
+		response, err := client.GetConfirmation(
+			fmt.Sprintf("import %s holding %s as %s", key.params, key.coins, keyName), buf)
+		if err != nil {
+			return err
+		}
+		if response {
+			picked = append(picked, key)
+		}
+	}
+	if len(picked) == 0 {
+		fmt.Println("No key imported")
+		return nil
+	}
+
+	pass, err := client.GetCheckPassword(
+		"Enter a passphrase for your keys:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+	var infos []keys.Info
+	for _, key := range picked {
+		info, err := kb.Derive(scannedKeyName(name, key.params), seed, pass, key.params)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, info)
+	}
+	printInfos(infos)
+	return nil
+}
+
+// address indexes from 2^31 on are hardened, they aren't part of the
+// non-hardened address index level
+const maxAddressIndex = uint64(1)<<31 - 1
+
+// check that the n address indexes starting at the path of params stay below
+// the hardened range, where the address index would wrap around
+func checkScanRange(params hd.BIP44Params, n uint64) error {
+	start := uint64(params.DerivationPath()[4])
+	if n > 0 && start+n-1 > maxAddressIndex {
+		return errors.Errorf("cannot scan %d addresses from index %d, address indexes must not exceed %d",
+			n, start, maxAddressIndex)
+	}
+	return nil
+}
+
+// derive the keys of n consecutive address indexes of a seed phrase, starting
+// at the path of params, and query their coins
+func scanSeed(seed string, params hd.BIP44Params, n uint32, querier CoinsQuerier) ([]scannedKey, error) {
+	// the public keys are derived in memory, without storing anything
+	if err := checkScanRange(params, uint64(n)); err != nil {
+		return nil, err
+	}
+	kb := client.MockKeyBase()
+	start := params.DerivationPath()[4]
+
+	scanned := make([]scannedKey, 0, n)
+	for i := uint32(0); i < n; i++ {
+		keyParams := params.WithAddressIndex(start + i)
+		info, err := kb.Derive(keyParams.String(), seed, "", keyParams)
+		if err != nil {
+			return nil, err
+		}
+		coins, err := querier(sdk.AccAddress(info.GetPubKey().Address()))
+		if err != nil {
+			return nil, err
+		}
+		scanned = append(scanned, scannedKey{keyParams, info, coins})
+	}
+	return scanned, nil
+}
+
+// name of a key imported by a scan
+func scannedKeyName(name string, params hd.BIP44Params) string {
+	return fmt.Sprintf("%s-%d", name, params.DerivationPath()[4])
+}
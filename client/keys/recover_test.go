@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestScanSeed(t *testing.T) {
+	kb := client.MockKeyBase()
+	_, seed, err := kb.CreateMnemonic("seed", keys.English, "1234567890", keys.Secp256k1)
+	require.NoError(t, err)
+
+	// only the address at index 3 holds coins
+	params := *hd.NewFundraiserParams(0, 2)
+	funded, err := kb.Derive("funded", seed, "1234567890", params.WithAddressIndex(3))
+	require.NoError(t, err)
+	fundedAddr := sdk.AccAddress(funded.GetPubKey().Address())
+	coins := sdk.Coins{sdk.NewCoin("steak", 10)}
+	querier := func(addr sdk.AccAddress) (sdk.Coins, error) {
+		if bytes.Equal(addr, fundedAddr) {
+			return coins, nil
+		}
+		return nil, nil
+	}
+
+	scanned, err := scanSeed(seed, params, 3, querier)
+	require.NoError(t, err)
+	require.Len(t, scanned, 3)
+	for i, key := range scanned {
+		require.Equal(t, params.WithAddressIndex(uint32(2+i)), key.params)
+	}
+	require.True(t, scanned[0].coins.IsZero())
+	require.Equal(t, coins, scanned[1].coins)
+	require.Equal(t, fundedAddr, sdk.AccAddress(scanned[1].info.GetPubKey().Address()))
+	require.True(t, scanned[2].coins.IsZero())
+
+	// the scan fails if a balance cannot be queried
+	failing := func(addr sdk.AccAddress) (sdk.Coins, error) {
+		return nil, errors.New("node unreachable")
+	}
+	_, err = scanSeed(seed, params, 3, failing)
+	require.Error(t, err)
+}
+
+func TestCheckScanRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      uint32
+		n          uint64
+		expectPass bool
+	}{
+		{"no scan", 1<<31 - 1, 0, true},
+		{"from zero", 0, 20, true},
+		{"up to the last index", 1<<31 - 3, 3, true},
+		{"past the last index", 1<<31 - 2, 3, false},
+		{"hardened start", 1 << 31, 1, false},
+		{"whole range", 0, 1<<31 + 1, false},
+	}
+
+	for _, tc := range tests {
+		err := checkScanRange(hd.NewFundraiserParams(0, 0).WithAddressIndex(tc.start), tc.n)
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", tc.name)
+		} else {
+			require.NotNil(t, err, "test: %v", tc.name)
+		}
+	}
+
+	// nothing is derived when the scan would wrap around
+	_, err := scanSeed("", *hd.NewFundraiserParams(0, 1<<31-1), 2, nil)
+	require.Error(t, err)
+}
+
+func TestScannedKeyName(t *testing.T) {
+	require.Equal(t, "mykey-0", scannedKeyName("mykey", *hd.NewFundraiserParams(0, 0)))
+	require.Equal(t, "mykey-7", scannedKeyName("mykey", *hd.NewFundraiserParams(1, 7)))
+}
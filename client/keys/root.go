@@ -7,8 +7,9 @@ import (
 )
 
 // Commands registers a sub-tree of commands to interact with
-// local private key storage.
-func Commands() *cobra.Command {
+// local private key storage. The querier returns the balances of the
+// addresses scanned by keys recover, scanning is disabled if it is nil.
+func Commands(querier CoinsQuerier) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "keys",
 		Short: "Add or view local private keys",
@@ -20,12 +21,17 @@ func Commands() *cobra.Command {
 	}
 	cmd.AddCommand(
 		addKeyCommand(),
+		recoverKeyCommand(querier),
 		listKeysCmd,
 		showKeysCmd,
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		client.LineBreak,
+		importKeyCommand(),
+		exportKeyCommand(),
 	)
+	client.AddKeyringBackendFlag(cmd.PersistentFlags())
 	return cmd
 }
 
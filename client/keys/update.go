@@ -26,7 +26,7 @@ func runUpdateCmd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
 	buf := client.BufferStdin()
-	kb, err := GetKeyBase()
+	kb, err := GetKeyBaseWithInput(buf)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package keys
 
 import (
+	"bufio"
 	"fmt"
 	"path/filepath"
 
@@ -18,6 +19,18 @@ import (
 // KeyDBName is the directory under root where we store the keys
 const KeyDBName = "keys"
 
+// backends storing the keys, selected with --keyring-backend
+const (
+	// LevelDB database under the keys directory
+	KeyringBackendLevelDB = "leveldb"
+	// one encrypted file per key under the keyring-file directory
+	KeyringBackendFile = "file"
+)
+
+// KeyringFileDirName is the directory under root where the file backend
+// stores the keys
+const KeyringFileDirName = "keyring-file"
+
 // keybase is used to make GetKeyBase a singleton
 var keybase keys.Keybase
 
@@ -25,22 +38,54 @@ var keybase keys.Keybase
 
 // initialize a keybase based on the configuration
 func GetKeyBase() (keys.Keybase, error) {
+	return GetKeyBaseWithInput(client.BufferStdin())
+}
+
+// initialize a keybase based on the configuration, the passphrase of a file
+// keyring is read from buf, which must be the reader of the other prompts of
+// the command as readers of a piped stdin each buffer lines of the others
+func GetKeyBaseWithInput(buf *bufio.Reader) (keys.Keybase, error) {
 	rootDir := viper.GetString(cli.HomeFlag)
-	return GetKeyBaseFromDir(rootDir)
+	return getKeyBaseFromDir(rootDir, buf)
 }
 
 // initialize a keybase based on the configuration
 func GetKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
+	return getKeyBaseFromDir(rootDir, client.BufferStdin())
+}
+
+func getKeyBaseFromDir(rootDir string, buf *bufio.Reader) (keys.Keybase, error) {
 	if keybase == nil {
-		db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys"))
-		if err != nil {
-			return nil, err
+		switch backend := viper.GetString(client.FlagKeyringBackend); backend {
+		case "", KeyringBackendLevelDB:
+			db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys"))
+			if err != nil {
+				return nil, err
+			}
+			keybase = client.GetKeyBase(db)
+		case KeyringBackendFile:
+			keyring := keys.NewFileKeyring(filepath.Join(rootDir, KeyringFileDirName), keyringPassphrase(buf))
+			keybase = keys.NewWithKeyring(keyring)
+		default:
+			return nil, fmt.Errorf("unknown keyring backend %s", backend)
 		}
-		keybase = client.GetKeyBase(db)
 	}
 	return keybase, nil
 }
 
+// prompt on buf for the passphrase of the file keyring, it is chosen when the
+// keyring is created
+func keyringPassphrase(buf *bufio.Reader) keys.KeyringPassphrase {
+	return func(create bool) (string, error) {
+		if create {
+			return client.GetCheckPassword(
+				"Enter a passphrase for the new keyring:",
+				"Repeat the passphrase:", buf)
+		}
+		return client.GetPassword("Enter the keyring passphrase:", buf)
+	}
+}
+
 // used to set the keybase manually in test
 func SetKeyBase(kb keys.Keybase) {
 	keybase = kb
@@ -518,7 +518,7 @@ func TestVote(t *testing.T) {
 	require.Equal(t, gov.OptionYes, vote.Option)
 }
 
-func TestUnrevoke(t *testing.T) {
+func TestUnjail(t *testing.T) {
 	_, password := "test", "1234567890"
 	addr, _ := CreateAddr(t, "test", password, GetKB(t))
 	cleanup, pks, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
@@ -530,8 +530,13 @@ func TestUnrevoke(t *testing.T) {
 	signingInfo := getSigningInfo(t, port, sdk.ValAddress(pks[0].Address()))
 	tests.WaitForHeight(4, port)
 	require.Equal(t, true, signingInfo.IndexOffset > 0)
-	require.Equal(t, int64(0), signingInfo.JailedUntil)
+	require.Equal(t, int64(0), signingInfo.DowntimeJailCount)
 	require.Equal(t, true, signingInfo.SignedBlocksCounter > 0)
+
+	missedBlocks := getMissedBlocks(t, port, sdk.ValAddress(pks[0].Address()))
+	require.Equal(t, int64(0), missedBlocks.MissedCount)
+	require.Equal(t, missedBlocks.SignedCount, int64(len(missedBlocks.Bitmap)))
+	require.Equal(t, true, missedBlocks.JailingHeight > missedBlocks.Height)
 }
 
 func TestProposalsQuery(t *testing.T) {
@@ -719,6 +724,15 @@ func getSigningInfo(t *testing.T, port string, validatorAddr sdk.ValAddress) sla
 	return signingInfo
 }
 
+func getMissedBlocks(t *testing.T, port string, validatorAddr sdk.ValAddress) slashing.MissedBlocks {
+	res, body := Request(t, port, "GET", fmt.Sprintf("/slashing/missed_blocks/%s", validatorAddr), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var missedBlocks slashing.MissedBlocks
+	err := cdc.UnmarshalJSON([]byte(body), &missedBlocks)
+	require.Nil(t, err)
+	return missedBlocks
+}
+
 func getDelegation(t *testing.T, port string, delegatorAddr, validatorAddr sdk.AccAddress) stake.Delegation {
 
 	// get the account to get the sequence
@@ -754,9 +768,7 @@ func doDelegate(t *testing.T, port, seed, name, password string, delegatorAddr,
 			}
 		],
 		"begin_unbondings": [],
-		"complete_unbondings": [],
-		"begin_redelegates": [],
-		"complete_redelegates": []
+		"begin_redelegates": []
 	}`, name, password, accnum, sequence, chainID, delegatorAddr, validatorAddr, "steak"))
 	res, body := Request(t, port, "POST", "/stake/delegations", jsonStr)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
@@ -794,9 +806,7 @@ func doBeginUnbonding(t *testing.T, port, seed, name, password string,
 				"shares": "30"
 			}
 		],
-		"complete_unbondings": [],
-		"begin_redelegates": [],
-		"complete_redelegates": []
+		"begin_redelegates": []
 	}`, name, password, accnum, sequence, chainID, delegatorAddr, validatorAddr))
 	res, body := Request(t, port, "POST", "/stake/delegations", jsonStr)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
@@ -828,7 +838,6 @@ func doBeginRedelegation(t *testing.T, port, seed, name, password string,
 		"chain_id": "%s",
 		"delegations": [],
 		"begin_unbondings": [],
-		"complete_unbondings": [],
 		"begin_redelegates": [
 			{
 				"delegator_addr": "%s",
@@ -836,8 +845,7 @@ func doBeginRedelegation(t *testing.T, port, seed, name, password string,
 				"validator_dst_addr": "%s",
 				"shares": "30"
 			}
-		],
-		"complete_redelegates": []
+		]
 	}`, name, password, accnum, sequence, chainID, delegatorAddr, validatorSrcAddr, validatorDstAddr))
 	res, body := Request(t, port, "POST", "/stake/delegations", jsonStr)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
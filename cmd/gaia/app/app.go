@@ -16,8 +16,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
@@ -33,6 +36,15 @@ var (
 	DefaultNodeHome = os.ExpandEnv("$HOME/.gaiad")
 )
 
+// permissions of the module accounts
+var moduleAccountPerms = map[string][]string{
+	auth.FeeCollectorName: nil,
+	stake.ModuleName:      {auth.Burner, auth.Staking},
+	mint.ModuleName:       {auth.Minter},
+	gov.ModuleName:        {auth.Burner},
+	ibc.ModuleName:        {auth.Minter, auth.Burner},
+}
+
 // Extended ABCI application
 type GaiaApp struct {
 	*bam.BaseApp
@@ -43,20 +55,32 @@ type GaiaApp struct {
 	keyAccount       *sdk.KVStoreKey
 	keyIBC           *sdk.KVStoreKey
 	keyStake         *sdk.KVStoreKey
+	keyMint          *sdk.KVStoreKey
 	keySlashing      *sdk.KVStoreKey
+	keyEvidence      *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
 	keyParams        *sdk.KVStoreKey
+	keySupply        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
 	feeCollectionKeeper auth.FeeCollectionKeeper
 	coinKeeper          bank.Keeper
+	supplyKeeper        bank.SupplyKeeper
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
+	mintKeeper          mint.Keeper
 	slashingKeeper      slashing.Keeper
+	evidenceKeeper      evidence.Keeper
 	govKeeper           gov.Keeper
 	paramsKeeper        params.Keeper
+
+	// invariants of the modules, asserted every invCheckPeriod blocks if it
+	// isn't zero
+	invarRegistry          *crisis.Registry
+	invCheckPeriod         uint
+	haltOnInvariantFailure bool
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -73,10 +97,13 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		keyAccount:       sdk.NewKVStoreKey("acc"),
 		keyIBC:           sdk.NewKVStoreKey("ibc"),
 		keyStake:         sdk.NewKVStoreKey("stake"),
+		keyMint:          sdk.NewKVStoreKey("mint"),
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
+		keyEvidence:      sdk.NewKVStoreKey("evidence"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
 		keyParams:        sdk.NewKVStoreKey("params"),
+		keySupply:        sdk.NewKVStoreKey("supply"),
 	}
 
 	// define the accountMapper
@@ -87,20 +114,41 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	)
 
 	// add handlers
-	app.coinKeeper = bank.NewKeeper(app.accountMapper)
-	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
 	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
+	bank.RegisterParamTypes(app.paramsKeeper)
+	app.coinKeeper = bank.NewKeeper(app.accountMapper, app.paramsKeeper.Getter(), BlockedAddrs())
+	app.supplyKeeper = bank.NewSupplyKeeper(app.cdc, app.keySupply, app.coinKeeper, moduleAccountPerms)
+	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.supplyKeeper, app.RegisterCodespace(stake.DefaultCodespace))
+	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint, app.stakeKeeper, app.coinKeeper, app.supplyKeeper)
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.supplyKeeper, app.paramsKeeper.Setter(), app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
 	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
 
+	app.evidenceKeeper = evidence.NewKeeper(app.cdc, app.keyEvidence, app.stakeKeeper, app.slashingKeeper.MaxEvidenceAge, app.RegisterCodespace(evidence.DefaultCodespace))
+
+	// register the staking hooks of the modules following validators
+	app.stakeKeeper.RegisterHooks(app.slashingKeeper.Hooks())
+
+	// register the handlers of the evidence types
+	app.evidenceKeeper.RegisterHandler(tmtypes.ABCIEvidenceTypeDuplicateVote, app.slashingKeeper.HandleDoubleSign, app.slashingKeeper.DoubleSignPolicy())
+	app.evidenceKeeper.RegisterHandler(ibc.MisbehaviourEvidenceType, app.ibcMapper.MisbehaviourHandler(app.stakeKeeper, app.slashingKeeper.MaxEvidenceAge), ibc.MisbehaviourPolicy())
+
+	// bind the modules exchanging IBC packets to their ports
+	app.ibcMapper.BindPort(ibc.TransferPort, ibc.NewTransferModule(app.ibcMapper, app.coinKeeper, app.supplyKeeper))
+
+	// register the invariants of the modules
+	app.invarRegistry = crisis.NewRegistry()
+	bank.RegisterInvariants(app.invarRegistry, app.accountMapper, app.supplyKeeper)
+	stake.RegisterInvariants(app.invarRegistry, app.stakeKeeper, app.accountMapper)
+
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
+		AddRoute("evidence", evidence.NewHandler(app.evidenceKeeper)).
 		AddRoute("gov", gov.NewHandler(app.govKeeper))
 
 	// initialize BaseApp
@@ -108,7 +156,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keyMint, app.keySlashing, app.keyEvidence, app.keyGov, app.keyFeeCollection, app.keyParams, app.keySupply)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -117,6 +165,16 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	return app
 }
 
+// BlockedAddrs returns the addresses of the module accounts, which are not
+// allowed to receive coins sent by users
+func BlockedAddrs() map[string]bool {
+	blockedAddrs := make(map[string]bool)
+	for name := range moduleAccountPerms {
+		blockedAddrs[auth.NewModuleAddress(name).String()] = true
+	}
+	return blockedAddrs
+}
+
 // custom tx codec
 func MakeCodec() *wire.Codec {
 	var cdc = wire.NewCodec()
@@ -124,6 +182,7 @@ func MakeCodec() *wire.Codec {
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
+	evidence.RegisterWire(cdc)
 	gov.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
@@ -133,7 +192,12 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// mint the provisions of the block before any fees are distributed
+	mint.BeginBlocker(ctx, app.mintKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+	evidenceTags := evidence.BeginBlocker(ctx, req, app.evidenceKeeper)
+	tags = tags.AppendTags(evidenceTags)
 
 	return abci.ResponseBeginBlock{
 		Tags: tags.ToKVPairs(),
@@ -143,9 +207,14 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
+	validatorUpdates, tags := stake.EndBlocker(ctx, app.stakeKeeper)
 
-	tags, _ := gov.EndBlocker(ctx, app.govKeeper)
+	govTags, _ := gov.EndBlocker(ctx, app.govKeeper)
+	tags = tags.AppendTags(govTags)
+
+	if app.invCheckPeriod != 0 && ctx.BlockHeight()%int64(app.invCheckPeriod) == 0 {
+		app.assertRuntimeInvariants(ctx)
+	}
 
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
@@ -153,6 +222,34 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	}
 }
 
+// SetInvariantCheck sets the period in blocks at which the invariants are
+// asserted at the end of a block, zero disabling the checks, and whether the
+// node halts on a broken invariant rather than only logging it
+func (app *GaiaApp) SetInvariantCheck(period uint, haltOnFailure bool) {
+	app.invCheckPeriod = period
+	app.haltOnInvariantFailure = haltOnFailure
+}
+
+// AssertInvariants asserts all the invariants of the modules against the
+// latest committed state
+func (app *GaiaApp) AssertInvariants() error {
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	return app.invarRegistry.AssertInvariants(ctx)
+}
+
+// assert the invariants at the end of a block, halting the node if they are
+// broken and it is configured to
+func (app *GaiaApp) assertRuntimeInvariants(ctx sdk.Context) {
+	err := app.invarRegistry.AssertInvariants(ctx)
+	if err == nil {
+		return
+	}
+	if app.haltOnInvariantFailure {
+		panic(err)
+	}
+	ctx.Logger().With("module", "x/crisis").Error(err.Error(), "height", ctx.BlockHeight())
+}
+
 // custom logic for gaia initialization
 func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 	stateJSON := req.AppStateBytes
@@ -167,11 +264,17 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
-		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		acc := gacc.ToAccountI()
+		err = acc.SetAccountNumber(app.accountMapper.GetNextAccountNumber(ctx))
+		if err != nil {
+			panic(err)
+		}
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// load the total supply
+	bank.InitGenesis(ctx, app.supplyKeeper, genesisState.BankData)
+
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 	if err != nil {
@@ -179,8 +282,20 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		// return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
 
+	// load the minter and mint params
+	err = mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+	if err != nil {
+		panic(err)
+	}
+
 	gov.InitGenesis(ctx, app.govKeeper, gov.DefaultGenesisState())
 
+	// create the light clients of the counterparty chains
+	err = ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCData)
+	if err != nil {
+		panic(err)
+	}
+
 	return abci.ResponseInitChain{
 		Validators: validators,
 	}
@@ -201,7 +316,10 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 
 	genState := GenesisState{
 		Accounts:  accounts,
+		BankData:  bank.WriteGenesis(ctx, app.supplyKeeper),
 		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+		MintData:  mint.WriteGenesis(ctx, app.mintKeeper),
+		IBCData:   ibc.WriteGenesis(ctx, app.ibcMapper),
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
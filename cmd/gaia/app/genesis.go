@@ -13,6 +13,9 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -25,13 +28,17 @@ var (
 // State to Unmarshal
 type GenesisState struct {
 	Accounts  []GenesisAccount   `json:"accounts"`
+	BankData  bank.GenesisState  `json:"bank"`
 	StakeData stake.GenesisState `json:"stake"`
+	MintData  mint.GenesisState  `json:"mint"`
+	IBCData   ibc.GenesisState   `json:"ibc"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
 type GenesisAccount struct {
-	Address sdk.AccAddress `json:"address"`
-	Coins   sdk.Coins      `json:"coins"`
+	Address    sdk.AccAddress `json:"address"`
+	Coins      sdk.Coins      `json:"coins"`
+	ModuleName string         `json:"module_name,omitempty"` // set for module accounts only
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -42,10 +49,14 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+	if macc, ok := acc.(*auth.ModuleAccount); ok {
+		gacc.ModuleName = macc.GetName()
+	}
+	return gacc
 }
 
 // convert GenesisAccount to auth.BaseAccount
@@ -56,6 +67,15 @@ func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
 	}
 }
 
+// convert GenesisAccount to an auth.Account, restoring module accounts
+func (ga *GenesisAccount) ToAccountI() auth.Account {
+	acc := ga.ToAccount()
+	if ga.ModuleName != "" {
+		return &auth.ModuleAccount{BaseAccount: acc, Name: ga.ModuleName}
+	}
+	return acc
+}
+
 // get app init parameters for server init command
 func GaiaAppInit() server.AppInit {
 	fsAppGenState := pflag.NewFlagSet("", pflag.ContinueOnError)
@@ -142,6 +162,12 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 	// start with the default staking genesis state
 	stakeData := stake.DefaultGenesisState()
 
+	// total supply of all coins created at genesis
+	supply := sdk.Coins{}
+
+	// the stake module account holds the tokens of the genesis validators
+	stakeAcc := auth.NewEmptyModuleAccount(stake.ModuleName)
+
 	// get genesis flag account information
 	genaccs := make([]GenesisAccount, len(appGenTxs))
 	for i, appGenTx := range appGenTxs {
@@ -161,6 +187,7 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 		acc := NewGenesisAccount(&accAuth)
 		genaccs[i] = acc
 		stakeData.Pool.LooseTokens = stakeData.Pool.LooseTokens.Add(sdk.NewRat(freeFermionsAcc)) // increase the supply
+		supply = supply.Plus(accAuth.Coins.Sort())
 
 		// add the validator
 		if len(genTx.Name) > 0 {
@@ -169,6 +196,8 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 				sdk.MustGetAccPubKeyBech32(genTx.PubKey), desc)
 
 			stakeData.Pool.LooseTokens = stakeData.Pool.LooseTokens.Add(sdk.NewRat(freeFermionVal)) // increase the supply
+			supply = supply.Plus(sdk.Coins{sdk.NewCoin("steak", freeFermionVal)})
+			stakeAcc.Coins = stakeAcc.Coins.Plus(sdk.Coins{sdk.NewCoin("steak", freeFermionVal)})
 
 			// add some new shares to the validator
 			var issuedDelShares sdk.Rat
@@ -187,10 +216,17 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 		}
 	}
 
+	if !stakeAcc.Coins.IsZero() {
+		genaccs = append(genaccs, NewGenesisAccountI(stakeAcc))
+	}
+
 	// create the final app state
 	genesisState = GenesisState{
 		Accounts:  genaccs,
+		BankData:  bank.NewGenesisState(supply),
 		StakeData: stakeData,
+		MintData:  mint.DefaultGenesisState(),
+		IBCData:   ibc.DefaultGenesisState(),
 	}
 	return
 }
@@ -12,7 +12,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	stake "github.com/cosmos/cosmos-sdk/x/stake"
 	stakesim "github.com/cosmos/cosmos-sdk/x/stake/simulation"
@@ -36,6 +38,7 @@ func init() {
 
 func appStateFn(r *rand.Rand, accs []sdk.AccAddress) json.RawMessage {
 	var genesisAccounts []GenesisAccount
+	supply := sdk.Coins{}
 
 	// Randomly generate some genesis accounts
 	for _, addr := range accs {
@@ -44,6 +47,7 @@ func appStateFn(r *rand.Rand, accs []sdk.AccAddress) json.RawMessage {
 			Address: addr,
 			Coins:   coins,
 		})
+		supply = supply.Plus(coins)
 	}
 
 	// Default genesis state
@@ -51,7 +55,9 @@ func appStateFn(r *rand.Rand, accs []sdk.AccAddress) json.RawMessage {
 	stakeGenesis.Pool.LooseTokens = sdk.NewRat(1000)
 	genesis := GenesisState{
 		Accounts:  genesisAccounts,
+		BankData:  bank.NewGenesisState(supply),
 		StakeData: stakeGenesis,
+		MintData:  mint.DefaultGenesisState(),
 	}
 
 	// Marshal genesis
@@ -83,13 +89,12 @@ func TestFullGaiaSimulation(t *testing.T) {
 			stakesim.SimulateMsgEditValidator(app.stakeKeeper),
 			stakesim.SimulateMsgDelegate(app.accountMapper, app.stakeKeeper),
 			stakesim.SimulateMsgBeginUnbonding(app.accountMapper, app.stakeKeeper),
-			stakesim.SimulateMsgCompleteUnbonding(app.stakeKeeper),
 			stakesim.SimulateMsgBeginRedelegate(app.accountMapper, app.stakeKeeper),
-			stakesim.SimulateMsgCompleteRedelegate(app.stakeKeeper),
 		},
 		[]simulation.RandSetup{},
 		[]simulation.Invariant{
 			banksim.NonnegativeBalanceInvariant(app.accountMapper),
+			banksim.SupplyInvariant(app.accountMapper, app.supplyKeeper),
 			stakesim.AllInvariants(app.coinKeeper, app.stakeKeeper, app.accountMapper),
 		},
 		numKeys,
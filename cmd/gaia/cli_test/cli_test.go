@@ -3,6 +3,7 @@ package clitest
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -84,6 +85,56 @@ func TestGaiaCLISend(t *testing.T) {
 	require.Equal(t, int64(20), fooAcc.GetCoins().AmountOf("steak").Int64())
 }
 
+func TestGaiaCLISendBatch(t *testing.T) {
+	tests.ExecuteT(t, fmt.Sprintf("gaiad --home=%s unsafe_reset_all", gaiadHome))
+	executeWrite(t, fmt.Sprintf("gaiacli keys delete --home=%s foo", gaiacliHome), pass)
+	executeWrite(t, fmt.Sprintf("gaiacli keys delete --home=%s bar", gaiacliHome), pass)
+	executeWrite(t, fmt.Sprintf("gaiacli keys delete --home=%s baz", gaiacliHome), pass)
+	chainID := executeInit(t, fmt.Sprintf("gaiad init -o --name=foo --home=%s --home-client=%s", gaiadHome, gaiacliHome))
+	executeWrite(t, fmt.Sprintf("gaiacli keys add --home=%s bar", gaiacliHome), pass)
+	executeWrite(t, fmt.Sprintf("gaiacli keys add --home=%s baz", gaiacliHome), pass)
+
+	// get a free port, also setup some common flags
+	servAddr, port, err := server.FreeTCPAddr()
+	require.NoError(t, err)
+	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
+
+	// start gaiad server
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v", gaiadHome, servAddr))
+
+	defer proc.Stop(false)
+	tests.WaitForTMStart(port)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	fooAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show foo --output=json --home=%s", gaiacliHome))
+	barAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show bar --output=json --home=%s", gaiacliHome))
+	bazAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show baz --output=json --home=%s", gaiacliHome))
+
+	// pay three recipients, at most two per tx
+	batchFile, err := ioutil.TempFile("", "batch")
+	require.NoError(t, err)
+	defer os.Remove(batchFile.Name())
+	_, err = fmt.Fprintf(batchFile, "address,amount,memo\n%s,10steak,salary\n%s,5steak\n%s,1steak,bonus\n", barAddr, bazAddr, barAddr)
+	require.NoError(t, err)
+	require.NoError(t, batchFile.Close())
+
+	success := executeWrite(t, fmt.Sprintf("gaiacli send-batch %s %v --gas-per-output=90000 --from=foo", batchFile.Name(), flags), pass)
+	require.True(t, success)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	barAcc := executeGetAccount(t, fmt.Sprintf("gaiacli account %s %v", barAddr, flags))
+	require.Equal(t, int64(11), barAcc.GetCoins().AmountOf("steak").Int64())
+	bazAcc := executeGetAccount(t, fmt.Sprintf("gaiacli account %s %v", bazAddr, flags))
+	require.Equal(t, int64(5), bazAcc.GetCoins().AmountOf("steak").Int64())
+	fooAcc := executeGetAccount(t, fmt.Sprintf("gaiacli account %s %v", fooAddr, flags))
+	require.Equal(t, int64(34), fooAcc.GetCoins().AmountOf("steak").Int64())
+	require.Equal(t, int64(2), fooAcc.GetSequence())
+
+	// the whole batch is rejected if the sender cannot pay every recipient
+	success = executeWrite(t, fmt.Sprintf("gaiacli send-batch %s %v --from=bar", batchFile.Name(), flags), pass)
+	require.False(t, success)
+}
+
 func TestGaiaCLICreateValidator(t *testing.T) {
 	tests.ExecuteT(t, fmt.Sprintf("gaiad --home=%s unsafe_reset_all", gaiadHome))
 	executeWrite(t, fmt.Sprintf("gaiacli keys delete --home=%s foo", gaiacliHome), pass)
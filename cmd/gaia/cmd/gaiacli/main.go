@@ -13,8 +13,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	evidencecmd "github.com/cosmos/cosmos-sdk/x/evidence/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
+	mintcmd "github.com/cosmos/cosmos-sdk/x/mint/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
 
@@ -61,6 +63,10 @@ func main() {
 			ibccmd.IBCTransferCmd(cdc),
 			ibccmd.IBCRelayCmd(cdc),
 		)...)
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			ibccmd.GetCmdQueryDenomTrace("ibc", cdc),
+		)...)
 
 	advancedCmd := &cobra.Command{
 		Use:   "advanced",
@@ -88,6 +94,15 @@ func main() {
 			stakecmd.GetCmdQueryValidators("stake", cdc),
 			stakecmd.GetCmdQueryDelegation("stake", cdc),
 			stakecmd.GetCmdQueryDelegations("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegation("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryRedelegation("stake", cdc),
+			stakecmd.GetCmdQueryRedelegations("stake", cdc),
+			stakecmd.GetCmdQueryDelegatorSummary("stake", cdc),
+			stakecmd.GetCmdQueryDelegatorValidators("stake", cdc),
+			stakecmd.GetCmdQueryValidatorDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorRedelegations("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
 		)...)
 	stakeCmd.AddCommand(
@@ -97,12 +112,43 @@ func main() {
 			stakecmd.GetCmdDelegate(cdc),
 			stakecmd.GetCmdUnbond("stake", cdc),
 			stakecmd.GetCmdRedelegate("stake", cdc),
-			slashingcmd.GetCmdUnrevoke(cdc),
+			slashingcmd.GetCmdUnjail(cdc),
 		)...)
 	rootCmd.AddCommand(
 		stakeCmd,
 	)
 
+	//Add slashing commands
+	slashingCmd := &cobra.Command{
+		Use:   "slashing",
+		Short: "Slashing and liveness subcommands",
+	}
+	slashingCmd.AddCommand(
+		client.GetCommands(
+			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
+			slashingcmd.GetCmdQueryMissedBlocks("slashing", "params", cdc),
+		)...)
+	rootCmd.AddCommand(
+		slashingCmd,
+	)
+
+	//Add evidence commands
+	evidenceCmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "Evidence of validator misbehaviour subcommands",
+	}
+	evidenceCmd.AddCommand(
+		client.GetCommands(
+			evidencecmd.GetCmdQueryEvidence("evidence", cdc),
+		)...)
+	evidenceCmd.AddCommand(
+		client.PostCommands(
+			evidencecmd.GetCmdSubmitEvidence(cdc),
+		)...)
+	rootCmd.AddCommand(
+		evidenceCmd,
+	)
+
 	//Add stake commands
 	govCmd := &cobra.Command{
 		Use:   "gov",
@@ -124,6 +170,21 @@ func main() {
 		govCmd,
 	)
 
+	//Add query commands
+	queryCmd := &cobra.Command{
+		Use:   "query",
+		Short: "Querying subcommands",
+	}
+	queryCmd.AddCommand(
+		client.GetCommands(
+			bankcmd.GetSupplyCmd("supply", cdc),
+			mintcmd.GetCmdQueryInflation("mint", cdc),
+			mintcmd.GetCmdQueryAnnualProvisions("mint", cdc),
+		)...)
+	rootCmd.AddCommand(
+		queryCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
@@ -132,11 +193,12 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.SendBatchTxCmd(cdc),
 		)...)
 
 	// add proxy, version and key info
 	rootCmd.AddCommand(
-		keys.Commands(),
+		keys.Commands(authcmd.GetCoinsQuerier("acc", authcmd.GetAccountDecoder(cdc))),
 		client.LineBreak,
 		version.VersionCmd,
 	)
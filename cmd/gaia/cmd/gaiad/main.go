@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 
@@ -19,6 +21,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
+const (
+	flagInvCheckPeriod         = "inv-check-period"
+	flagHaltOnInvariantFailure = "halt-on-invariant-failure"
+)
+
 func main() {
 	cdc := app.MakeCodec()
 	ctx := server.NewDefaultContext()
@@ -32,6 +39,12 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, app.GaiaAppInit(),
 		server.ConstructAppCreator(newApp, "gaia"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "gaia"))
+	rootCmd.AddCommand(checkInvariantsCmd(ctx))
+
+	rootCmd.PersistentFlags().Uint(flagInvCheckPeriod, 0,
+		"Assert the invariants of the modules every this many blocks, 0 disables the checks")
+	rootCmd.PersistentFlags().Bool(flagHaltOnInvariantFailure, false,
+		"Halt the node when a periodic invariant check fails instead of only logging it")
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -43,7 +56,9 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(logger, db, traceStore, baseapp.SetPruning(viper.GetString("pruning")))
+	gApp := app.NewGaiaApp(logger, db, traceStore, baseapp.SetPruning(viper.GetString("pruning")))
+	gApp.SetInvariantCheck(uint(viper.GetInt(flagInvCheckPeriod)), viper.GetBool(flagHaltOnInvariantFailure))
+	return gApp
 }
 
 func exportAppStateAndTMValidators(
@@ -52,3 +67,27 @@ func exportAppStateAndTMValidators(
 	gApp := app.NewGaiaApp(logger, db, traceStore)
 	return gApp.ExportAppStateAndValidators()
 }
+
+// checkInvariantsCmd asserts the invariants of the modules against the state
+// last committed by the node, which must not be running
+func checkInvariantsCmd(ctx *server.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-invariants",
+		Short: "Assert the invariants of the modules against the on-disk state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir := filepath.Join(viper.GetString(cli.HomeFlag), "data")
+			db, err := dbm.NewGoLevelDB("gaia", dataDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			gApp := app.NewGaiaApp(ctx.Logger, db, nil)
+			if err := gApp.AssertInvariants(); err != nil {
+				return err
+			}
+			fmt.Printf("All invariants hold at height %d\n", gApp.LastBlockHeight())
+			return nil
+		},
+	}
+}
@@ -16,6 +16,7 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -23,7 +24,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
@@ -64,7 +67,7 @@ func runHackCmd(cmd *cobra.Command, args []string) error {
 	// The following powerKey was there, but the corresponding "trouble" validator did not exist.
 	// So here we do a binary search on the past states to find when the powerKey first showed up ...
 
-	// owner of the validator the bonds, gets revoked, later unbonds, and then later is still found in the bypower store
+	// owner of the validator the bonds, gets jailed, later unbonds, and then later is still found in the bypower store
 	trouble := hexToBytes("D3DC0FF59F7C3B548B7AFA365561B87FD0208AF8")
 	// this is his "bypower" key
 	powerKey := hexToBytes("05303030303030303030303033FFFFFFFFFFFF4C0C0000FFFED3DC0FF59F7C3B548B7AFA365561B87FD0208AF8")
@@ -133,16 +136,22 @@ type GaiaApp struct {
 	keyAccount  *sdk.KVStoreKey
 	keyIBC      *sdk.KVStoreKey
 	keyStake    *sdk.KVStoreKey
+	keyMint     *sdk.KVStoreKey
 	keySlashing *sdk.KVStoreKey
+	keyEvidence *sdk.KVStoreKey
 	keyParams   *sdk.KVStoreKey
+	keySupply   *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
 	feeCollectionKeeper auth.FeeCollectionKeeper
 	coinKeeper          bank.Keeper
+	supplyKeeper        bank.SupplyKeeper
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
+	mintKeeper          mint.Keeper
 	slashingKeeper      slashing.Keeper
+	evidenceKeeper      evidence.Keeper
 	paramsKeeper        params.Keeper
 }
 
@@ -160,8 +169,11 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 		keyAccount:  sdk.NewKVStoreKey("acc"),
 		keyIBC:      sdk.NewKVStoreKey("ibc"),
 		keyStake:    sdk.NewKVStoreKey("stake"),
+		keyMint:     sdk.NewKVStoreKey("mint"),
 		keySlashing: sdk.NewKVStoreKey("slashing"),
+		keyEvidence: sdk.NewKVStoreKey("evidence"),
 		keyParams:   sdk.NewKVStoreKey("params"),
+		keySupply:   sdk.NewKVStoreKey("supply"),
 	}
 
 	// define the accountMapper
@@ -172,16 +184,34 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 	)
 
 	// add handlers
-	app.coinKeeper = bank.NewKeeper(app.accountMapper)
-	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
 	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
+	bank.RegisterParamTypes(app.paramsKeeper)
+	app.coinKeeper = bank.NewKeeper(app.accountMapper, app.paramsKeeper.Getter(), gaia.BlockedAddrs())
+	app.supplyKeeper = bank.NewSupplyKeeper(app.cdc, app.keySupply, app.coinKeeper, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+		mint.ModuleName:       {auth.Minter},
+		ibc.ModuleName:        {auth.Minter, auth.Burner},
+	})
+	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	app.ibcMapper.BindPort(ibc.TransferPort, ibc.NewTransferModule(app.ibcMapper, app.coinKeeper, app.supplyKeeper))
+	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.supplyKeeper, app.RegisterCodespace(stake.DefaultCodespace))
+	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint, app.stakeKeeper, app.coinKeeper, app.supplyKeeper)
 	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
 
+	app.evidenceKeeper = evidence.NewKeeper(app.cdc, app.keyEvidence, app.stakeKeeper, app.slashingKeeper.MaxEvidenceAge, app.RegisterCodespace(evidence.DefaultCodespace))
+
+	// register the staking hooks of the modules following validators
+	app.stakeKeeper.RegisterHooks(app.slashingKeeper.Hooks())
+
+	// register the handlers of the evidence types
+	app.evidenceKeeper.RegisterHandler(tmtypes.ABCIEvidenceTypeDuplicateVote, app.slashingKeeper.HandleDoubleSign, app.slashingKeeper.DoubleSignPolicy())
+	app.evidenceKeeper.RegisterHandler(ibc.MisbehaviourEvidenceType, app.ibcMapper.MisbehaviourHandler(app.stakeKeeper, app.slashingKeeper.MaxEvidenceAge), ibc.MisbehaviourPolicy())
+
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper))
 
 	// initialize BaseApp
@@ -189,7 +219,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keyMint, app.keySlashing, app.keyEvidence, app.keyParams, app.keySupply)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -205,6 +235,7 @@ func MakeCodec() *wire.Codec {
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
+	evidence.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
@@ -214,7 +245,11 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	mint.BeginBlocker(ctx, app.mintKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+	evidenceTags := evidence.BeginBlocker(ctx, req, app.evidenceKeeper)
+	tags = tags.AppendTags(evidenceTags)
 
 	return abci.ResponseBeginBlock{
 		Tags: tags.ToKVPairs(),
@@ -224,10 +259,11 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
+	validatorUpdates, tags := stake.EndBlocker(ctx, app.stakeKeeper)
 
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
+		Tags:             tags,
 	}
 }
 
@@ -244,16 +280,25 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
-		acc := gacc.ToAccount()
+		acc := gacc.ToAccountI()
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// load the total supply
+	bank.InitGenesis(ctx, app.supplyKeeper, genesisState.BankData)
+
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 	if err != nil {
 		panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468 // return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
 
+	// load the minter and mint params
+	err = mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+	if err != nil {
+		panic(err)
+	}
+
 	return abci.ResponseInitChain{
 		Validators: validators,
 	}
@@ -62,6 +62,53 @@ func NewFundraiserParams(account uint32, addressIdx uint32) *BIP44Params {
 	return NewParams(44, 118, account, false, addressIdx)
 }
 
+// NewParamsFromPath parses a BIP 44 path of the form
+// [m/] purpose' / coin_type' / account' / change / address_index
+// such as 44'/118'/0'/0/0, as used by other wallets.
+func NewParamsFromPath(path string) (*BIP44Params, error) {
+	spl := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	if len(spl) != 5 {
+		return nil, fmt.Errorf("path length is wrong. Expected 5, got %d", len(spl))
+	}
+
+	// the purpose, coin type and account are hardened, the change and
+	// address index aren't
+	values := make([]uint32, len(spl))
+	for i, str := range spl {
+		hardened := strings.HasSuffix(str, "'")
+		if hardened != (i < 3) {
+			return nil, fmt.Errorf("unexpected hardening of level %d in path %s", i, path)
+		}
+		value, err := strconv.ParseUint(strings.TrimSuffix(str, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level %d in path %s: %s", i, path, err)
+		}
+		values[i] = uint32(value)
+	}
+	if values[3] > 1 {
+		return nil, fmt.Errorf("change must be 0 or 1, got %d", values[3])
+	}
+
+	return NewParams(values[0], values[1], values[2], values[3] == 1, values[4]), nil
+}
+
+// DerivationPath returns the levels of the path, in the form used by Ledger
+// devices: purpose, coin_type, account, change, address_index
+func (p BIP44Params) DerivationPath() []uint32 {
+	change := uint32(0)
+	if p.change {
+		change = 1
+	}
+	return []uint32{p.purpose, p.coinType, p.account, change, p.addressIdx}
+}
+
+// WithAddressIndex returns the params of the same account with another
+// address index
+func (p BIP44Params) WithAddressIndex(addressIdx uint32) BIP44Params {
+	p.addressIdx = addressIdx
+	return p
+}
+
 func (p BIP44Params) String() string {
 	var changeStr string
 	if p.change {
@@ -3,7 +3,10 @@ package hd
 import (
 	"encoding/hex"
 	"fmt"
+	"testing"
+
 	"github.com/cosmos/cosmos-sdk/crypto/keys/bip39"
+	"github.com/stretchr/testify/require"
 )
 
 //nolint
@@ -13,6 +16,38 @@ func ExampleStringifyPathParams() {
 	// Output: 44'/0'/0'/0/0
 }
 
+func TestNewParamsFromPath(t *testing.T) {
+	tests := []struct {
+		path       string
+		expected   *BIP44Params
+		expectPass bool
+	}{
+		{FullFundraiserPath, NewFundraiserParams(0, 0), true},
+		{"m/44'/60'/1'/1/7", NewParams(44, 60, 1, true, 7), true},
+		{"44'/118'/0'/0", nil, false},
+		{"44'/118'/0'/0/0/0", nil, false},
+		{"44/118'/0'/0/0", nil, false},
+		{"44'/118'/0'/0'/0", nil, false},
+		{"44'/118'/0'/2/0", nil, false},
+		{"44'/118'/x'/0/0", nil, false},
+		{"44'/118'/2147483648'/0/0", nil, false},
+	}
+
+	for _, tc := range tests {
+		params, err := NewParamsFromPath(tc.path)
+		if tc.expectPass {
+			require.Nil(t, err, tc.path)
+			require.Equal(t, tc.expected, params, tc.path)
+		} else {
+			require.NotNil(t, err, tc.path)
+		}
+	}
+
+	params, _ := NewParamsFromPath("44'/60'/1'/1/7")
+	require.Equal(t, []uint32{44, 60, 1, 1, 7}, params.DerivationPath())
+	require.Equal(t, "44'/60'/1'/1/9", params.WithAddressIndex(9).String())
+}
+
 //nolint
 func ExampleSomeBIP32TestVecs() {
 
@@ -14,7 +14,7 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
-var _ Keybase = dbKeybase{}
+var _ Keybase = keybase{}
 
 // Language is a language to create the BIP 39 mnemonic in.
 // Currently, only english is supported though.
@@ -51,26 +51,38 @@ var (
 	ErrUnsupportedLanguage = errors.New("unsupported language: only english is supported")
 )
 
-// dbKeybase combines encryption and storage implementation to provide
+// keybase combines encryption and storage implementation to provide
 // a full-featured key manager
-type dbKeybase struct {
-	db dbm.DB
+type keybase struct {
+	keyring Keyring
 }
 
 // New creates a new keybase instance using the passed DB for reading and writing keys.
 func New(db dbm.DB) Keybase {
-	return dbKeybase{
-		db: db,
+	return NewWithKeyring(NewDBKeyring(db))
+}
+
+// NewWithKeyring creates a new keybase instance storing the keys in the
+// keyring backend.
+func NewWithKeyring(keyring Keyring) Keybase {
+	return keybase{
+		keyring: keyring,
 	}
 }
 
+// NewInMemory creates a new keybase instance storing the keys in memory, they
+// are lost when it is discarded.
+func NewInMemory() Keybase {
+	return NewWithKeyring(NewMemKeyring())
+}
+
 // CreateMnemonic generates a new key and persists it to storage, encrypted
 // using the provided password.
 // It returns the generated mnemonic and the key Info.
 // It returns an error if it fails to
 // generate a key for the given algo type, or if another key is
 // already stored under the same name.
-func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, mnemonic string, err error) {
+func (kb keybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, mnemonic string, err error) {
 	if language != English {
 		return nil, "", ErrUnsupportedLanguage
 	}
@@ -91,7 +103,7 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 }
 
 // TEMPORARY METHOD UNTIL WE FIGURE OUT USER FACING HD DERIVATION API
-func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err error) {
+func (kb keybase) CreateKey(name, mnemonic, passwd string) (info Info, err error) {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 && len(words) != 24 {
 		err = fmt.Errorf("recovering only works with 12 word (fundraiser) or 24 word mnemonics, got: %v words", len(words))
@@ -108,7 +120,7 @@ func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err err
 // CreateFundraiserKey converts a mnemonic to a private key and persists it,
 // encrypted with the given password.
 // TODO(ismail)
-func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Info, err error) {
+func (kb keybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Info, err error) {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 {
 		err = fmt.Errorf("recovering only works with 12 word (fundraiser), got: %v words", len(words))
@@ -122,7 +134,7 @@ func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Inf
 	return
 }
 
-func (kb dbKeybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params) (info Info, err error) {
+func (kb keybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params) (info Info, err error) {
 	seed, err := bip39.MnemonicToSeedWithErrChecking(mnemonic)
 	if err != nil {
 		return
@@ -134,7 +146,7 @@ func (kb dbKeybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params)
 
 // CreateLedger creates a new locally-stored reference to a Ledger keypair
 // It returns the created key info and an error if the Ledger could not be queried
-func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
+func (kb keybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
 	if algo != Secp256k1 {
 		return nil, ErrUnsupportedSigningAlgo
 	}
@@ -143,16 +155,16 @@ func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo S
 		return nil, err
 	}
 	pub := priv.PubKey()
-	return kb.writeLedgerKey(pub, path, name), nil
+	return kb.writeLedgerKey(pub, path, name)
 }
 
 // CreateOffline creates a new reference to an offline keypair
 // It returns the created key info
-func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error) {
-	return kb.writeOfflineKey(pub, name), nil
+func (kb keybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error) {
+	return kb.writeOfflineKey(pub, name)
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
+func (kb *keybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
 	// create master key and derive first key:
 	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
 	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
@@ -163,21 +175,21 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 	// if we have a password, use it to encrypt the private key and store it
 	// else store the public key only
 	if passwd != "" {
-		info = kb.writeLocalKey(tmcrypto.PrivKeySecp256k1(derivedPriv), name, passwd)
-	} else {
-		pubk := tmcrypto.PrivKeySecp256k1(derivedPriv).PubKey()
-		info = kb.writeOfflineKey(pubk, name)
+		return kb.writeLocalKey(tmcrypto.PrivKeySecp256k1(derivedPriv), name, passwd)
 	}
-	return
+	pubk := tmcrypto.PrivKeySecp256k1(derivedPriv).PubKey()
+	return kb.writeOfflineKey(pubk, name)
 }
 
 // List returns the keys from storage in alphabetical order.
-func (kb dbKeybase) List() ([]Info, error) {
+func (kb keybase) List() ([]Info, error) {
 	var res []Info
-	iter := kb.db.Iterator(nil, nil)
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		info, err := readInfo(iter.Value())
+	infos, err := kb.keyring.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, bz := range infos {
+		info, err := readInfo(bz)
 		if err != nil {
 			return nil, err
 		}
@@ -187,8 +199,11 @@ func (kb dbKeybase) List() ([]Info, error) {
 }
 
 // Get returns the public information about one key.
-func (kb dbKeybase) Get(name string) (Info, error) {
-	bs := kb.db.Get(infoKey(name))
+func (kb keybase) Get(name string) (Info, error) {
+	bs, err := kb.keyring.Get(name)
+	if err != nil {
+		return nil, err
+	}
 	if len(bs) == 0 {
 		return nil, fmt.Errorf("Key %s not found", name)
 	}
@@ -197,7 +212,7 @@ func (kb dbKeybase) Get(name string) (Info, error) {
 
 // Sign signs the msg with the named key.
 // It returns an error if the key doesn't exist or the decryption fails.
-func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig tmcrypto.Signature, pub tmcrypto.PubKey, err error) {
+func (kb keybase) Sign(name, passphrase string, msg []byte) (sig tmcrypto.Signature, pub tmcrypto.PubKey, err error) {
 	info, err := kb.Get(name)
 	if err != nil {
 		return
@@ -241,7 +256,7 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig tmcrypto.Sign
 	return sig, pub, nil
 }
 
-func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcrypto.PrivKey, error) {
+func (kb keybase) ExportPrivateKeyObject(name string, passphrase string) (tmcrypto.PrivKey, error) {
 	info, err := kb.Get(name)
 	if err != nil {
 		return nil, err
@@ -266,8 +281,11 @@ func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcr
 	return priv, nil
 }
 
-func (kb dbKeybase) Export(name string) (armor string, err error) {
-	bz := kb.db.Get(infoKey(name))
+func (kb keybase) Export(name string) (armor string, err error) {
+	bz, err := kb.keyring.Get(name)
+	if err != nil {
+		return
+	}
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
@@ -277,8 +295,11 @@ func (kb dbKeybase) Export(name string) (armor string, err error) {
 // ExportPubKey returns public keys in ASCII armored format.
 // Retrieve a Info object by its name and return the public key in
 // a portable format.
-func (kb dbKeybase) ExportPubKey(name string) (armor string, err error) {
-	bz := kb.db.Get(infoKey(name))
+func (kb keybase) ExportPubKey(name string) (armor string, err error) {
+	bz, err := kb.keyring.Get(name)
+	if err != nil {
+		return
+	}
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
@@ -289,8 +310,11 @@ func (kb dbKeybase) ExportPubKey(name string) (armor string, err error) {
 	return armorPubKeyBytes(info.GetPubKey().Bytes()), nil
 }
 
-func (kb dbKeybase) Import(name string, armor string) (err error) {
-	bz := kb.db.Get(infoKey(name))
+func (kb keybase) Import(name string, armor string) (err error) {
+	bz, err := kb.keyring.Get(name)
+	if err != nil {
+		return
+	}
 	if len(bz) > 0 {
 		return errors.New("Cannot overwrite data for name " + name)
 	}
@@ -298,15 +322,17 @@ func (kb dbKeybase) Import(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.db.Set(infoKey(name), infoBytes)
-	return nil
+	return kb.keyring.Set(name, infoBytes)
 }
 
 // ImportPubKey imports ASCII-armored public keys.
 // Store a new Info object holding a public key only, i.e. it will
 // not be possible to sign with it as it lacks the secret key.
-func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
-	bz := kb.db.Get(infoKey(name))
+func (kb keybase) ImportPubKey(name string, armor string) (err error) {
+	bz, err := kb.keyring.Get(name)
+	if err != nil {
+		return
+	}
 	if len(bz) > 0 {
 		return errors.New("Cannot overwrite data for name " + name)
 	}
@@ -318,15 +344,28 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.writeOfflineKey(pubKey, name)
+	_, err = kb.writeOfflineKey(pubKey, name)
 	return
 }
 
+// ImportPrivKey stores a private key as a local key, encrypted with the
+// passphrase. It is used to import private keys exported by other wallets.
+func (kb keybase) ImportPrivKey(name string, priv tmcrypto.PrivKey, passphrase string) (Info, error) {
+	bz, err := kb.keyring.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > 0 {
+		return nil, errors.New("Cannot overwrite data for name " + name)
+	}
+	return kb.writeLocalKey(priv, name, passphrase)
+}
+
 // Delete removes key forever, but we must present the
 // proper passphrase before deleting it (for security).
 // A passphrase of 'yes' is used to delete stored
 // references to offline and Ledger / HW wallet keys
-func (kb dbKeybase) Delete(name, passphrase string) error {
+func (kb keybase) Delete(name, passphrase string) error {
 	// verify we have the proper password before deleting
 	info, err := kb.Get(name)
 	if err != nil {
@@ -339,15 +378,13 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 		if err != nil {
 			return err
 		}
-		kb.db.DeleteSync(infoKey(name))
-		return nil
+		return kb.keyring.Delete(name)
 	case ledgerInfo:
 	case offlineInfo:
 		if passphrase != "yes" {
 			return fmt.Errorf("enter 'yes' exactly to delete the key - this cannot be undone")
 		}
-		kb.db.DeleteSync(infoKey(name))
-		return nil
+		return kb.keyring.Delete(name)
 	}
 	return nil
 }
@@ -358,7 +395,7 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 // oldpass must be the current passphrase used for encryption,
 // getNewpass is a function to get the passphrase to permanently replace
 // the current passphrase
-func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
+func (kb keybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
 	info, err := kb.Get(name)
 	if err != nil {
 		return err
@@ -374,40 +411,33 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 		if err != nil {
 			return err
 		}
-		kb.writeLocalKey(key, name, newpass)
-		return nil
+		_, err = kb.writeLocalKey(key, name, newpass)
+		return err
 	default:
 		return fmt.Errorf("locally stored key required")
 	}
 }
 
-func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) Info {
+func (kb keybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) (Info, error) {
 	// encrypt private key using passphrase
 	privArmor := encryptArmorPrivKey(priv, passphrase)
 	// make Info
 	pub := priv.PubKey()
 	info := newLocalInfo(name, pub, privArmor)
-	kb.writeInfo(info, name)
-	return info
+	return info, kb.writeInfo(info, name)
 }
 
-func (kb dbKeybase) writeLedgerKey(pub tmcrypto.PubKey, path crypto.DerivationPath, name string) Info {
+func (kb keybase) writeLedgerKey(pub tmcrypto.PubKey, path crypto.DerivationPath, name string) (Info, error) {
 	info := newLedgerInfo(name, pub, path)
-	kb.writeInfo(info, name)
-	return info
+	return info, kb.writeInfo(info, name)
 }
 
-func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) Info {
+func (kb keybase) writeOfflineKey(pub tmcrypto.PubKey, name string) (Info, error) {
 	info := newOfflineInfo(name, pub)
-	kb.writeInfo(info, name)
-	return info
+	return info, kb.writeInfo(info, name)
 }
 
-func (kb dbKeybase) writeInfo(info Info, name string) {
+func (kb keybase) writeInfo(info Info, name string) error {
 	// write the info by key
-	kb.db.SetSync(infoKey(name), writeInfo(info))
-}
-
-func infoKey(name string) []byte {
-	return []byte(fmt.Sprintf("%s.info", name))
+	return kb.keyring.Set(name, writeInfo(info))
 }
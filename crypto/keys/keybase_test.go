@@ -382,3 +382,21 @@ func ExampleNew() {
 	// Carl
 	// signed by Bob
 }
+
+func TestImportPrivKey(t *testing.T) {
+	cstore := NewInMemory()
+	priv := crypto.GenPrivKeySecp256k1()
+
+	info, err := cstore.ImportPrivKey("john", priv, "secretcpw")
+	require.NoError(t, err)
+	require.Equal(t, "local", info.GetType())
+	require.Equal(t, priv.PubKey(), info.GetPubKey())
+
+	exported, err := cstore.ExportPrivateKeyObject("john", "secretcpw")
+	require.NoError(t, err)
+	require.Equal(t, priv, exported)
+
+	// keys cannot be overwritten
+	_, err = cstore.ImportPrivKey("john", crypto.GenPrivKeySecp256k1(), "secretcpw")
+	require.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// Keyring is the storage backend of a keybase. It stores the encoded info of
+// each key by name, the private key of a local key is encrypted by the
+// keybase with the passphrase of the key before it is stored.
+type Keyring interface {
+	// Get returns the encoded info of a key, or nil if there is no key with
+	// the name
+	Get(name string) ([]byte, error)
+	// Set stores the encoded info of a key, overwriting any key with the name
+	Set(name string, bz []byte) error
+	// Delete removes a key
+	Delete(name string) error
+	// List returns the encoded info of all the keys, ordered by name
+	List() ([][]byte, error)
+}
+
+var _ Keyring = dbKeyring{}
+var _ Keyring = memKeyring{}
+var _ Keyring = &fileKeyring{}
+
+//__________________________________________________________________
+
+// dbKeyring stores the keys in a database, such as LevelDB
+type dbKeyring struct {
+	db dbm.DB
+}
+
+// NewDBKeyring returns a keyring storing the keys in the db
+func NewDBKeyring(db dbm.DB) Keyring {
+	return dbKeyring{db: db}
+}
+
+func (kr dbKeyring) Get(name string) ([]byte, error) {
+	return kr.db.Get(infoKey(name)), nil
+}
+
+func (kr dbKeyring) Set(name string, bz []byte) error {
+	kr.db.SetSync(infoKey(name), bz)
+	return nil
+}
+
+func (kr dbKeyring) Delete(name string) error {
+	kr.db.DeleteSync(infoKey(name))
+	return nil
+}
+
+func (kr dbKeyring) List() ([][]byte, error) {
+	var res [][]byte
+	iter := kr.db.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		res = append(res, iter.Value())
+	}
+	return res, nil
+}
+
+func infoKey(name string) []byte {
+	return []byte(fmt.Sprintf("%s.info", name))
+}
+
+//__________________________________________________________________
+
+// memKeyring stores the keys in memory, they are lost when it is discarded.
+// It is meant for tests.
+type memKeyring struct {
+	keys map[string][]byte
+}
+
+// NewMemKeyring returns an empty in-memory keyring
+func NewMemKeyring() Keyring {
+	return memKeyring{keys: make(map[string][]byte)}
+}
+
+func (kr memKeyring) Get(name string) ([]byte, error) {
+	return kr.keys[name], nil
+}
+
+func (kr memKeyring) Set(name string, bz []byte) error {
+	kr.keys[name] = bz
+	return nil
+}
+
+func (kr memKeyring) Delete(name string) error {
+	delete(kr.keys, name)
+	return nil
+}
+
+func (kr memKeyring) List() ([][]byte, error) {
+	names := make([]string, 0, len(kr.keys))
+	for name := range kr.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([][]byte, len(names))
+	for i, name := range names {
+		res[i] = kr.keys[name]
+	}
+	return res, nil
+}
+
+//__________________________________________________________________
+
+const (
+	blockTypeKeyringFile  = "TENDERMINT KEYRING FILE"
+	keyringHashFile       = "keyhash"
+	keyringFileExt        = ".info"
+	keyringHashPlaintext  = "cosmos-sdk keyring"
+	keyringDirPermissions = 0700
+	keyringFilePermission = 0600
+)
+
+// KeyringPassphrase returns the passphrase of a file keyring, create is true
+// if the keyring is new and the passphrase must be chosen
+type KeyringPassphrase func(create bool) (string, error)
+
+// fileKeyring stores each key in its own file of a directory. The files are
+// encrypted with a key derived from the passphrase of the keyring, which is
+// asked the first time the keyring is read or written.
+type fileKeyring struct {
+	dir        string
+	passphrase KeyringPassphrase
+
+	// symmetric key of the files, derived from the passphrase
+	key []byte
+}
+
+// NewFileKeyring returns a keyring storing the keys in encrypted files in dir
+func NewFileKeyring(dir string, passphrase KeyringPassphrase) Keyring {
+	return &fileKeyring{
+		dir:        dir,
+		passphrase: passphrase,
+	}
+}
+
+// unlock derives the key of the files from the passphrase, the keyring is
+// created with the passphrase if it doesn't exist yet. The passphrase is
+// checked against the keyhash file of the keyring.
+func (kr *fileKeyring) unlock() error {
+	if kr.key != nil {
+		return nil
+	}
+	err := os.MkdirAll(kr.dir, keyringDirPermissions)
+	if err != nil {
+		return err
+	}
+
+	hashPath := filepath.Join(kr.dir, keyringHashFile)
+	armorBz, err := ioutil.ReadFile(hashPath)
+	if os.IsNotExist(err) {
+		passphrase, err := kr.passphrase(true)
+		if err != nil {
+			return err
+		}
+		salt, encBytes := encryptBytes([]byte(keyringHashPlaintext), passphrase)
+		err = ioutil.WriteFile(hashPath, []byte(armorEncryptedBytes(blockTypeKeyringFile, salt, encBytes)), keyringFilePermission)
+		if err != nil {
+			return err
+		}
+		kr.key = deriveSymmetricKey(salt, passphrase)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := kr.passphrase(false)
+	if err != nil {
+		return err
+	}
+	salt, encBytes, err := unarmorEncryptedBytes(blockTypeKeyringFile, string(armorBz))
+	if err != nil {
+		return err
+	}
+	key := deriveSymmetricKey(salt, passphrase)
+	plaintext, err := tmcrypto.DecryptSymmetric(encBytes, key)
+	if err != nil || string(plaintext) != keyringHashPlaintext {
+		return errors.New("invalid keyring passphrase")
+	}
+	kr.key = key
+	return nil
+}
+
+// the files are named by the hex of the name of their key, so that any key
+// name is a valid file name
+func (kr *fileKeyring) filename(name string) string {
+	return filepath.Join(kr.dir, hex.EncodeToString([]byte(name))+keyringFileExt)
+}
+
+func (kr *fileKeyring) Get(name string) ([]byte, error) {
+	if err := kr.unlock(); err != nil {
+		return nil, err
+	}
+	return kr.readFile(kr.filename(name))
+}
+
+func (kr *fileKeyring) Set(name string, bz []byte) error {
+	if err := kr.unlock(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(kr.filename(name), tmcrypto.EncryptSymmetric(bz, kr.key), keyringFilePermission)
+}
+
+func (kr *fileKeyring) Delete(name string) error {
+	if err := kr.unlock(); err != nil {
+		return err
+	}
+	err := os.Remove(kr.filename(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (kr *fileKeyring) List() ([][]byte, error) {
+	if err := kr.unlock(); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(kr.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the files are listed sorted by name, which sorts the keys by name
+	// as the hex encoding preserves the order
+	var res [][]byte
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), keyringFileExt) {
+			continue
+		}
+		bz, err := kr.readFile(filepath.Join(kr.dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, bz)
+	}
+	return res, nil
+}
+
+func (kr *fileKeyring) readFile(path string) ([]byte, error) {
+	encBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bz, err := tmcrypto.DecryptSymmetric(encBytes, kr.key)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting %s: %v", path, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,120 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func testKeyring(t *testing.T, kr Keyring) {
+	bz, err := kr.Get("missing")
+	require.NoError(t, err)
+	require.Nil(t, bz)
+	l, err := kr.List()
+	require.NoError(t, err)
+	require.Empty(t, l)
+
+	// keys are listed by name
+	require.NoError(t, kr.Set("john", []byte("john-info")))
+	require.NoError(t, kr.Set("alice", []byte("alice-info")))
+	require.NoError(t, kr.Set("john", []byte("john-info-2")))
+	bz, err = kr.Get("john")
+	require.NoError(t, err)
+	require.Equal(t, []byte("john-info-2"), bz)
+	l, err = kr.List()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("alice-info"), []byte("john-info-2")}, l)
+
+	require.NoError(t, kr.Delete("john"))
+	require.NoError(t, kr.Delete("john"))
+	bz, err = kr.Get("john")
+	require.NoError(t, err)
+	require.Nil(t, bz)
+	l, err = kr.List()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("alice-info")}, l)
+}
+
+func TestDBKeyring(t *testing.T) {
+	testKeyring(t, NewDBKeyring(dbm.NewMemDB()))
+}
+
+func TestMemKeyring(t *testing.T) {
+	testKeyring(t, NewMemKeyring())
+}
+
+func TestFileKeyring(t *testing.T) {
+	BcryptSecurityParameter = 1
+	defer func() { BcryptSecurityParameter = 12 }()
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var prompts []bool
+	passphrase := func(pass string) KeyringPassphrase {
+		return func(create bool) (string, error) {
+			prompts = append(prompts, create)
+			return pass, nil
+		}
+	}
+
+	// the passphrase is chosen when the keyring is first used, and only
+	// asked once
+	kr := NewFileKeyring(dir, passphrase("12345678"))
+	require.Empty(t, prompts)
+	testKeyring(t, kr)
+	require.Equal(t, []bool{true}, prompts)
+
+	// the files are encrypted
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, file := range files {
+		bz, err := ioutil.ReadFile(dir + "/" + file.Name())
+		require.NoError(t, err)
+		require.NotContains(t, string(bz), "alice-info")
+	}
+
+	// the keys are read by another keyring with the same passphrase
+	bz, err := NewFileKeyring(dir, passphrase("12345678")).Get("alice")
+	require.NoError(t, err)
+	require.Equal(t, []byte("alice-info"), bz)
+	require.Equal(t, []bool{true, false}, prompts)
+
+	// a wrong passphrase is rejected
+	_, err = NewFileKeyring(dir, passphrase("87654321")).Get("alice")
+	require.Error(t, err)
+	_, err = NewFileKeyring(dir, passphrase("87654321")).List()
+	require.Error(t, err)
+}
+
+func TestKeybaseWithFileKeyring(t *testing.T) {
+	BcryptSecurityParameter = 1
+	defer func() { BcryptSecurityParameter = 12 }()
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	passphrase := func(bool) (string, error) { return "12345678", nil }
+
+	cstore := NewWithKeyring(NewFileKeyring(dir, passphrase))
+	info, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+
+	// the key signs once reopened
+	cstore = NewWithKeyring(NewFileKeyring(dir, passphrase))
+	john, err := cstore.Get("john")
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), john.GetPubKey())
+	sig, pub, err := cstore.Sign("john", "secretcpw", []byte("msg"))
+	require.NoError(t, err)
+	require.True(t, pub.VerifyBytes([]byte("msg"), sig))
+
+	require.NoError(t, cstore.Delete("john", "secretcpw"))
+	l, err := cstore.List()
+	require.NoError(t, err)
+	require.Empty(t, l)
+}
@@ -0,0 +1,264 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// The private keys of other wallets are imported and exported unencrypted, as
+// hex, or in the keystore JSON format of the Web3 Secret Storage definition:
+// https://github.com/ethereum/wiki/wiki/Web3-Secret-Storage-Definition
+// Both formats only hold secp256k1 private keys.
+
+// Make the scrypt cost parameter of exported keystore files a var, so it can
+// be lowered in tests
+var KeystoreScryptN = 1 << 18
+
+const (
+	keystoreVersion   = 3
+	keystoreCipher    = "aes-128-ctr"
+	keystoreKDFScrypt = "scrypt"
+	keystoreKDFPBKDF2 = "pbkdf2"
+	keystoreScryptR   = 8
+	keystoreScryptP   = 1
+	keystoreKeyLen    = 32
+)
+
+// keystore JSON file
+type keystoreJSON struct {
+	Version int            `json:"version"`
+	ID      string         `json:"id"`
+	Address string         `json:"address,omitempty"`
+	Crypto  keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// ExportUnsafeHex returns the unencrypted hex of a secp256k1 private key
+func ExportUnsafeHex(priv tmcrypto.PrivKey) (string, error) {
+	secp, ok := priv.(tmcrypto.PrivKeySecp256k1)
+	if !ok {
+		return "", ErrUnsupportedSigningAlgo
+	}
+	return hex.EncodeToString(secp[:]), nil
+}
+
+// ImportUnsafeHex parses the unencrypted hex of a secp256k1 private key
+func ImportUnsafeHex(hexKey string) (tmcrypto.PrivKey, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) != 32 {
+		return nil, fmt.Errorf("expected a 32 bytes secp256k1 private key, got %d bytes", len(bz))
+	}
+	var priv tmcrypto.PrivKeySecp256k1
+	copy(priv[:], bz)
+	return priv, nil
+}
+
+// ExportKeystoreJSON encrypts a secp256k1 private key with the passphrase into
+// a keystore JSON file, using the scrypt key derivation function
+func ExportKeystoreJSON(priv tmcrypto.PrivKey, passphrase string) ([]byte, error) {
+	secp, ok := priv.(tmcrypto.PrivKeySecp256k1)
+	if !ok {
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	salt := tmcrypto.CRandBytes(32)
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, KeystoreScryptN, keystoreScryptR, keystoreScryptP, keystoreKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	iv := tmcrypto.CRandBytes(aes.BlockSize)
+	cipherText, err := aesCTRXOR(derivedKey[:16], secp[:], iv)
+	if err != nil {
+		return nil, err
+	}
+
+	id := tmcrypto.CRandBytes(16)
+	id[6] = (id[6] & 0x0f) | 0x40 // uuid version 4
+	id[8] = (id[8] & 0x3f) | 0x80 // uuid variant 10
+
+	return json.MarshalIndent(keystoreJSON{
+		Version: keystoreVersion,
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Address: keystoreAddress(secp),
+		Crypto: keystoreCrypto{
+			Cipher:       keystoreCipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          keystoreKDFScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     KeystoreScryptN,
+				"r":     keystoreScryptR,
+				"p":     keystoreScryptP,
+				"dklen": keystoreKeyLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derivedKey, cipherText)),
+		},
+	}, "", "  ")
+}
+
+// ImportKeystoreJSON decrypts the secp256k1 private key of a keystore JSON
+// file with the passphrase, the file may use the scrypt or pbkdf2 key
+// derivation function
+func ImportKeystoreJSON(bz []byte, passphrase string) (tmcrypto.PrivKey, error) {
+	var keystore keystoreJSON
+	err := json.Unmarshal(bz, &keystore)
+	if err != nil {
+		return nil, err
+	}
+	if keystore.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != keystoreCipher {
+		return nil, fmt.Errorf("unsupported keystore cipher %s", keystore.Crypto.Cipher)
+	}
+
+	derivedKey, err := keystoreDerivedKey(keystore.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(mac, keystoreMAC(derivedKey, cipherText)) {
+		return nil, errors.New("invalid keystore passphrase")
+	}
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := ImportUnsafeHex(hex.EncodeToString(plainText))
+	if err != nil {
+		return nil, err
+	}
+	if keystore.Address != "" {
+		address := keystoreAddress(priv.(tmcrypto.PrivKeySecp256k1))
+		if !strings.EqualFold(strings.TrimPrefix(keystore.Address, "0x"), address) {
+			return nil, errors.New("keystore address doesn't match its private key")
+		}
+	}
+	return priv, nil
+}
+
+// derive the key of a keystore from the passphrase, with its kdf and params
+func keystoreDerivedKey(params keystoreCrypto, passphrase string) ([]byte, error) {
+	getInt := func(name string) (int, error) {
+		value, ok := params.KDFParams[name].(float64)
+		if !ok {
+			return 0, fmt.Errorf("missing keystore kdf param %s", name)
+		}
+		return int(value), nil
+	}
+	saltHex, ok := params.KDFParams["salt"].(string)
+	if !ok {
+		return nil, errors.New("missing keystore kdf param salt")
+	}
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return nil, err
+	}
+	dkLen, err := getInt("dklen")
+	if err != nil {
+		return nil, err
+	}
+	if dkLen < keystoreKeyLen {
+		return nil, fmt.Errorf("keystore derived key length must be at least %d, got %d", keystoreKeyLen, dkLen)
+	}
+
+	switch params.KDF {
+	case keystoreKDFScrypt:
+		n, err := getInt("n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := getInt("r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := getInt("p")
+		if err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	case keystoreKDFPBKDF2:
+		if prf, _ := params.KDFParams["prf"].(string); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported keystore pbkdf2 prf %s", prf)
+		}
+		c, err := getInt("c")
+		if err != nil {
+			return nil, err
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, c, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported keystore kdf %s", params.KDF)
+	}
+}
+
+// the MAC of a keystore is the keccak256 of the second half of the derived
+// key followed by the cipher text
+func keystoreMAC(derivedKey []byte, cipherText []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	return hasher.Sum(nil)
+}
+
+// the address of a keystore is the last 20 bytes of the keccak256 of the
+// uncompressed public key, without its prefix byte
+func keystoreAddress(priv tmcrypto.PrivKeySecp256k1) string {
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), priv[:])
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(pub.SerializeUncompressed()[1:])
+	return hex.EncodeToString(hasher.Sum(nil)[12:])
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("keystore iv must be %d bytes", aes.BlockSize)
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
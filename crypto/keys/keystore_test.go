@@ -0,0 +1,71 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+func TestUnsafeHex(t *testing.T) {
+	priv := tmcrypto.GenPrivKeySecp256k1()
+	hexKey, err := ExportUnsafeHex(priv)
+	require.NoError(t, err)
+	require.Len(t, hexKey, 64)
+
+	imported, err := ImportUnsafeHex("0x" + hexKey + "\n")
+	require.NoError(t, err)
+	require.Equal(t, priv, imported)
+
+	_, err = ImportUnsafeHex(hexKey[:62])
+	require.Error(t, err)
+	_, err = ImportUnsafeHex("zz" + hexKey[2:])
+	require.Error(t, err)
+	_, err = ExportUnsafeHex(tmcrypto.GenPrivKeyEd25519())
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+}
+
+func TestKeystoreJSON(t *testing.T) {
+	KeystoreScryptN = 1 << 4
+	defer func() { KeystoreScryptN = 1 << 18 }()
+
+	priv := tmcrypto.GenPrivKeySecp256k1()
+	bz, err := ExportKeystoreJSON(priv, "12345678")
+	require.NoError(t, err)
+
+	imported, err := ImportKeystoreJSON(bz, "12345678")
+	require.NoError(t, err)
+	require.Equal(t, priv, imported)
+
+	_, err = ImportKeystoreJSON(bz, "87654321")
+	require.Error(t, err)
+	_, err = ExportKeystoreJSON(tmcrypto.GenPrivKeyEd25519(), "12345678")
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+}
+
+// test vector of the Web3 Secret Storage definition
+func TestImportKeystoreJSONPBKDF2(t *testing.T) {
+	keystore := `{
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+    "ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+    "kdf": "pbkdf2",
+    "kdfparams": {
+      "c": 262144,
+      "dklen": 32,
+      "prf": "hmac-sha256",
+      "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+    },
+    "mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+  },
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "version": 3
+}`
+	priv, err := ImportKeystoreJSON([]byte(keystore), "testpassword")
+	require.NoError(t, err)
+	hexKey, err := ExportUnsafeHex(priv)
+	require.NoError(t, err)
+	require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hexKey)
+	require.Equal(t, "008aeeda4d805471df9b2a5b0f38a0c3bcba786b", keystoreAddress(priv.(tmcrypto.PrivKeySecp256k1)))
+}
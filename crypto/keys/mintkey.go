@@ -73,54 +73,74 @@ func unarmorBytes(armorStr, blockType string) (bz []byte, err error) {
 
 func encryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) string {
 	saltBytes, encBytes := encryptPrivKey(privKey, passphrase)
+	return armorEncryptedBytes(blockTypePrivKey, saltBytes, encBytes)
+}
+
+func unarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey, error) {
+	var privKey crypto.PrivKey
+	saltBytes, encBytes, err := unarmorEncryptedBytes(blockTypePrivKey, armorStr)
+	if err != nil {
+		return privKey, err
+	}
+	privKey, err = decryptPrivKey(saltBytes, encBytes, passphrase)
+	return privKey, err
+}
+
+// armor bytes encrypted with a key derived from a passphrase by bcrypt
+func armorEncryptedBytes(blockType string, saltBytes []byte, encBytes []byte) string {
 	header := map[string]string{
 		"kdf":  "bcrypt",
 		"salt": fmt.Sprintf("%X", saltBytes),
 	}
-	armorStr := crypto.EncodeArmor(blockTypePrivKey, header, encBytes)
-	return armorStr
+	return crypto.EncodeArmor(blockType, header, encBytes)
 }
 
-func unarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey, error) {
-	var privKey crypto.PrivKey
-	blockType, header, encBytes, err := crypto.DecodeArmor(armorStr)
+func unarmorEncryptedBytes(blockType string, armorStr string) (saltBytes []byte, encBytes []byte, err error) {
+	bType, header, encBytes, err := crypto.DecodeArmor(armorStr)
 	if err != nil {
-		return privKey, err
+		return
 	}
-	if blockType != blockTypePrivKey {
-		return privKey, fmt.Errorf("Unrecognized armor type: %v", blockType)
+	if bType != blockType {
+		err = fmt.Errorf("Unrecognized armor type: %v", bType)
+		return
 	}
 	if header["kdf"] != "bcrypt" {
-		return privKey, fmt.Errorf("Unrecognized KDF type: %v", header["KDF"])
+		err = fmt.Errorf("Unrecognized KDF type: %v", header["kdf"])
+		return
 	}
 	if header["salt"] == "" {
-		return privKey, fmt.Errorf("Missing salt bytes")
+		err = fmt.Errorf("Missing salt bytes")
+		return
 	}
-	saltBytes, err := hex.DecodeString(header["salt"])
+	saltBytes, err = hex.DecodeString(header["salt"])
 	if err != nil {
-		return privKey, fmt.Errorf("Error decoding salt: %v", err.Error())
+		err = fmt.Errorf("Error decoding salt: %v", err.Error())
 	}
-	privKey, err = decryptPrivKey(saltBytes, encBytes, passphrase)
-	return privKey, err
+	return
 }
 
 func encryptPrivKey(privKey crypto.PrivKey, passphrase string) (saltBytes []byte, encBytes []byte) {
+	return encryptBytes(privKey.Bytes(), passphrase)
+}
+
+// encrypt bytes with a key derived from the passphrase and a new salt
+func encryptBytes(bz []byte, passphrase string) (saltBytes []byte, encBytes []byte) {
 	saltBytes = crypto.CRandBytes(16)
+	key := deriveSymmetricKey(saltBytes, passphrase)
+	return saltBytes, crypto.EncryptSymmetric(bz, key)
+}
+
+// derive the 32 bytes symmetric key of a passphrase with bcrypt
+func deriveSymmetricKey(saltBytes []byte, passphrase string) []byte {
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
 	if err != nil {
 		cmn.Exit("Error generating bcrypt key from passphrase: " + err.Error())
 	}
-	key = crypto.Sha256(key) // Get 32 bytes
-	privKeyBytes := privKey.Bytes()
-	return saltBytes, crypto.EncryptSymmetric(privKeyBytes, key)
+	return crypto.Sha256(key) // Get 32 bytes
 }
 
 func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privKey crypto.PrivKey, err error) {
-	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
-	if err != nil {
-		cmn.Exit("Error generating bcrypt key from passphrase: " + err.Error())
-	}
-	key = crypto.Sha256(key) // Get 32 bytes
+	key := deriveSymmetricKey(saltBytes, passphrase)
 	privKeyBytes, err := crypto.DecryptSymmetric(encBytes, key)
 	if err != nil {
 		return privKey, err
@@ -39,6 +39,9 @@ type Keybase interface {
 	ImportPubKey(name string, armor string) (err error)
 	Export(name string) (armor string, err error)
 	ExportPubKey(name string) (armor string, err error)
+	// ImportPrivKey stores an unencrypted private key, such as one exported
+	// by another wallet, as a local key encrypted with the passphrase
+	ImportPrivKey(name string, priv crypto.PrivKey, passphrase string) (info Info, err error)
 
 	// *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
@@ -9,6 +9,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 const (
@@ -25,11 +26,13 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 
 	// Create a key for accessing the account store.
 	keyAccount := sdk.NewKVStoreKey("acc")
+	keyParams := sdk.NewKVStoreKey("params")
 	keyFees := sdk.NewKVStoreKey("fee") // TODO
 
 	// Set various mappers/keepers to interact easily with underlying stores
 	accountMapper := auth.NewAccountMapper(cdc, keyAccount, auth.ProtoBaseAccount)
-	coinKeeper := bank.NewKeeper(accountMapper)
+	paramsKeeper := params.NewKeeper(cdc, keyParams)
+	coinKeeper := bank.NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
 	feeKeeper := auth.NewFeeCollectionKeeper(cdc, keyFees)
 
 	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper))
@@ -40,7 +43,7 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 		AddRoute("send", bank.NewHandler(coinKeeper))
 
 	// Mount stores and load the latest state.
-	app.MountStoresIAVL(keyAccount, keyFees)
+	app.MountStoresIAVL(keyAccount, keyFees, keyParams)
 	err := app.LoadLatestVersion(keyAccount)
 	if err != nil {
 		cmn.Exit(err.Error())
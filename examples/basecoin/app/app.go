@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -33,12 +34,16 @@ type BasecoinApp struct {
 	keyMain    *sdk.KVStoreKey
 	keyAccount *sdk.KVStoreKey
 	keyIBC     *sdk.KVStoreKey
+	keyParams  *sdk.KVStoreKey
+	keySupply  *sdk.KVStoreKey
 
 	// manage getting and setting accounts
 	accountMapper       auth.AccountMapper
 	feeCollectionKeeper auth.FeeCollectionKeeper
 	coinKeeper          bank.Keeper
+	supplyKeeper        bank.SupplyKeeper
 	ibcMapper           ibc.Mapper
+	paramsKeeper        params.Keeper
 }
 
 // NewBasecoinApp returns a reference to a new BasecoinApp given a logger and
@@ -57,6 +62,8 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 		keyMain:    sdk.NewKVStoreKey("main"),
 		keyAccount: sdk.NewKVStoreKey("acc"),
 		keyIBC:     sdk.NewKVStoreKey("ibc"),
+		keyParams:  sdk.NewKVStoreKey("params"),
+		keySupply:  sdk.NewKVStoreKey("supply"),
 	}
 
 	// define and attach the mappers and keepers
@@ -65,13 +72,19 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 		app.keyAccount,        // target store
 		auth.ProtoBaseAccount, // prototype
 	)
-	app.coinKeeper = bank.NewKeeper(app.accountMapper)
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+	bank.RegisterParamTypes(app.paramsKeeper)
+	app.coinKeeper = bank.NewKeeper(app.accountMapper, app.paramsKeeper.Getter(), nil)
+	app.supplyKeeper = bank.NewSupplyKeeper(app.cdc, app.keySupply, app.coinKeeper, map[string][]string{
+		ibc.ModuleName: {auth.Minter, auth.Burner},
+	})
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	app.ibcMapper.BindPort(ibc.TransferPort, ibc.NewTransferModule(app.ibcMapper, app.coinKeeper, app.supplyKeeper))
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper))
+		AddRoute("ibc", ibc.NewHandler(app.ibcMapper))
 
 	// perform initialization logic
 	app.SetInitChainer(app.initChainer)
@@ -80,7 +93,7 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
 
 	// mount the multistore and load the latest state
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyParams, app.keySupply)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -102,6 +115,7 @@ func MakeCodec() *wire.Codec {
 	// register custom types
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
 	cdc.RegisterConcrete(&types.AppAccount{}, "basecoin/Account", nil)
+	cdc.RegisterConcrete(&auth.ModuleAccount{}, "basecoin/ModuleAccount", nil)
 
 	cdc.Seal()
 
@@ -85,7 +85,7 @@ func main() {
 	rootCmd.AddCommand(
 		client.LineBreak,
 		lcd.ServeCommand(cdc),
-		keys.Commands(),
+		keys.Commands(authcmd.GetCoinsQuerier("acc", types.GetAccountDecoder(cdc))),
 		client.LineBreak,
 		version.VersionCmd,
 	)
@@ -44,10 +44,20 @@ func (v Validator) GetDelegatorShares() sdk.Rat {
 }
 
 // Implements sdk.Validator
-func (v Validator) GetRevoked() bool {
+func (v Validator) GetMinSelfDelegation() sdk.Int {
+	return sdk.ZeroInt()
+}
+
+// Implements sdk.Validator
+func (v Validator) GetJailed() bool {
 	return false
 }
 
+// Implements sdk.Validator
+func (v Validator) GetJailedUntil() int64 {
+	return 0
+}
+
 // Implements sdk.Validator
 func (v Validator) GetBondHeight() int64 {
 	return 0
@@ -87,6 +97,12 @@ func (vs *ValidatorSet) Validator(ctx sdk.Context, addr sdk.AccAddress) sdk.Vali
 	return nil
 }
 
+// ValidatorByPubKey implements sdk.ValidatorSet, the mock validators have no
+// pubkey
+func (vs *ValidatorSet) ValidatorByPubKey(ctx sdk.Context, pubkey crypto.PubKey) sdk.Validator {
+	return nil
+}
+
 // TotalPower implements sdk.ValidatorSet
 func (vs *ValidatorSet) TotalPower(ctx sdk.Context) sdk.Rat {
 	res := sdk.ZeroRat()
@@ -96,6 +112,12 @@ func (vs *ValidatorSet) TotalPower(ctx sdk.Context) sdk.Rat {
 	return res
 }
 
+// Delegation implements sdk.ValidatorSet, the mock validators have no
+// delegations
+func (vs *ValidatorSet) Delegation(ctx sdk.Context, delegator sdk.AccAddress, validator sdk.AccAddress) sdk.Delegation {
+	return nil
+}
+
 // Helper function for adding new validator
 func (vs *ValidatorSet) AddValidator(val Validator) {
 	vs.Validators = append(vs.Validators, val)
@@ -122,11 +144,11 @@ func (vs *ValidatorSet) Slash(ctx sdk.Context, pubkey crypto.PubKey, height int6
 }
 
 // Implements sdk.ValidatorSet
-func (vs *ValidatorSet) Revoke(ctx sdk.Context, pubkey crypto.PubKey) {
+func (vs *ValidatorSet) Jail(ctx sdk.Context, pubkey crypto.PubKey, jailedUntil int64) {
 	panic("not implemented")
 }
 
 // Implements sdk.ValidatorSet
-func (vs *ValidatorSet) Unrevoke(ctx sdk.Context, pubkey crypto.PubKey) {
+func (vs *ValidatorSet) Unjail(ctx sdk.Context, pubkey crypto.PubKey) {
 	panic("not implemented")
 }
@@ -204,14 +204,17 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	// latest height
 	res.Height = getHeight(tree, req)
 
+	// heights which were pruned or not yet committed cannot be queried
+	if !st.VersionExists(res.Height) {
+		msg := fmt.Sprintf("no state at height %d, the latest height is %d and older heights may have been pruned",
+			res.Height, tree.Version64())
+		return sdk.ErrInvalidHeight(msg).QueryResult()
+	}
+
 	switch req.Path {
 	case "/store", "/key": // Get by key
 		key := req.Data // Data holds the key bytes
 		res.Key = key
-		if !st.VersionExists(res.Height) {
-			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
-			break
-		}
 		if req.Prove {
 			value, proof, err := tree.GetVersionedWithProof(key, res.Height)
 			if err != nil {
@@ -232,12 +235,17 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	case "/subspace":
 		subspace := req.Data
 		res.Key = subspace
+		// iterate the subspace as of the queried version, a limit of 0
+		// returns all the keys of the range
+		keys, values, _, err := tree.GetVersionedRangeWithProof(subspace, sdk.PrefixEndBytes(subspace), 0, res.Height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
 		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
+		for i, key := range keys {
+			KVs = append(KVs, KVPair{key, values[i]})
 		}
-		iterator.Close()
 		res.Value = cdc.MustMarshalBinary(KVs)
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
@@ -420,6 +420,11 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Nil(t, qres.Value)
 
+	// and neither in the subspace
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSubEmpty, qres.Value)
+
 	// but yes on the new version
 	query.Height = cid.Version
 	qres = iavlStore.Query(query)
@@ -427,6 +432,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 
 	// and for the subspace
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub1, qres.Value)
@@ -449,11 +455,23 @@ func TestIAVLStoreQuery(t *testing.T) {
 	qres = iavlStore.Query(query2)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v2, qres.Value)
-	// and for the subspace
+	// the subspace keeps the values of its height
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSub1, qres.Value)
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
 
+	// heights which don't exist return an error
+	queryFuture := abci.RequestQuery{Path: "/key", Data: k1, Height: cid.Version + 1}
+	qres = iavlStore.Query(queryFuture)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidHeight), sdk.ABCICodeType(qres.Code))
+	querySub.Height = cid.Version + 1
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidHeight), sdk.ABCICodeType(qres.Code))
+
 	// default (height 0) will show latest -1
 	query0 := abci.RequestQuery{Path: "/store", Data: k1}
 	qres = iavlStore.Query(query0)
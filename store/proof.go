@@ -0,0 +1,110 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+)
+
+// StoreCommitID is the commit ID of a named substore of a rootMultiStore,
+// the leaves of the simple merkle tree of the app hash
+type StoreCommitID struct {
+	Name     string
+	CommitID CommitID
+}
+
+// MultiStoreProof proves the value of a key in an IAVL substore against the
+// app hash of a rootMultiStore. It holds the commit IDs of all the substores
+// at the proven version, and the amino encoded range proof of the key in the
+// substore as returned by the IAVL store queries.
+type MultiStoreProof struct {
+	StoreCommitIDs []StoreCommitID
+	RangeProof     []byte
+}
+
+func newMultiStoreProof(cInfo commitInfo, rangeProof []byte) MultiStoreProof {
+	storeCommitIDs := make([]StoreCommitID, len(cInfo.StoreInfos))
+	for i, storeInfo := range cInfo.StoreInfos {
+		storeCommitIDs[i] = StoreCommitID{storeInfo.Name, storeInfo.Core.CommitID}
+	}
+	return MultiStoreProof{
+		StoreCommitIDs: storeCommitIDs,
+		RangeProof:     rangeProof,
+	}
+}
+
+// ComputeAppHash returns the app hash committing to the commit IDs of the
+// proof, as computed by the rootMultiStore
+func (proof MultiStoreProof) ComputeAppHash() []byte {
+	cInfo := commitInfo{StoreInfos: make([]storeInfo, len(proof.StoreCommitIDs))}
+	for i, storeCommitID := range proof.StoreCommitIDs {
+		cInfo.StoreInfos[i].Name = storeCommitID.Name
+		cInfo.StoreInfos[i].Core.CommitID = storeCommitID.CommitID
+	}
+	return cInfo.Hash()
+}
+
+// Verify checks that the proof commits the value of the key in the named
+// substore to the app hash, a nil value is never proven
+func (proof MultiStoreProof) Verify(appHash []byte, storeName string, key, value []byte) error {
+	if len(value) == 0 {
+		return fmt.Errorf("cannot prove an empty value")
+	}
+
+	rangeProof, err := proof.verifyRangeProof(appHash, storeName)
+	if err != nil {
+		return err
+	}
+	if err = rangeProof.VerifyItem(key, value); err != nil {
+		return fmt.Errorf("range proof doesn't prove the value of key %X: %v", key, err)
+	}
+	return nil
+}
+
+// VerifyAbsence checks that the proof commits the absence of the key in the
+// named substore to the app hash
+func (proof MultiStoreProof) VerifyAbsence(appHash []byte, storeName string, key []byte) error {
+	rangeProof, err := proof.verifyRangeProof(appHash, storeName)
+	if err != nil {
+		return err
+	}
+	if err = rangeProof.VerifyAbsence(key); err != nil {
+		return fmt.Errorf("range proof doesn't prove the absence of key %X: %v", key, err)
+	}
+	return nil
+}
+
+// verifyRangeProof checks that the store commit IDs hash to the app hash and
+// returns the range proof, verified against the root of the named substore
+func (proof MultiStoreProof) verifyRangeProof(appHash []byte, storeName string) (*iavl.RangeProof, error) {
+	var storeRoot []byte
+	seen := make(map[string]bool, len(proof.StoreCommitIDs))
+	for _, storeCommitID := range proof.StoreCommitIDs {
+		// the app hash is computed from a map of the stores by name
+		if seen[storeCommitID.Name] {
+			return nil, fmt.Errorf("store %s is committed several times", storeCommitID.Name)
+		}
+		seen[storeCommitID.Name] = true
+		if storeCommitID.Name == storeName {
+			storeRoot = storeCommitID.CommitID.Hash
+		}
+	}
+	if storeRoot == nil {
+		return nil, fmt.Errorf("store %s is not committed by the proof", storeName)
+	}
+	if !bytes.Equal(proof.ComputeAppHash(), appHash) {
+		return nil, fmt.Errorf("store commit IDs don't match the app hash %X", appHash)
+	}
+
+	rangeProof := new(iavl.RangeProof)
+	err := amino.NewCodec().UnmarshalBinary(proof.RangeProof, rangeProof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range proof: %v", err)
+	}
+	if err = rangeProof.Verify(storeRoot); err != nil {
+		return nil, fmt.Errorf("range proof doesn't match the root of store %s: %v", storeName, err)
+	}
+	return rangeProof, nil
+}
@@ -265,7 +265,8 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// Proofs of the substore are wrapped in a MultiStoreProof which proves them
+// against the app hash.
 func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
@@ -288,6 +289,15 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// trim the path and make the query
 	req.Path = subpath
 	res := queryable.Query(req)
+
+	// extend the proof of the substore up to the app hash
+	if req.Prove && res.IsOK() && len(res.Proof) != 0 {
+		cInfo, err := getCommitInfo(rs.db, res.Height)
+		if err != nil {
+			return sdk.ErrInternal(err.Error()).QueryResult()
+		}
+		res.Proof = cdc.MustMarshalBinary(newMultiStoreProof(cInfo, res.Proof))
+	}
 	return res
 }
 
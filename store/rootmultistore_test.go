@@ -157,6 +157,28 @@ func TestMultiStoreQuery(t *testing.T) {
 	qres = multi.Query(query)
 	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(qres.Code))
 	require.Equal(t, v2, qres.Value)
+
+	// Test the proof of store2 data against the app hash.
+	var proof MultiStoreProof
+	err = cdc.UnmarshalBinary(qres.Proof, &proof)
+	require.Nil(t, err)
+	require.Equal(t, cid.Hash, proof.ComputeAppHash())
+	require.Nil(t, proof.Verify(cid.Hash, "store2", k2, v2))
+	require.NotNil(t, proof.Verify(cid.Hash, "store2", k2, v))
+	require.NotNil(t, proof.Verify(cid.Hash, "store1", k2, v2))
+	require.NotNil(t, proof.Verify([]byte("garbage"), "store2", k2, v2))
+	require.NotNil(t, proof.VerifyAbsence(cid.Hash, "store2", k2))
+
+	// Test the proof of the absence of a key against the app hash.
+	query.Data = k
+	qres = multi.Query(query)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(qres.Code))
+	require.Nil(t, qres.Value)
+	err = cdc.UnmarshalBinary(qres.Proof, &proof)
+	require.Nil(t, err)
+	require.Nil(t, proof.VerifyAbsence(cid.Hash, "store2", k))
+	require.NotNil(t, proof.VerifyAbsence(cid.Hash, "store2", k2))
+	require.NotNil(t, proof.VerifyAbsence([]byte("garbage"), "store2", k))
 }
 
 //-----------------------------------------------------------------------
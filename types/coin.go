@@ -277,8 +277,10 @@ func (coins Coins) Sort() Coins {
 // Parsing
 
 var (
-	// Denominations can be 3 ~ 16 characters long.
-	reDnm  = `[[:alpha:]][[:alnum:]]{2,15}`
+	// Denominations can be 3 ~ 16 characters long, IBC vouchers prefix them
+	// with the port and chain they were received from, as in
+	// "transfer/chain-id/denom".
+	reDnm  = `(?:[[:alpha:]][[:alnum:]]{1,15}/[[:alnum:]][[:alnum:]._\-]{0,49}/)*[[:alpha:]][[:alnum:]]{2,15}`
 	reAmt  = `[[:digit:]]+`
 	reSpc  = `[[:space:]]*`
 	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
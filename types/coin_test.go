@@ -240,6 +240,10 @@ func TestParse(t *testing.T) {
 		{"11me coin, 12you coin", false, nil}, // no spaces in coin names
 		{"1.2btc", false, nil},                // amount must be integer
 		{"5foo-bar", false, nil},              // once more, only letters in coin name
+		{"5transfer/chain-1/foo", true, Coins{{"transfer/chain-1/foo", NewInt(5)}}},
+		{"5transfer/chain-1/transfer/chain-2/foo", true, Coins{{"transfer/chain-1/transfer/chain-2/foo", NewInt(5)}}},
+		{"5transfer/foo", false, nil},             // vouchers have a port and a chain
+		{"5transfer/chain-1/foo-bar", false, nil}, // only letters in the base coin name
 	}
 
 	for tcIndex, tc := range cases {
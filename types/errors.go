@@ -54,6 +54,7 @@ const (
 	CodeInvalidCoins      CodeType = 11
 	CodeOutOfGas          CodeType = 12
 	CodeMemoTooLarge      CodeType = 13
+	CodeInvalidHeight     CodeType = 14
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -95,6 +96,8 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "out of gas"
 	case CodeMemoTooLarge:
 		return "memo too large"
+	case CodeInvalidHeight:
+		return "invalid height"
 	default:
 		return fmt.Sprintf("unknown code %d", code)
 	}
@@ -144,6 +147,9 @@ func ErrOutOfGas(msg string) Error {
 func ErrMemoTooLarge(msg string) Error {
 	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
 }
+func ErrInvalidHeight(msg string) Error {
+	return newErrorWithRootCodespace(CodeInvalidHeight, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -0,0 +1,6 @@
+package types
+
+// An Invariant is a function which tests a particular invariant of the state,
+// it returns an error describing how the invariant is broken, or nil if it
+// holds.
+type Invariant func(ctx Context) error
@@ -195,6 +195,11 @@ func (r Rat) RoundInt() Int {
 	return NewIntFromBigInt(r.EvaluateBig())
 }
 
+// TruncateInt truncates the rational towards zero
+func (r Rat) TruncateInt() Int {
+	return NewIntFromBigInt(new(big.Int).Quo(r.Rat.Num(), r.Rat.Denom()))
+}
+
 // round Rat with the provided precisionFactor
 func (r Rat) Round(precisionFactor int64) Rat {
 	rTen := Rat{new(big.Rat).Mul(r.Rat, big.NewRat(precisionFactor, 1))}
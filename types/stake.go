@@ -16,7 +16,7 @@ const (
 	Bonded    BondStatus = 0x02
 )
 
-//BondStatusToString for pretty prints of Bond Status
+// BondStatusToString for pretty prints of Bond Status
 func BondStatusToString(b BondStatus) string {
 	switch b {
 	case 0x00:
@@ -37,15 +37,17 @@ func (b BondStatus) Equal(b2 BondStatus) bool {
 
 // validator for a delegated proof of stake system
 type Validator interface {
-	GetRevoked() bool         // whether the validator is revoked
-	GetMoniker() string       // moniker of the validator
-	GetStatus() BondStatus    // status of the validator
-	GetOwner() AccAddress     // owner AccAddress to receive/return validators coins
-	GetPubKey() crypto.PubKey // validation pubkey
-	GetPower() Rat            // validation power
-	GetTokens() Rat           // validation tokens
-	GetDelegatorShares() Rat  // Total out standing delegator shares
-	GetBondHeight() int64     // height in which the validator became active
+	GetJailed() bool           // whether the validator is jailed
+	GetJailedUntil() int64     // time the validator cannot be unjailed until
+	GetMoniker() string        // moniker of the validator
+	GetStatus() BondStatus     // status of the validator
+	GetOwner() AccAddress      // owner AccAddress to receive/return validators coins
+	GetPubKey() crypto.PubKey  // validation pubkey
+	GetPower() Rat             // validation power
+	GetTokens() Rat            // validation tokens
+	GetDelegatorShares() Rat   // Total out standing delegator shares
+	GetMinSelfDelegation() Int // minimum tokens the owner must self delegate
+	GetBondHeight() int64      // height in which the validator became active
 }
 
 // validator which fulfills abci validator interface for use in Tendermint
@@ -66,13 +68,17 @@ type ValidatorSet interface {
 	IterateValidatorsBonded(Context,
 		func(index int64, validator Validator) (stop bool))
 
-	Validator(Context, AccAddress) Validator // get a particular validator by owner AccAddress
-	TotalPower(Context) Rat                  // total power of the validator set
+	Validator(Context, AccAddress) Validator            // get a particular validator by owner AccAddress
+	ValidatorByPubKey(Context, crypto.PubKey) Validator // get a particular validator by its validation pubkey
+	TotalPower(Context) Rat                             // total power of the validator set
+
+	// get the delegation of a delegator to a validator, nil if none exists
+	Delegation(ctx Context, delegator AccAddress, validator AccAddress) Delegation
 
 	// slash the validator and delegators of the validator, specifying offence height, offence power, and slash fraction
 	Slash(Context, crypto.PubKey, int64, int64, Rat)
-	Revoke(Context, crypto.PubKey)   // revoke a validator
-	Unrevoke(Context, crypto.PubKey) // unrevoke a validator
+	Jail(Context, crypto.PubKey, int64) // jail a validator until a time
+	Unjail(Context, crypto.PubKey)      // unjail a validator
 }
 
 //_______________________________________________________________________________
@@ -93,3 +99,20 @@ type DelegationSet interface {
 	IterateDelegations(ctx Context, delegator AccAddress,
 		fn func(index int64, delegation Delegation) (stop bool))
 }
+
+//_______________________________________________________________________________
+
+// event hooks for the validators and delegations of a delegated proof of stake
+// system, called synchronously by the staking keeper once the event has been
+// written to the store
+type StakingHooks interface {
+	BeforeValidatorCreated(ctx Context, pubkey crypto.PubKey) Error    // a validator is about to be created, an error rejects it
+	OnValidatorCreated(ctx Context, validator Validator)               // a validator has been created
+	OnValidatorBonded(ctx Context, validator Validator)                // a validator has entered the bonded set
+	OnValidatorBeginUnbonding(ctx Context, validator Validator)        // a validator has left the bonded set
+	OnValidatorRemoved(ctx Context, validator Validator)               // a validator has been removed from the store
+	OnValidatorSlashed(ctx Context, validator Validator, fraction Rat) // a validator has been slashed by a fraction
+
+	// the delegation of a delegator to a validator has been created, modified or removed
+	OnDelegationModified(ctx Context, delegator AccAddress, validator AccAddress)
+}
@@ -78,6 +78,15 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 					return ctx, res, true
 				}
 				fck.addCollectedFees(ctx, fee.Amount)
+
+				// hold the fees in the fee collector module account
+				feeCollector := am.GetModuleAccount(ctx, FeeCollectorName)
+				err := feeCollector.SetCoins(feeCollector.GetCoins().Plus(fee.Amount))
+				if err != nil {
+					// Handle w/ #870
+					panic(err)
+				}
+				am.SetAccount(ctx, feeCollector)
 			}
 
 			// Save the account.
@@ -336,6 +336,7 @@ func TestAnteHandlerFees(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
+	require.True(t, mapper.GetModuleAccount(ctx, FeeCollectorName).GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
 }
 
 // Test logic around memo gas consumption.
@@ -29,6 +29,26 @@ func GetAccountDecoder(cdc *wire.Codec) auth.AccountDecoder {
 	}
 }
 
+// GetCoinsQuerier returns a function querying the coins held by an address,
+// an address without an account holds no coins
+func GetCoinsQuerier(storeName string, decoder auth.AccountDecoder) func(sdk.AccAddress) (sdk.Coins, error) {
+	return func(addr sdk.AccAddress) (sdk.Coins, error) {
+		ctx := context.NewCoreContextFromViper()
+		res, err := ctx.QueryStore(auth.AddressStoreKey(addr), storeName)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, nil
+		}
+		account, err := decoder(res)
+		if err != nil {
+			return nil, err
+		}
+		return account.GetCoins(), nil
+	}
+}
+
 // GetAccountCmd returns a query account that will display the
 // state of the account at a given address
 func GetAccountCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/tendermint/tendermint/crypto"
@@ -59,6 +61,25 @@ func (am AccountMapper) NewAccount(ctx sdk.Context, acc Account) Account {
 	return acc
 }
 
+// GetModuleAccount returns the account owned by the named module, creating it
+// if it does not exist yet
+func (am AccountMapper) GetModuleAccount(ctx sdk.Context, name string) *ModuleAccount {
+	addr := NewModuleAddress(name)
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		macc := NewEmptyModuleAccount(name)
+		am.NewAccount(ctx, macc)
+		am.SetAccount(ctx, macc)
+		return macc
+	}
+
+	macc, ok := acc.(*ModuleAccount)
+	if !ok {
+		panic(fmt.Sprintf("account %s of module %s is not a module account", addr, name))
+	}
+	return macc
+}
+
 // Turn an address to key used to get it from the account store
 func AddressStoreKey(addr sdk.AccAddress) []byte {
 	return append([]byte("account:"), addr.Bytes()...)
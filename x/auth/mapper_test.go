@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -60,3 +61,36 @@ func TestAccountMapperGetSet(t *testing.T) {
 	require.NotNil(t, acc)
 	require.Equal(t, newSequence, acc.GetSequence())
 }
+
+func TestAccountMapperModuleAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+
+	// make context and mapper
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+
+	addr := NewModuleAddress("mymodule")
+	require.Nil(t, mapper.GetAccount(ctx, addr))
+
+	// the module account is created on first access
+	macc := mapper.GetModuleAccount(ctx, "mymodule")
+	require.Equal(t, addr, macc.GetAddress())
+	require.Equal(t, "mymodule", macc.GetName())
+	require.NotNil(t, mapper.GetAccount(ctx, addr))
+
+	// coins set on the module account are persisted
+	macc.SetCoins(sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	mapper.SetAccount(ctx, macc)
+	macc = mapper.GetModuleAccount(ctx, "mymodule")
+	require.True(t, macc.GetCoins().IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+
+	// module accounts cannot have a pubkey
+	require.NotNil(t, macc.SetPubKey(crypto.GenPrivKeyEd25519().PubKey()))
+
+	// a regular account at a module address is rejected
+	otherAddr := NewModuleAddress("othermodule")
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, otherAddr))
+	require.Panics(t, func() { mapper.GetModuleAccount(ctx, "othermodule") })
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// permissions which can be granted to module accounts
+const (
+	Minter  = "minter"  // allows a module to create coins
+	Burner  = "burner"  // allows a module to destroy coins
+	Staking = "staking" // allows a module to hold delegated coins
+)
+
+// FeeCollectorName is the name of the module account holding collected fees
+const FeeCollectorName = "fee_collector"
+
+//-----------------------------------------------------------
+// ModuleAccount
+
+var _ Account = (*ModuleAccount)(nil)
+
+// ModuleAccount is an account owned by a module rather than by a private key.
+// Its address is derived from the module name, so no transaction can ever be
+// signed on its behalf.
+type ModuleAccount struct {
+	*BaseAccount
+	Name string `json:"name"`
+}
+
+// NewModuleAddress returns the address of the account owned by a module
+func NewModuleAddress(name string) sdk.AccAddress {
+	return sdk.AccAddress(tmhash.Sum([]byte(name)))
+}
+
+// NewEmptyModuleAccount returns an account for the named module without coins
+func NewEmptyModuleAccount(name string) *ModuleAccount {
+	baseAcc := NewBaseAccountWithAddress(NewModuleAddress(name))
+	return &ModuleAccount{
+		BaseAccount: &baseAcc,
+		Name:        name,
+	}
+}
+
+// GetName returns the name of the module owning the account
+func (ma ModuleAccount) GetName() string {
+	return ma.Name
+}
+
+// Implements sdk.Account. Module accounts cannot have a pubkey.
+func (ma *ModuleAccount) SetPubKey(pubKey crypto.PubKey) error {
+	return errors.New("cannot set a PubKey on a module account")
+}
@@ -0,0 +1,370 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/bank/client"
+)
+
+const (
+	flagBaseGas      = "base-gas"
+	flagGasPerOutput = "gas-per-output"
+	flagOffline      = "offline"
+)
+
+// a recipient of a batch file
+type batchRecipient struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	Memo    string `json:"memo"`
+}
+
+// summary of a tx of a batch
+type batchTxReport struct {
+	Sequence int64     `json:"sequence"`
+	Outputs  int       `json:"outputs"`
+	Total    sdk.Coins `json:"total"`
+	Height   int64     `json:"height,omitempty"`
+	Hash     string    `json:"hash,omitempty"`
+	Tx       string    `json:"tx,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// SendBatchTxCmd will split a file of recipients into send txs, sign them with
+// the given key and broadcast them
+func SendBatchTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send-batch [file]",
+		Short: "Create and sign send txs paying all recipients of a CSV or JSON file",
+		Long: `Create and sign send txs paying all recipients of a file.
+
+A file with the .json extension holds a list of recipients:
+
+  [{"address": "cosmosaccaddr1...", "amount": "10steak", "memo": "thanks"}]
+
+Any other file is read as CSV, one recipient per line as address,amount[,memo].
+Amounts of several denominations must be quoted, e.g. "10steak,5photino".
+
+The recipients are split into as few txs as the gas limit allows, each paying
+as many recipients as fit within --gas given --base-gas and --gas-per-output.
+Unless given, both are measured by simulating txs paying the first recipients,
+with a margin for recipients using more gas. The txs are broadcast one after
+the other, or with --offline printed signed and hex encoded without contacting
+a node, in which case --chain-id, --account-number, --sequence, --base-gas and
+--gas-per-output are required.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+			offline := viper.GetBool(flagOffline)
+
+			outputs, err := readBatchFile(args[0])
+			if err != nil {
+				return err
+			}
+			baseGas, gasPerOutput := viper.GetInt64(flagBaseGas), viper.GetInt64(flagGasPerOutput)
+			if offline && (baseGas <= 0 || gasPerOutput <= 0) {
+				return errors.Errorf("--%s and --%s are required with --%s", flagBaseGas, flagGasPerOutput, flagOffline)
+			}
+
+			from, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			if !offline {
+				err = ensureBatchFunds(ctx, from, outputs)
+				if err != nil {
+					return err
+				}
+				ctx, err = context.EnsureAccountNumber(ctx)
+				if err != nil {
+					return err
+				}
+				ctx, err = context.EnsureSequence(ctx)
+				if err != nil {
+					return err
+				}
+			}
+
+			passphrase, err := getBatchPassphrase(ctx)
+			if err != nil {
+				return err
+			}
+
+			if baseGas <= 0 || gasPerOutput <= 0 {
+				simBaseGas, simGasPerOutput, err := simulateBatchGas(ctx, cdc, from, passphrase, outputs)
+				if err != nil {
+					return err
+				}
+				if baseGas <= 0 {
+					baseGas = simBaseGas
+				}
+				if gasPerOutput <= 0 {
+					gasPerOutput = simGasPerOutput
+				}
+			}
+			perTx, err := outputsPerTx(ctx.Gas, baseGas, gasPerOutput)
+			if err != nil {
+				return err
+			}
+			batches := splitOutputs(outputs, perTx)
+
+			// sign the txs with consecutive sequences, stopping at the first
+			// failed broadcast as all later txs would be rejected
+			reports := make([]batchTxReport, 0, len(batches))
+			var broadcastErr error
+			for i, batch := range batches {
+				msg := client.BuildMultiSendMsg(from, batch)
+				report := batchTxReport{
+					Sequence: ctx.Sequence + int64(i),
+					Outputs:  len(batch),
+					Total:    msg.(bank.MsgSend).Inputs[0].Coins,
+				}
+
+				txBytes, err := ctx.WithSequence(report.Sequence).SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{msg}, cdc)
+				if err != nil {
+					return fmt.Errorf("Error signing transaction: %v", err)
+				}
+
+				if offline {
+					report.Tx = hex.EncodeToString(txBytes)
+				} else {
+					res, err := ctx.BroadcastTx(txBytes)
+					if err != nil {
+						report.Error = err.Error()
+						reports = append(reports, report)
+						broadcastErr = errors.Errorf("tx %d of %d failed: %v", i+1, len(batches), err)
+						break
+					}
+					report.Height = res.Height
+					report.Hash = res.Hash.String()
+				}
+				reports = append(reports, report)
+			}
+
+			err = printBatchReport(ctx, cdc, reports, len(batches), offline)
+			if err != nil {
+				return err
+			}
+			return broadcastErr
+		},
+	}
+
+	cmd.Flags().Int64(flagBaseGas, 0, "Gas used by a tx independently of its recipients, measured by simulation if not given")
+	cmd.Flags().Int64(flagGasPerOutput, 0, "Gas reserved for each recipient of a tx, measured by simulation if not given")
+	cmd.Flags().Bool(flagOffline, false, "Print the signed txs instead of broadcasting them, without querying the node")
+
+	return cmd
+}
+
+// read the recipients of a JSON or CSV batch file as outputs
+func readBatchFile(path string) ([]bank.Output, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []batchRecipient
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(bz, &recipients)
+	} else {
+		recipients, err = parseBatchCSV(bz)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, errors.Errorf("no recipients in %s", path)
+	}
+
+	outputs := make([]bank.Output, len(recipients))
+	for i, recipient := range recipients {
+		addr, err := sdk.AccAddressFromBech32(strings.TrimSpace(recipient.Address))
+		if err != nil {
+			return nil, errors.Errorf("recipient %d: %v", i+1, err)
+		}
+		coins, err := sdk.ParseCoins(strings.TrimSpace(recipient.Amount))
+		if err != nil {
+			return nil, errors.Errorf("recipient %d: %v", i+1, err)
+		}
+		outputs[i] = bank.NewOutputWithMemo(addr, coins, recipient.Memo)
+		if err := outputs[i].ValidateBasic(); err != nil {
+			return nil, errors.Errorf("recipient %d: %v", i+1, err.Error())
+		}
+	}
+	return outputs, nil
+}
+
+// parse CSV lines of address,amount[,memo], skipping a header line starting
+// with "address"
+func parseBatchCSV(bz []byte) ([]batchRecipient, error) {
+	reader := csv.NewReader(bytes.NewReader(bz))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []batchRecipient
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, errors.Errorf("line %d: expected address,amount[,memo]", i+1)
+		}
+		recipient := batchRecipient{Address: record[0], Amount: record[1]}
+		if len(record) == 3 {
+			recipient.Memo = record[2]
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// measure the gas used by a batch tx independently of its recipients and for
+// each recipient, by simulating txs paying the first one and the first two
+// recipients. The gas per recipient is raised by half, as recipients with new
+// accounts or more denominations use more gas than the simulated ones.
+func simulateBatchGas(ctx context.CoreContext, cdc *wire.Codec, from sdk.AccAddress, passphrase string,
+	outputs []bank.Output) (baseGas, gasPerOutput int64, err error) {
+
+	simulate := func(outputs []bank.Output) (int64, error) {
+		msg := client.BuildMultiSendMsg(from, outputs)
+		txBytes, err := ctx.SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{msg}, cdc)
+		if err != nil {
+			return 0, err
+		}
+		res, err := ctx.Simulate(cdc, txBytes)
+		if err != nil {
+			return 0, errors.Errorf("failed to measure the gas of the txs, set --%s and --%s: %v", flagBaseGas, flagGasPerOutput, err)
+		}
+		return res.GasUsed, nil
+	}
+
+	gasOne, err := simulate(outputs[:1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(outputs) == 1 {
+		return 0, gasOne, nil
+	}
+	gasTwo, err := simulate(outputs[:2])
+	if err != nil {
+		return 0, 0, err
+	}
+	gasPerOutput = gasTwo - gasOne
+	if gasPerOutput <= 0 {
+		return 0, 0, errors.Errorf("failed to measure the gas of the txs, set --%s and --%s", flagBaseGas, flagGasPerOutput)
+	}
+	return gasOne - gasPerOutput, gasPerOutput + gasPerOutput/2, nil
+}
+
+// number of outputs which fit within the gas limit of a tx
+func outputsPerTx(gas, baseGas, gasPerOutput int64) (int, error) {
+	if baseGas < 0 || gasPerOutput <= 0 {
+		return 0, errors.Errorf("--%s must not be negative and --%s must be positive", flagBaseGas, flagGasPerOutput)
+	}
+	n := (gas - baseGas) / gasPerOutput
+	if n < 1 {
+		return 0, errors.Errorf("gas limit %d is too low for a batch tx, at least %d is needed", gas, baseGas+gasPerOutput)
+	}
+	return int(n), nil
+}
+
+// split the outputs into batches of at most perTx outputs
+func splitOutputs(outputs []bank.Output, perTx int) (batches [][]bank.Output) {
+	for len(outputs) > perTx {
+		batches = append(batches, outputs[:perTx])
+		outputs = outputs[perTx:]
+	}
+	return append(batches, outputs)
+}
+
+// ensure the sender can pay all recipients before broadcasting anything
+func ensureBatchFunds(ctx context.CoreContext, from sdk.AccAddress, outputs []bank.Output) error {
+	fromAcc, err := ctx.QueryStore(auth.AddressStoreKey(from), ctx.AccountStore)
+	if err != nil {
+		return err
+	}
+	if fromAcc == nil {
+		return errors.Errorf("No account with address %s was found in the state.\nAre you sure there has been a transaction involving it?", from)
+	}
+	account, err := ctx.Decoder(fromAcc)
+	if err != nil {
+		return err
+	}
+
+	var total sdk.Coins
+	for _, output := range outputs {
+		total = total.Plus(output.Coins)
+	}
+	if !account.GetCoins().IsGTE(total) {
+		return errors.Errorf("Address %s doesn't have enough coins to pay %s to all recipients.", from, total)
+	}
+	return nil
+}
+
+// get the passphrase once for all txs, only locally stored keys need one
+func getBatchPassphrase(ctx context.CoreContext) (string, error) {
+	keybase, err := keys.GetKeyBase()
+	if err != nil {
+		return "", err
+	}
+	info, err := keybase.Get(ctx.FromAddressName)
+	if err != nil {
+		return "", err
+	}
+	if info.GetType() != "local" {
+		return "", nil
+	}
+	return ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+}
+
+// print a summary of the signed and broadcast txs
+func printBatchReport(ctx context.CoreContext, cdc *wire.Codec, reports []batchTxReport, numTxs int, offline bool) error {
+	if ctx.JSON || offline {
+		output, err := wire.MarshalJSONIndent(cdc, reports)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	var committed, recipients int
+	var total sdk.Coins
+	for i, report := range reports {
+		if report.Error != "" {
+			fmt.Printf("tx %d/%d: %d recipients, %s, sequence %d, failed: %s\n",
+				i+1, numTxs, report.Outputs, report.Total, report.Sequence, report.Error)
+			continue
+		}
+		fmt.Printf("tx %d/%d: %d recipients, %s, sequence %d, committed at block %d. Hash: %s\n",
+			i+1, numTxs, report.Outputs, report.Total, report.Sequence, report.Height, report.Hash)
+		committed++
+		recipients += report.Outputs
+		total = total.Plus(report.Total)
+	}
+	fmt.Printf("Paid %d recipients a total of %s in %d of %d txs\n", recipients, total, committed, numTxs)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestParseBatchCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		csv        string
+		recipients []batchRecipient
+		expectPass bool
+	}{
+		{"recipients", "addr1,10steak\naddr2,5steak,thanks\n",
+			[]batchRecipient{{"addr1", "10steak", ""}, {"addr2", "5steak", "thanks"}}, true},
+		{"header skipped", "Address,amount,memo\naddr1,10steak\n",
+			[]batchRecipient{{"addr1", "10steak", ""}}, true},
+		{"quoted amounts", "addr1,\"10steak,5photino\"\n",
+			[]batchRecipient{{"addr1", "10steak,5photino", ""}}, true},
+		{"leading spaces trimmed", "addr1, 10steak, thanks\n",
+			[]batchRecipient{{"addr1", "10steak", "thanks"}}, true},
+		{"only header", "address,amount\n", nil, true},
+		{"missing amount", "addr1,10steak\naddr2\n", nil, false},
+		{"too many fields", "addr1,10steak,thanks,extra\n", nil, false},
+		{"unterminated quote", "addr1,\"10steak\n", nil, false},
+	}
+
+	for _, tc := range tests {
+		recipients, err := parseBatchCSV([]byte(tc.csv))
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", tc.name)
+			require.Equal(t, tc.recipients, recipients, "test: %v", tc.name)
+		} else {
+			require.NotNil(t, err, "test: %v", tc.name)
+		}
+	}
+}
+
+func TestOutputsPerTx(t *testing.T) {
+	tests := []struct {
+		name         string
+		gas          int64
+		baseGas      int64
+		gasPerOutput int64
+		perTx        int
+		expectPass   bool
+	}{
+		{"exact fit", 1000, 200, 100, 8, true},
+		{"rounded down", 1099, 200, 100, 8, true},
+		{"single output", 300, 200, 100, 1, true},
+		{"no base gas", 1000, 0, 100, 10, true},
+		{"gas too low", 299, 200, 100, 0, false},
+		{"zero gas per output", 1000, 200, 0, 0, false},
+		{"negative base gas", 1000, -1, 100, 0, false},
+	}
+
+	for _, tc := range tests {
+		perTx, err := outputsPerTx(tc.gas, tc.baseGas, tc.gasPerOutput)
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", tc.name)
+			require.Equal(t, tc.perTx, perTx, "test: %v", tc.name)
+		} else {
+			require.NotNil(t, err, "test: %v", tc.name)
+		}
+	}
+}
+
+func TestSplitOutputs(t *testing.T) {
+	outputs := make([]bank.Output, 6)
+	for i := range outputs {
+		outputs[i] = bank.NewOutput(sdk.AccAddress([]byte{byte(i)}), sdk.Coins{{"steak", sdk.NewInt(int64(i + 1))}})
+	}
+
+	tests := []struct {
+		name    string
+		outputs []bank.Output
+		perTx   int
+		sizes   []int
+	}{
+		{"one per tx", outputs, 1, []int{1, 1, 1, 1, 1, 1}},
+		{"even split", outputs, 3, []int{3, 3}},
+		{"remainder", outputs, 4, []int{4, 2}},
+		{"exactly one tx", outputs, 6, []int{6}},
+		{"more than needed", outputs, 10, []int{6}},
+		{"single output", outputs[:1], 3, []int{1}},
+	}
+
+	for _, tc := range tests {
+		batches := splitOutputs(tc.outputs, tc.perTx)
+		require.Len(t, batches, len(tc.sizes), "test: %v", tc.name)
+		var joined []bank.Output
+		for i, batch := range batches {
+			require.Len(t, batch, tc.sizes[i], "test: %v", tc.name)
+			joined = append(joined, batch...)
+		}
+		require.Equal(t, tc.outputs, joined, "test: %v", tc.name)
+	}
+}
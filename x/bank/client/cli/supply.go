@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// GetSupplyCmd returns a command to query the total supply of all coins, or
+// of a single denomination if one is provided
+func GetSupplyCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "supply [denom]",
+		Short: "Query the total supply of coins",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			// perform query
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(bank.SupplyKey, storeName)
+			if err != nil {
+				return err
+			}
+
+			// decode the value
+			supply := sdk.Coins{}
+			if len(res) != 0 {
+				err = cdc.UnmarshalBinary(res, &supply)
+				if err != nil {
+					return err
+				}
+			}
+
+			// print out the supply of a single denomination
+			if len(args) == 1 {
+				fmt.Println(sdk.NewIntCoin(args[0], supply.AmountOf(args[0])).String())
+				return nil
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, supply)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
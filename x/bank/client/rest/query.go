@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const supplyStoreName = "supply"
+
+func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/supply",
+		supplyHandlerFn(ctx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/supply/{denom}",
+		supplyHandlerFn(ctx, cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the total supply, optionally of a single denomination
+func supplyHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := ctx.QueryStore(bank.SupplyKey, supplyStoreName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query supply. Error: %s", err.Error())))
+			return
+		}
+
+		supply := sdk.Coins{}
+		if len(res) != 0 {
+			err = cdc.UnmarshalBinary(res, &supply)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("couldn't parse query result. Result: %s. Error: %s", res, err.Error())))
+				return
+			}
+		}
+
+		var output []byte
+		if denom, ok := mux.Vars(r)["denom"]; ok {
+			output, err = cdc.MarshalJSON(sdk.NewIntCoin(denom, supply.AmountOf(denom)))
+		} else {
+			output, err = cdc.MarshalJSON(supply)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
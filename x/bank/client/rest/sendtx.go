@@ -17,6 +17,8 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	r.HandleFunc("/accounts/{address}/send", SendRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	r.HandleFunc("/bank/multisend", MultiSendRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	registerQueryRoutes(ctx, r, cdc)
 }
 
 type sendBody struct {
@@ -31,6 +33,16 @@ type sendBody struct {
 	Gas              int64     `json:"gas"`
 }
 
+type multiSendBody struct {
+	Outputs          []bank.Output `json:"outputs"`
+	LocalAccountName string        `json:"name"`
+	Password         string        `json:"password"`
+	ChainID          string        `json:"chain_id"`
+	AccountNumber    int64         `json:"account_number"`
+	Sequence         int64         `json:"sequence"`
+	Gas              int64         `json:"gas"`
+}
+
 var msgCdc = wire.NewCodec()
 
 func init() {
@@ -113,3 +125,69 @@ func SendRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreCont
 		w.Write(output)
 	}
 }
+
+// MultiSendRequestHandlerFn - http request handler to send coins to several addresses in one tx
+func MultiSendRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m multiSendBody
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		err = msgCdc.UnmarshalJSON(body, &m)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		info, err := kb.Get(m.LocalAccountName)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// build message
+		msg := client.BuildMultiSendMsg(sdk.AccAddress(info.GetPubKey().Address()), m.Outputs)
+		if err := msg.ValidateBasic(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// add gas to context
+		ctx = ctx.WithGas(m.Gas)
+		// add chain-id to context
+		ctx = ctx.WithChainID(m.ChainID)
+
+		// sign
+		ctx = ctx.WithAccountNumber(m.AccountNumber)
+		ctx = ctx.WithSequence(m.Sequence)
+		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// send
+		res, err := ctx.BroadcastTx(txBytes)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		output, err := wire.MarshalJSONIndent(cdc, res)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
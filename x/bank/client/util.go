@@ -12,3 +12,14 @@ func BuildMsg(from sdk.AccAddress, to sdk.AccAddress, coins sdk.Coins) sdk.Msg {
 	msg := bank.NewMsgSend([]bank.Input{input}, []bank.Output{output})
 	return msg
 }
+
+// build a sendTx msg paying all outputs from a single input
+func BuildMultiSendMsg(from sdk.AccAddress, outputs []bank.Output) sdk.Msg {
+	var total sdk.Coins
+	for _, output := range outputs {
+		total = total.Plus(output.Coins)
+	}
+	input := bank.NewInput(from, total)
+	msg := bank.NewMsgSend([]bank.Input{input}, outputs)
+	return msg
+}
@@ -2,6 +2,8 @@
 package bank
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -9,8 +11,10 @@ import (
 const (
 	DefaultCodespace sdk.CodespaceType = 2
 
-	CodeInvalidInput  sdk.CodeType = 101
-	CodeInvalidOutput sdk.CodeType = 102
+	CodeInvalidInput     sdk.CodeType = 101
+	CodeInvalidOutput    sdk.CodeType = 102
+	CodeSendDisabled     sdk.CodeType = 103
+	CodeBlockedRecipient sdk.CodeType = 104
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -20,6 +24,10 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid input coins"
 	case CodeInvalidOutput:
 		return "invalid output coins"
+	case CodeSendDisabled:
+		return "transfers are disabled"
+	case CodeBlockedRecipient:
+		return "recipient is not allowed to receive coins"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -44,6 +52,14 @@ func ErrNoOutputs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidOutput, "")
 }
 
+func ErrSendDisabled(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeSendDisabled, msg)
+}
+
+func ErrBlockedRecipient(codespace sdk.CodespaceType, addr sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeBlockedRecipient, fmt.Sprintf("%s is not allowed to receive coins", addr))
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
@@ -0,0 +1,28 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all bank state that must be provided at genesis
+type GenesisState struct {
+	Supply sdk.Coins `json:"supply"`
+}
+
+func NewGenesisState(supply sdk.Coins) GenesisState {
+	return GenesisState{
+		Supply: supply,
+	}
+}
+
+// InitGenesis - store the initial total supply
+func InitGenesis(ctx sdk.Context, sk SupplyKeeper, data GenesisState) {
+	sk.SetSupply(ctx, data.Supply.Sort())
+}
+
+// WriteGenesis - output the total supply
+func WriteGenesis(ctx sdk.Context, sk SupplyKeeper) GenesisState {
+	return GenesisState{
+		Supply: sk.GetSupply(ctx),
+	}
+}
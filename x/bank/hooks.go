@@ -0,0 +1,67 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// SendHook is implemented by modules which need to observe or veto transfers
+// sent by users. BeforeSend is called before any coins are moved, an error
+// aborts the transfer and is returned to the sender.
+type SendHook interface {
+	BeforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error
+}
+
+// sendRestrictions are checked before the inputs and outputs of a transfer
+// are applied by InputOutputCoins, transfers made by other modules through
+// SendCoins or the module account methods are not restricted
+type sendRestrictions struct {
+	params       params.Getter
+	blockedAddrs map[string]bool
+	hooks        *[]SendHook // registered with RegisterSendHook
+}
+
+func newSendRestrictions(params params.Getter, blockedAddrs map[string]bool) sendRestrictions {
+	if blockedAddrs == nil {
+		blockedAddrs = make(map[string]bool)
+	}
+	return sendRestrictions{
+		params:       params,
+		blockedAddrs: blockedAddrs,
+		hooks:        new([]SendHook),
+	}
+}
+
+// RegisterSendHook registers a hook which is called before every restricted
+// transfer, hooks are called in the order they were registered
+func (r sendRestrictions) RegisterSendHook(hook SendHook) {
+	*r.hooks = append(*r.hooks, hook)
+}
+
+// BlockedAddr returns whether an address is not allowed to receive coins
+func (r sendRestrictions) BlockedAddr(addr sdk.AccAddress) bool {
+	return r.blockedAddrs[addr.String()]
+}
+
+// beforeSend checks the send-enabled params, the blocked recipients and the
+// registered hooks
+func (r sendRestrictions) beforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error {
+	for _, in := range inputs {
+		if err := checkSendEnabled(ctx, r.params, in.Coins); err != nil {
+			return err
+		}
+	}
+
+	for _, out := range outputs {
+		if r.BlockedAddr(out.Address) {
+			return ErrBlockedRecipient(DefaultCodespace, out.Address)
+		}
+	}
+
+	for _, hook := range *r.hooks {
+		if err := hook.BeforeSend(ctx, inputs, outputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+// RegisterInvariants registers all the bank invariants on a crisis registry
+func RegisterInvariants(r *crisis.Registry, am auth.AccountMapper, sk SupplyKeeper) {
+	r.RegisterRoute("bank", "nonnegative-balances", NonnegativeBalanceInvariant(am))
+	r.RegisterRoute("bank", "supply", SupplyInvariant(am, sk))
+}
+
+// NonnegativeBalanceInvariant checks that all accounts have non-negative
+// balances
+func NonnegativeBalanceInvariant(am auth.AccountMapper) sdk.Invariant {
+	return func(ctx sdk.Context) (err error) {
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			if !acc.GetCoins().IsNotNegative() {
+				err = fmt.Errorf("account %v has a negative balance %v", acc.GetAddress(), acc.GetCoins())
+				return true
+			}
+			return false
+		})
+		return
+	}
+}
+
+// SupplyInvariant checks that the total supply recorded by the supply keeper
+// equals the sum of the coins across all accounts, including module accounts
+func SupplyInvariant(am auth.AccountMapper, sk SupplyKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		totalCoins := sdk.Coins{}
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			totalCoins = totalCoins.Plus(acc.GetCoins())
+			return false
+		})
+
+		supply := sk.GetSupply(ctx)
+		if !supply.IsEqual(totalCoins) {
+			return fmt.Errorf("total supply %v differs from the coins held by accounts %v", supply, totalCoins)
+		}
+		return nil
+	}
+}
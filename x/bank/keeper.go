@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 const (
@@ -17,12 +18,18 @@ const (
 
 // Keeper manages transfers between accounts
 type Keeper struct {
+	sendRestrictions
+
 	am auth.AccountMapper
 }
 
-// NewKeeper returns a new Keeper
-func NewKeeper(am auth.AccountMapper) Keeper {
-	return Keeper{am: am}
+// NewKeeper returns a new Keeper, transfers to any of the blocked addresses
+// are rejected
+func NewKeeper(am auth.AccountMapper, params params.Getter, blockedAddrs map[string]bool) Keeper {
+	return Keeper{
+		sendRestrictions: newSendRestrictions(params, blockedAddrs),
+		am:               am,
+	}
 }
 
 // GetCoins returns the coins at the addr.
@@ -55,21 +62,51 @@ func (keeper Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
 }
 
-// InputOutputCoins handles a list of inputs and outputs
+// InputOutputCoins handles a list of inputs and outputs, if the transfer is
+// allowed by the send restrictions
 func (keeper Keeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
+	err := keeper.beforeSend(ctx, inputs, outputs)
+	if err != nil {
+		return nil, err
+	}
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
 }
 
+// SendCoinsFromModuleToAccount moves coins from a module account to an account
+func (keeper Keeper) SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	senderAddr := keeper.am.GetModuleAccount(ctx, senderModule).GetAddress()
+	return sendCoins(ctx, keeper.am, senderAddr, recipientAddr, amt)
+}
+
+// SendCoinsFromAccountToModule moves coins from an account to a module account
+func (keeper Keeper) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	recipientAddr := keeper.am.GetModuleAccount(ctx, recipientModule).GetAddress()
+	return sendCoins(ctx, keeper.am, senderAddr, recipientAddr, amt)
+}
+
+// SendCoinsFromModuleToModule moves coins from one module account to another
+func (keeper Keeper) SendCoinsFromModuleToModule(ctx sdk.Context, senderModule string, recipientModule string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	senderAddr := keeper.am.GetModuleAccount(ctx, senderModule).GetAddress()
+	recipientAddr := keeper.am.GetModuleAccount(ctx, recipientModule).GetAddress()
+	return sendCoins(ctx, keeper.am, senderAddr, recipientAddr, amt)
+}
+
 //______________________________________________________________________________________________
 
 // SendKeeper only allows transfers between accounts, without the possibility of creating coins
 type SendKeeper struct {
+	sendRestrictions
+
 	am auth.AccountMapper
 }
 
-// NewSendKeeper returns a new Keeper
-func NewSendKeeper(am auth.AccountMapper) SendKeeper {
-	return SendKeeper{am: am}
+// NewSendKeeper returns a new Keeper, transfers to any of the blocked
+// addresses are rejected
+func NewSendKeeper(am auth.AccountMapper, params params.Getter, blockedAddrs map[string]bool) SendKeeper {
+	return SendKeeper{
+		sendRestrictions: newSendRestrictions(params, blockedAddrs),
+		am:               am,
+	}
 }
 
 // GetCoins returns the coins at the addr.
@@ -87,8 +124,13 @@ func (keeper SendKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toA
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
 }
 
-// InputOutputCoins handles a list of inputs and outputs
+// InputOutputCoins handles a list of inputs and outputs, if the transfer is
+// allowed by the send restrictions
 func (keeper SendKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
+	err := keeper.beforeSend(ctx, inputs, outputs)
+	if err != nil {
+		return nil, err
+	}
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
 }
 
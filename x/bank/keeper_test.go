@@ -1,6 +1,7 @@
 package bank
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,26 +16,30 @@ import (
 	wire "github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey) {
 	db := dbm.NewMemDB()
 	authKey := sdk.NewKVStoreKey("authkey")
+	paramsKey := sdk.NewKVStoreKey("params")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
 	ms.LoadLatestVersion()
-	return ms, authKey
+	return ms, authKey, paramsKey
 }
 
 func TestKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, paramsKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
-	coinKeeper := NewKeeper(accountMapper)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
 
 	addr := sdk.AccAddress([]byte("addr1"))
 	addr2 := sdk.AccAddress([]byte("addr2"))
@@ -112,15 +117,16 @@ func TestKeeper(t *testing.T) {
 }
 
 func TestSendKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, paramsKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
-	coinKeeper := NewKeeper(accountMapper)
-	sendKeeper := NewSendKeeper(accountMapper)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
+	sendKeeper := NewSendKeeper(accountMapper, paramsKeeper.Getter(), nil)
 
 	addr := sdk.AccAddress([]byte("addr1"))
 	addr2 := sdk.AccAddress([]byte("addr2"))
@@ -181,14 +187,15 @@ func TestSendKeeper(t *testing.T) {
 }
 
 func TestViewKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, paramsKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
-	coinKeeper := NewKeeper(accountMapper)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
 	viewKeeper := NewViewKeeper(accountMapper)
 
 	addr := sdk.AccAddress([]byte("addr1"))
@@ -207,3 +214,184 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)}))
 }
+
+func TestKeeperModuleAccounts(t *testing.T) {
+	ms, authKey, paramsKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	moduleAddr := auth.NewModuleAddress("mymodule")
+	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+
+	// Test SendCoinsFromAccountToModule
+	_, err := coinKeeper.SendCoinsFromAccountToModule(ctx, addr, "mymodule", sdk.Coins{sdk.NewCoin("foocoin", 15)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoinsFromAccountToModule(ctx, addr, "mymodule", sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsZero())
+	require.True(t, coinKeeper.GetCoins(ctx, moduleAddr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+	_, ok := accountMapper.GetAccount(ctx, moduleAddr).(*auth.ModuleAccount)
+	require.True(t, ok)
+
+	// Test SendCoinsFromModuleToModule
+	_, err = coinKeeper.SendCoinsFromModuleToModule(ctx, "mymodule", "othermodule", sdk.Coins{sdk.NewCoin("foocoin", 4)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, auth.NewModuleAddress("othermodule")).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 4)}))
+
+	// Test SendCoinsFromModuleToAccount
+	_, err = coinKeeper.SendCoinsFromModuleToAccount(ctx, "mymodule", addr, sdk.Coins{sdk.NewCoin("foocoin", 7)})
+	require.NotNil(t, err)
+	_, err = coinKeeper.SendCoinsFromModuleToAccount(ctx, "mymodule", addr, sdk.Coins{sdk.NewCoin("foocoin", 6)})
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 6)}))
+	require.True(t, coinKeeper.GetCoins(ctx, moduleAddr).IsZero())
+}
+
+func TestKeeperSendEnabled(t *testing.T) {
+	ms, authKey, paramsKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
+	handler := NewHandler(coinKeeper)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 10), sdk.NewCoin("foocoin", 10)})
+
+	fooCoins := sdk.Coins{sdk.NewCoin("foocoin", 1)}
+	barCoins := sdk.Coins{sdk.NewCoin("barcoin", 1)}
+	fooMsg := MsgSend{Inputs: []Input{NewInput(addr, fooCoins)}, Outputs: []Output{NewOutput(addr2, fooCoins)}}
+	barMsg := MsgSend{Inputs: []Input{NewInput(addr, barCoins)}, Outputs: []Output{NewOutput(addr2, barCoins)}}
+
+	// transfers are enabled by default
+	require.True(t, coinKeeper.SendEnabled(ctx))
+	require.Empty(t, coinKeeper.NonTransferableDenoms(ctx))
+	require.True(t, handler(ctx, fooMsg).IsOK())
+
+	// disable all transfers
+	paramsKeeper.Setter().SetBool(ctx, SendEnabledKey, false)
+	res := handler(ctx, fooMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSendDisabled), res.Code)
+	_, err := coinKeeper.InputOutputCoins(ctx, fooMsg.Inputs, fooMsg.Outputs)
+	require.Equal(t, CodeSendDisabled, err.Code())
+
+	// module transfers are not restricted
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, fooCoins)
+	require.Nil(t, err)
+
+	// disable transfers of a single denomination
+	paramsKeeper.Setter().SetBool(ctx, SendEnabledKey, true)
+	require.Nil(t, paramsKeeper.Setter().Set(ctx, NonTransferableDenomsKey, []string{"foocoin"}))
+	require.Equal(t, []string{"foocoin"}, coinKeeper.NonTransferableDenoms(ctx))
+	res = handler(ctx, fooMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSendDisabled), res.Code)
+	require.True(t, handler(ctx, barMsg).IsOK())
+
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 9), sdk.NewCoin("foocoin", 8)}))
+}
+
+func TestKeeperBlockedAddrs(t *testing.T) {
+	ms, authKey, paramsKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	blockedAddr := auth.NewModuleAddress("module")
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), map[string]bool{blockedAddr.String(): true})
+	handler := NewHandler(coinKeeper)
+	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+
+	coins := sdk.Coins{sdk.NewCoin("foocoin", 1)}
+	require.True(t, coinKeeper.BlockedAddr(blockedAddr))
+	require.False(t, coinKeeper.BlockedAddr(addr2))
+
+	// a single blocked output rejects the whole transfer
+	msg := MsgSend{
+		Inputs:  []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("foocoin", 2)})},
+		Outputs: []Output{NewOutput(addr2, coins), NewOutput(blockedAddr, coins)},
+	}
+	res := handler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeBlockedRecipient), res.Code)
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+	require.True(t, coinKeeper.GetCoins(ctx, blockedAddr).IsZero())
+
+	msg = MsgSend{Inputs: []Input{NewInput(addr, coins)}, Outputs: []Output{NewOutput(addr2, coins)}}
+	require.True(t, handler(ctx, msg).IsOK())
+
+	// module transfers are not restricted
+	_, err := coinKeeper.SendCoins(ctx, addr, blockedAddr, coins)
+	require.Nil(t, err)
+	require.True(t, coinKeeper.GetCoins(ctx, blockedAddr).IsEqual(coins))
+}
+
+// freezeHook vetoes any transfer from a frozen account and records the
+// transfers it has seen
+type freezeHook struct {
+	frozen sdk.AccAddress
+	seen   int
+}
+
+func (h *freezeHook) BeforeSend(ctx sdk.Context, inputs []Input, outputs []Output) sdk.Error {
+	h.seen++
+	for _, in := range inputs {
+		if bytes.Equal(in.Address, h.frozen) {
+			return sdk.ErrUnauthorized("account is frozen")
+		}
+	}
+	return nil
+}
+
+func TestKeeperSendHook(t *testing.T) {
+	ms, authKey, paramsKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	coinKeeper := NewKeeper(accountMapper, paramsKeeper.Getter(), nil)
+
+	// hooks registered after the handler was created are called
+	handler := NewHandler(coinKeeper)
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	hook := &freezeHook{frozen: addr}
+	coinKeeper.RegisterSendHook(hook)
+
+	coins := sdk.Coins{sdk.NewCoin("foocoin", 1)}
+	coinKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	coinKeeper.SetCoins(ctx, addr2, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+
+	// the veto reason is returned to the sender
+	msg := MsgSend{Inputs: []Input{NewInput(addr, coins)}, Outputs: []Output{NewOutput(addr2, coins)}}
+	res := handler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "account is frozen")
+	require.Equal(t, 1, hook.seen)
+
+	msg = MsgSend{Inputs: []Input{NewInput(addr2, coins)}, Outputs: []Output{NewOutput(addr, coins)}}
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, 2, hook.seen)
+
+	require.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 11)}))
+	require.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 9)}))
+}
@@ -2,10 +2,14 @@ package bank
 
 import (
 	"encoding/json"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// maximum length of the memo of an output
+const maxOutputMemoCharacters = 100
+
 // MsgSend - high level transaction of the coin module
 type MsgSend struct {
 	Inputs  []Input  `json:"inputs"`
@@ -84,63 +88,6 @@ func (msg MsgSend) GetSigners() []sdk.AccAddress {
 	return addrs
 }
 
-//----------------------------------------
-// MsgIssue
-
-// MsgIssue - high level transaction of the coin module
-type MsgIssue struct {
-	Banker  sdk.AccAddress `json:"banker"`
-	Outputs []Output       `json:"outputs"`
-}
-
-var _ sdk.Msg = MsgIssue{}
-
-// NewMsgIssue - construct arbitrary multi-in, multi-out send msg.
-func NewMsgIssue(banker sdk.AccAddress, out []Output) MsgIssue {
-	return MsgIssue{Banker: banker, Outputs: out}
-}
-
-// Implements Msg.
-func (msg MsgIssue) Type() string { return "bank" } // TODO: "bank/issue"
-
-// Implements Msg.
-func (msg MsgIssue) ValidateBasic() sdk.Error {
-	// XXX
-	if len(msg.Outputs) == 0 {
-		return ErrNoOutputs(DefaultCodespace).TraceSDK("")
-	}
-	for _, out := range msg.Outputs {
-		if err := out.ValidateBasic(); err != nil {
-			return err.TraceSDK("")
-		}
-	}
-	return nil
-}
-
-// Implements Msg.
-func (msg MsgIssue) GetSignBytes() []byte {
-	var outputs []json.RawMessage
-	for _, output := range msg.Outputs {
-		outputs = append(outputs, output.GetSignBytes())
-	}
-	b, err := msgCdc.MarshalJSON(struct {
-		Banker  sdk.AccAddress    `json:"banker"`
-		Outputs []json.RawMessage `json:"outputs"`
-	}{
-		Banker:  msg.Banker,
-		Outputs: outputs,
-	})
-	if err != nil {
-		panic(err)
-	}
-	return sdk.MustSortJSON(b)
-}
-
-// Implements Msg.
-func (msg MsgIssue) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.Banker}
-}
-
 //----------------------------------------
 // Input
 
@@ -185,10 +132,11 @@ func NewInput(addr sdk.AccAddress, coins sdk.Coins) Input {
 //----------------------------------------
 // Output
 
-// Transaction Output
+// Transaction Output, the memo is an optional note to the recipient
 type Output struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+	Memo    string         `json:"memo,omitempty"`
 }
 
 // Return bytes to sign for Output
@@ -211,6 +159,10 @@ func (out Output) ValidateBasic() sdk.Error {
 	if !out.Coins.IsPositive() {
 		return sdk.ErrInvalidCoins(out.Coins.String())
 	}
+	if len(out.Memo) > maxOutputMemoCharacters {
+		return sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters",
+			maxOutputMemoCharacters, len(out.Memo)))
+	}
 	return nil
 }
 
@@ -222,3 +174,10 @@ func NewOutput(addr sdk.AccAddress, coins sdk.Coins) Output {
 	}
 	return output
 }
+
+// NewOutputWithMemo - create a transaction output with a memo to the recipient
+func NewOutputWithMemo(addr sdk.AccAddress, coins sdk.Coins, memo string) Output {
+	output := NewOutput(addr, coins)
+	output.Memo = memo
+	return output
+}
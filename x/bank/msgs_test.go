@@ -2,6 +2,7 @@ package bank
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -89,6 +90,7 @@ func TestOutputValidation(t *testing.T) {
 		{true, NewOutput(addr1, someCoins)},
 		{true, NewOutput(addr2, someCoins)},
 		{true, NewOutput(addr2, multiCoins)},
+		{true, NewOutputWithMemo(addr1, someCoins, "salary")},
 
 		{false, NewOutput(emptyAddr, someCoins)},  // empty address
 		{false, NewOutput(addr1, emptyCoins)},     // invalid coins
@@ -97,6 +99,8 @@ func TestOutputValidation(t *testing.T) {
 		{false, NewOutput(addr1, minusCoins)},     // negative coins
 		{false, NewOutput(addr1, someMinusCoins)}, // negative coins
 		{false, NewOutput(addr1, unsortedCoins)},  // unsorted coins
+
+		{false, NewOutputWithMemo(addr1, someCoins, strings.Repeat("a", 101))}, // memo too long
 	}
 
 	for i, tc := range cases {
@@ -189,6 +193,13 @@ func TestMsgSendGetSignBytes(t *testing.T) {
 
 	expected := `{"inputs":[{"address":"cosmosaccaddr1d9h8qat5e4ehc5","coins":[{"amount":"10","denom":"atom"}]}],"outputs":[{"address":"cosmosaccaddr1da6hgur4wse3jx32","coins":[{"amount":"10","denom":"atom"}]}]}`
 	require.Equal(t, expected, string(res))
+
+	// the memo of an output is signed
+	msg.Outputs = []Output{NewOutputWithMemo(addr2, coins, "thanks")}
+	res = msg.GetSignBytes()
+
+	expected = `{"inputs":[{"address":"cosmosaccaddr1d9h8qat5e4ehc5","coins":[{"amount":"10","denom":"atom"}]}],"outputs":[{"address":"cosmosaccaddr1da6hgur4wse3jx32","coins":[{"amount":"10","denom":"atom"}],"memo":"thanks"}]}`
+	require.Equal(t, expected, string(res))
 }
 
 func TestMsgSendGetSigners(t *testing.T) {
@@ -223,48 +234,3 @@ func TestMsgSendSigners(t *testing.T) {
 	require.Equal(t, signers, tx.Signers())
 }
 */
-
-// ----------------------------------------
-// MsgIssue Tests
-
-func TestNewMsgIssue(t *testing.T) {
-	// TODO
-}
-
-func TestMsgIssueType(t *testing.T) {
-	// Construct an MsgIssue
-	addr := sdk.AccAddress([]byte("loan-from-bank"))
-	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
-	var msg = MsgIssue{
-		Banker:  sdk.AccAddress([]byte("input")),
-		Outputs: []Output{NewOutput(addr, coins)},
-	}
-
-	// TODO some failures for bad result
-	require.Equal(t, msg.Type(), "bank")
-}
-
-func TestMsgIssueValidation(t *testing.T) {
-	// TODO
-}
-
-func TestMsgIssueGetSignBytes(t *testing.T) {
-	addr := sdk.AccAddress([]byte("loan-from-bank"))
-	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
-	var msg = MsgIssue{
-		Banker:  sdk.AccAddress([]byte("input")),
-		Outputs: []Output{NewOutput(addr, coins)},
-	}
-	res := msg.GetSignBytes()
-
-	expected := `{"banker":"cosmosaccaddr1d9h8qat5e4ehc5","outputs":[{"address":"cosmosaccaddr1d3hkzm3dveex7mfdvfsku6cwsauqd","coins":[{"amount":"10","denom":"atom"}]}]}`
-	require.Equal(t, expected, string(res))
-}
-
-func TestMsgIssueGetSigners(t *testing.T) {
-	var msg = MsgIssue{
-		Banker: sdk.AccAddress([]byte("onlyone")),
-	}
-	res := msg.GetSigners()
-	require.Equal(t, fmt.Sprintf("%v", res), "[6F6E6C796F6E65]")
-}
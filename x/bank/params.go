@@ -0,0 +1,56 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// nolint
+const (
+	SendEnabledKey           = "bank/SendEnabled"
+	NonTransferableDenomsKey = "bank/NonTransferableDenoms"
+)
+
+// RegisterParamTypes registers the types of the bank params, so that they can
+// be changed by governance
+func RegisterParamTypes(k params.Keeper) {
+	k.RegisterType(SendEnabledKey, false)
+	k.RegisterType(NonTransferableDenomsKey, []string{})
+}
+
+// SendEnabled - whether transfers between accounts are enabled, enabled by default
+func (keeper Keeper) SendEnabled(ctx sdk.Context) bool {
+	return sendEnabled(ctx, keeper.params)
+}
+
+// NonTransferableDenoms - denominations which cannot be transferred between accounts
+func (keeper Keeper) NonTransferableDenoms(ctx sdk.Context) []string {
+	return nonTransferableDenoms(ctx, keeper.params)
+}
+
+func sendEnabled(ctx sdk.Context, pg params.Getter) bool {
+	return pg.GetBoolWithDefault(ctx, SendEnabledKey, true)
+}
+
+func nonTransferableDenoms(ctx sdk.Context, pg params.Getter) (denoms []string) {
+	if pg.Get(ctx, NonTransferableDenomsKey, &denoms) != nil {
+		return nil
+	}
+	return denoms
+}
+
+// checkSendEnabled returns an error if transfers are disabled, or if any of
+// the coins is of a non-transferable denomination
+func checkSendEnabled(ctx sdk.Context, pg params.Getter, amt sdk.Coins) sdk.Error {
+	if !sendEnabled(ctx, pg) {
+		return ErrSendDisabled(DefaultCodespace, "transfers are currently disabled")
+	}
+	for _, denom := range nonTransferableDenoms(ctx, pg) {
+		if !amt.AmountOf(denom).IsZero() {
+			return ErrSendDisabled(DefaultCodespace, fmt.Sprintf("%s transfers are currently disabled", denom))
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -48,3 +49,13 @@ func TotalCoinsInvariant(mapper auth.AccountMapper, totalSupplyFn func() sdk.Coi
 		require.Equal(t, totalSupplyFn(), totalCoins, log)
 	}
 }
+
+// SupplyInvariant checks that the total supply recorded by the supply keeper
+// equals the sum of the coins across all accounts, including module accounts
+func SupplyInvariant(mapper auth.AccountMapper, sk bank.SupplyKeeper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+		err := bank.SupplyInvariant(mapper, sk)(ctx)
+		require.Nil(t, err, "%v\nlog: %s", err, log)
+	}
+}
@@ -0,0 +1,159 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var (
+	// SupplyKey is the key under which the total supply is stored
+	SupplyKey = []byte("supply")
+)
+
+// SupplyKeeper keeps track of the total supply of every denomination. Coins
+// can only be minted and burned through module accounts which have been
+// granted the corresponding permission, so that every change is recorded.
+type SupplyKeeper struct {
+
+	// The (unexposed) key used to access the supply store from the Context.
+	key sdk.StoreKey
+
+	// The wire codec for binary encoding/decoding of the supply.
+	cdc *wire.Codec
+
+	// The reference to the Keeper to modify balances
+	ck Keeper
+
+	// The permissions granted to each module account, by module name
+	permissions map[string][]string
+}
+
+// NewSupplyKeeper returns a new SupplyKeeper. Only the modules present in
+// permissions may own a module account managed by this keeper.
+func NewSupplyKeeper(cdc *wire.Codec, key sdk.StoreKey, ck Keeper, permissions map[string][]string) SupplyKeeper {
+	return SupplyKeeper{
+		key:         key,
+		cdc:         cdc,
+		ck:          ck,
+		permissions: permissions,
+	}
+}
+
+// GetSupply returns the total supply of all denominations
+func (sk SupplyKeeper) GetSupply(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(sk.key)
+	bz := store.Get(SupplyKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+
+	supply := &(sdk.Coins{})
+	sk.cdc.MustUnmarshalBinary(bz, supply)
+	return *supply
+}
+
+// GetSupplyOf returns the total supply of a single denomination
+func (sk SupplyKeeper) GetSupplyOf(ctx sdk.Context, denom string) sdk.Int {
+	return sk.GetSupply(ctx).AmountOf(denom)
+}
+
+// SetSupply sets the total supply of all denominations
+func (sk SupplyKeeper) SetSupply(ctx sdk.Context, supply sdk.Coins) {
+	bz := sk.cdc.MustMarshalBinary(supply)
+	store := ctx.KVStore(sk.key)
+	store.Set(SupplyKey, bz)
+}
+
+// Inflate records newly minted coins in the total supply
+func (sk SupplyKeeper) Inflate(ctx sdk.Context, amt sdk.Coins) {
+	sk.SetSupply(ctx, sk.GetSupply(ctx).Plus(amt))
+}
+
+// Deflate records burned coins in the total supply
+func (sk SupplyKeeper) Deflate(ctx sdk.Context, amt sdk.Coins) {
+	supply := sk.GetSupply(ctx)
+	newSupply := supply.Minus(amt)
+	if !newSupply.IsNotNegative() {
+		panic(fmt.Sprintf("burned more coins than exist in the supply: %s < %s", supply, amt))
+	}
+	sk.SetSupply(ctx, newSupply)
+}
+
+// HasPermission returns whether the named module has been granted a permission
+func (sk SupplyKeeper) HasPermission(moduleName string, permission string) bool {
+	for _, perm := range sk.permissions[moduleName] {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GetModuleAccount returns the account of a registered module, creating it if
+// it does not exist yet
+func (sk SupplyKeeper) GetModuleAccount(ctx sdk.Context, moduleName string) *auth.ModuleAccount {
+	if _, ok := sk.permissions[moduleName]; !ok {
+		panic(fmt.Sprintf("module account %s has not been registered", moduleName))
+	}
+	return sk.ck.am.GetModuleAccount(ctx, moduleName)
+}
+
+// MintCoins creates new coins in the account of a module with the Minter
+// permission and adds them to the total supply
+func (sk SupplyKeeper) MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error {
+	sk.requirePermission(moduleName, auth.Minter)
+	macc := sk.GetModuleAccount(ctx, moduleName)
+
+	_, _, err := addCoins(ctx, sk.ck.am, macc.GetAddress(), amt)
+	if err != nil {
+		return err
+	}
+	sk.Inflate(ctx, amt)
+
+	logger := ctx.Logger().With("module", "x/bank")
+	logger.Info(fmt.Sprintf("minted %s from %s module account", amt, moduleName))
+	return nil
+}
+
+// BurnCoins destroys coins held in the account of a module with the Burner
+// permission and removes them from the total supply
+func (sk SupplyKeeper) BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error {
+	sk.requirePermission(moduleName, auth.Burner)
+	macc := sk.GetModuleAccount(ctx, moduleName)
+
+	_, _, err := subtractCoins(ctx, sk.ck.am, macc.GetAddress(), amt)
+	if err != nil {
+		return err
+	}
+	sk.Deflate(ctx, amt)
+
+	logger := ctx.Logger().With("module", "x/bank")
+	logger.Info(fmt.Sprintf("burned %s from %s module account", amt, moduleName))
+	return nil
+}
+
+// DelegateCoins moves coins delegated by an account into the account of a
+// module with the Staking permission
+func (sk SupplyKeeper) DelegateCoins(ctx sdk.Context, delegatorAddr sdk.AccAddress, moduleName string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	sk.requirePermission(moduleName, auth.Staking)
+	macc := sk.GetModuleAccount(ctx, moduleName)
+	return sendCoins(ctx, sk.ck.am, delegatorAddr, macc.GetAddress(), amt)
+}
+
+// UndelegateCoins returns coins held by a module with the Staking permission
+// to the delegator
+func (sk SupplyKeeper) UndelegateCoins(ctx sdk.Context, moduleName string, delegatorAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	sk.requirePermission(moduleName, auth.Staking)
+	macc := sk.GetModuleAccount(ctx, moduleName)
+	return sendCoins(ctx, sk.ck.am, macc.GetAddress(), delegatorAddr, amt)
+}
+
+// panic if a module has not been granted a permission
+func (sk SupplyKeeper) requirePermission(moduleName string, permission string) {
+	if !sk.HasPermission(moduleName, permission) {
+		panic(fmt.Sprintf("module account %s does not have %s permission", moduleName, permission))
+	}
+}
@@ -0,0 +1,107 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	wire "github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func setupSupplyKeeper() (sdk.Context, auth.AccountMapper, SupplyKeeper) {
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey("authkey")
+	supplyKey := sdk.NewKVStoreKey("supply")
+	paramsKey := sdk.NewKVStoreKey("params")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(supplyKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	ms.LoadLatestVersion()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	supplyKeeper := NewSupplyKeeper(cdc, supplyKey, NewKeeper(accountMapper, params.NewKeeper(cdc, paramsKey).Getter(), nil), map[string][]string{
+		"minter":  {auth.Minter},
+		"burner":  {auth.Burner},
+		"staking": {auth.Staking},
+		"basic":   nil,
+	})
+	return ctx, accountMapper, supplyKeeper
+}
+
+func TestSupplyKeeper(t *testing.T) {
+	ctx, _, supplyKeeper := setupSupplyKeeper()
+
+	// Test empty supply
+	require.True(t, supplyKeeper.GetSupply(ctx).IsEqual(sdk.Coins{}))
+	require.True(t, supplyKeeper.GetSupplyOf(ctx, "foocoin").IsZero())
+
+	// Test Inflate
+	supplyKeeper.Inflate(ctx, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	supplyKeeper.Inflate(ctx, sdk.Coins{sdk.NewCoin("barcoin", 5), sdk.NewCoin("foocoin", 5)})
+	require.True(t, supplyKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("barcoin", 5), sdk.NewCoin("foocoin", 15)}))
+	require.Equal(t, int64(15), supplyKeeper.GetSupplyOf(ctx, "foocoin").Int64())
+
+	// Test Deflate
+	supplyKeeper.Deflate(ctx, sdk.Coins{sdk.NewCoin("barcoin", 5)})
+	require.True(t, supplyKeeper.GetSupply(ctx).IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 15)}))
+
+	// Burning more than the supply should panic
+	require.Panics(t, func() {
+		supplyKeeper.Deflate(ctx, sdk.Coins{sdk.NewCoin("foocoin", 20)})
+	})
+
+	// Test genesis round trip
+	InitGenesis(ctx, supplyKeeper, NewGenesisState(sdk.Coins{sdk.NewCoin("foocoin", 100)}))
+	require.True(t, WriteGenesis(ctx, supplyKeeper).Supply.IsEqual(sdk.Coins{sdk.NewCoin("foocoin", 100)}))
+}
+
+func TestSupplyKeeperModuleAccounts(t *testing.T) {
+	ctx, accountMapper, supplyKeeper := setupSupplyKeeper()
+	coins := sdk.Coins{sdk.NewCoin("foocoin", 10)}
+	addr := sdk.AccAddress([]byte("addr1"))
+
+	// Unregistered modules have no account
+	require.Panics(t, func() { supplyKeeper.GetModuleAccount(ctx, "unknown") })
+	require.Equal(t, auth.NewModuleAddress("basic"), supplyKeeper.GetModuleAccount(ctx, "basic").GetAddress())
+
+	// Test MintCoins
+	require.Panics(t, func() { supplyKeeper.MintCoins(ctx, "burner", coins) })
+	require.Nil(t, supplyKeeper.MintCoins(ctx, "minter", coins))
+	require.True(t, supplyKeeper.GetModuleAccount(ctx, "minter").GetCoins().IsEqual(coins))
+	require.True(t, supplyKeeper.GetSupply(ctx).IsEqual(coins))
+
+	// Test BurnCoins
+	_, err := supplyKeeper.ck.SendCoinsFromModuleToModule(ctx, "minter", "burner", coins)
+	require.Nil(t, err)
+	require.Panics(t, func() { supplyKeeper.BurnCoins(ctx, "minter", coins) })
+	require.NotNil(t, supplyKeeper.BurnCoins(ctx, "burner", coins.Plus(coins)))
+	require.Nil(t, supplyKeeper.BurnCoins(ctx, "burner", coins))
+	require.True(t, supplyKeeper.GetModuleAccount(ctx, "burner").GetCoins().IsZero())
+	require.True(t, supplyKeeper.GetSupply(ctx).IsZero())
+
+	// Test DelegateCoins/UndelegateCoins
+	acc := accountMapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(coins)
+	accountMapper.SetAccount(ctx, acc)
+	_, err = supplyKeeper.DelegateCoins(ctx, addr, "staking", coins)
+	require.Nil(t, err)
+	require.True(t, supplyKeeper.GetModuleAccount(ctx, "staking").GetCoins().IsEqual(coins))
+	require.Panics(t, func() { supplyKeeper.UndelegateCoins(ctx, "basic", addr, coins) })
+	_, err = supplyKeeper.UndelegateCoins(ctx, "staking", addr, coins)
+	require.Nil(t, err)
+	require.True(t, accountMapper.GetAccount(ctx, addr).GetCoins().IsEqual(coins))
+}
@@ -0,0 +1,58 @@
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InvarRoute is an invariant registered by a module under a route
+type InvarRoute struct {
+	ModuleName string
+	Route      string
+	Invar      sdk.Invariant
+}
+
+// FullRoute returns the route of the invariant prefixed by its module name
+func (i InvarRoute) FullRoute() string {
+	return i.ModuleName + "/" + i.Route
+}
+
+// Registry holds the invariants registered by the modules of an application,
+// so they can be asserted on demand or periodically against the state
+type Registry struct {
+	routes []InvarRoute
+}
+
+// NewRegistry returns an empty invariant registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// RegisterRoute registers an invariant of a module under a route, routes must
+// be unique within a module
+func (r *Registry) RegisterRoute(moduleName, route string, invar sdk.Invariant) {
+	invarRoute := InvarRoute{moduleName, route, invar}
+	for _, existing := range r.routes {
+		if existing.FullRoute() == invarRoute.FullRoute() {
+			panic(fmt.Sprintf("invariant %s already registered", invarRoute.FullRoute()))
+		}
+	}
+	r.routes = append(r.routes, invarRoute)
+}
+
+// Routes returns the registered invariants in registration order
+func (r *Registry) Routes() []InvarRoute {
+	return r.routes
+}
+
+// AssertInvariants runs all the registered invariants against the state of
+// the context, it returns an error naming the first broken invariant
+func (r *Registry) AssertInvariants(ctx sdk.Context) error {
+	for _, invarRoute := range r.routes {
+		if err := invarRoute.Invar(ctx); err != nil {
+			return fmt.Errorf("invariant broken: %s: %v", invarRoute.FullRoute(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package crisis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAssertInvariants(t *testing.T) {
+	ctx := sdk.Context{}
+	r := NewRegistry()
+	require.Nil(t, r.AssertInvariants(ctx))
+
+	var ran []string
+	holds := func(route string) sdk.Invariant {
+		return func(ctx sdk.Context) error {
+			ran = append(ran, route)
+			return nil
+		}
+	}
+	r.RegisterRoute("bank", "supply", holds("bank/supply"))
+	r.RegisterRoute("stake", "supply", holds("stake/supply"))
+	require.Nil(t, r.AssertInvariants(ctx))
+	require.Equal(t, []string{"bank/supply", "stake/supply"}, ran)
+
+	// routes must be unique within a module
+	require.Panics(t, func() { r.RegisterRoute("stake", "supply", holds("stake/supply")) })
+
+	// the first broken invariant is reported
+	r.RegisterRoute("stake", "power", func(ctx sdk.Context) error {
+		return errors.New("negative power")
+	})
+	r.RegisterRoute("stake", "shares", func(ctx sdk.Context) error {
+		return errors.New("shares mismatch")
+	})
+	err := r.AssertInvariants(ctx)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "stake/power")
+	require.Contains(t, err.Error(), "negative power")
+	require.Len(t, r.Routes(), 4)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+)
+
+// get the command to query the evidence handled by the chain
+func GetCmdQueryEvidence(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evidence [hash]",
+		Short: "Query a piece of evidence by hash, or all the evidence handled if no hash is given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+
+			var records []evidence.Record
+			if len(args) == 1 {
+				hash, err := hex.DecodeString(args[0])
+				if err != nil {
+					return err
+				}
+				res, err := ctx.QueryStore(evidence.GetEvidenceKey(hash), storeName)
+				if err != nil {
+					return err
+				}
+				if len(res) == 0 {
+					return fmt.Errorf("no evidence found with hash %s", args[0])
+				}
+				var record evidence.Record
+				cdc.MustUnmarshalBinary(res, &record)
+				records = append(records, record)
+			} else {
+				resKVs, err := ctx.QuerySubspace(cdc, evidence.EvidenceKeyPrefix, storeName)
+				if err != nil {
+					return err
+				}
+				for _, kv := range resKVs {
+					var record evidence.Record
+					cdc.MustUnmarshalBinary(kv.Value, &record)
+					records = append(records, record)
+				}
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+
+			case "text":
+				for _, record := range records {
+					fmt.Println(record.HumanReadableString())
+				}
+
+			case "json":
+				var output []byte
+				var err error
+				if len(args) == 1 {
+					output, err = wire.MarshalJSONIndent(cdc, records[0])
+				} else {
+					output, err = wire.MarshalJSONIndent(cdc, records)
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
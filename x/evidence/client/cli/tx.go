@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+)
+
+// create submit evidence command
+func GetCmdSubmitEvidence(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-evidence [evidence-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "submit evidence of the misbehaviour of a validator",
+		Long: `Submit evidence of the misbehaviour of a validator, read as JSON from a file.
+The evidence type must be registered by the app, for example:
+
+{"type":"<evidence type name>","value":{...}}`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var ev evidence.Evidence
+			err = cdc.UnmarshalJSON(bz, &ev)
+			if err != nil {
+				return err
+			}
+
+			submitter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := evidence.NewMsgSubmitEvidence(submitter, ev)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	return cmd
+}
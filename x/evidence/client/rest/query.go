@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+)
+
+func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/evidence",
+		allEvidenceHandlerFn(ctx, "evidence", cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/evidence/{hash}",
+		evidenceHandlerFn(ctx, "evidence", cdc),
+	).Methods("GET")
+}
+
+// http request handler to query all the evidence handled
+func allEvidenceHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resKVs, err := ctx.QuerySubspace(cdc, evidence.EvidenceKeyPrefix, storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query evidence. Error: %s", err.Error())))
+			return
+		}
+
+		records := make([]evidence.Record, 0, len(resKVs))
+		for _, kv := range resKVs {
+			var record evidence.Record
+			err = cdc.UnmarshalBinary(kv.Value, &record)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("couldn't decode evidence. Error: %s", err.Error())))
+				return
+			}
+			records = append(records, record)
+		}
+
+		output, err := cdc.MarshalJSON(records)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query a piece of evidence by hash
+func evidenceHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		hash, err := hex.DecodeString(vars["hash"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := ctx.QueryStore(evidence.GetEvidenceKey(hash), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query evidence. Error: %s", err.Error())))
+			return
+		}
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var record evidence.Record
+		err = cdc.UnmarshalBinary(res, &record)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't decode evidence. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(record)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -0,0 +1,36 @@
+//nolint
+package evidence
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default evidence codespace
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeInvalidEvidence     CodeType = 101
+	CodeUnknownEvidenceType CodeType = 102
+	CodeEvidenceExists      CodeType = 103
+	CodeNoValidator         CodeType = 104
+)
+
+func ErrInvalidEvidence(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidEvidence, fmt.Sprintf("invalid evidence: %s", msg))
+}
+func ErrUnknownEvidenceType(codespace sdk.CodespaceType, evidenceType string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownEvidenceType, fmt.Sprintf("no handler registered for evidence type %s", evidenceType))
+}
+func ErrEvidenceExists(codespace sdk.CodespaceType, hash []byte) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceExists, fmt.Sprintf("evidence %X was already handled", hash))
+}
+func ErrNoValidator(codespace sdk.CodespaceType, address crypto.Address) sdk.Error {
+	return sdk.NewError(codespace, CodeNoValidator, fmt.Sprintf("no validator with the consensus address %s", address))
+}
@@ -0,0 +1,39 @@
+package evidence
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "evidence" type messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgSubmitEvidence:
+			return handleMsgSubmitEvidence(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in evidence module").Result()
+		}
+	}
+}
+
+// Anyone may submit evidence of the misbehaviour of a validator, it is
+// handled by the handler registered for its type
+func handleMsgSubmitEvidence(ctx sdk.Context, msg MsgSubmitEvidence, k Keeper) sdk.Result {
+	err := k.HandleEvidence(ctx, msg.Evidence)
+	if err != nil {
+		return err.Result()
+	}
+
+	hash := Hash(k.cdc, msg.Evidence)
+	tags := sdk.NewTags(
+		"action", []byte("submit_evidence"),
+		"submitter", []byte(msg.Submitter.String()),
+		"evidence", []byte(hash.String()),
+	)
+
+	return sdk.Result{
+		Data: hash,
+		Tags: tags,
+	}
+}
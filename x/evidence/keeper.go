@@ -0,0 +1,211 @@
+package evidence
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Handler verifies a piece of evidence of a type against the state, the
+// validator it incriminates is only punished if it returns no error. It may
+// also record the misbehaviour in the state of the module registering it.
+type Handler func(ctx sdk.Context, evidence Evidence) sdk.Error
+
+// Policy is the punishment of the validator incriminated by a verified piece
+// of evidence of a type
+type Policy struct {
+	// fraction of the stake of the validator at the height of the
+	// infraction which is slashed
+	SlashFraction func(ctx sdk.Context) sdk.Rat
+
+	// number of seconds the validator is jailed for, the validator isn't
+	// jailed if it is nil
+	JailDuration func(ctx sdk.Context) int64
+}
+
+// FixedPolicy returns the policy slashing a constant fraction and jailing for
+// a constant number of seconds, a negative jail duration doesn't jail
+func FixedPolicy(slashFraction sdk.Rat, jailDuration int64) Policy {
+	policy := Policy{
+		SlashFraction: func(sdk.Context) sdk.Rat { return slashFraction },
+	}
+	if jailDuration >= 0 {
+		policy.JailDuration = func(sdk.Context) int64 { return jailDuration }
+	}
+	return policy
+}
+
+type route struct {
+	handler Handler
+	policy  Policy
+}
+
+// Keeper of the evidence store
+type Keeper struct {
+	storeKey       sdk.StoreKey
+	cdc            *wire.Codec
+	validatorSet   sdk.ValidatorSet
+	maxEvidenceAge func(ctx sdk.Context) int64 // age in seconds past which records are pruned
+	routes         map[string]route            // handler and policy of each evidence type
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an evidence keeper, the records of the evidence older
+// than the max evidence age are pruned, the handlers must reject such
+// evidence as it couldn't be deduplicated anymore
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, maxEvidenceAge func(ctx sdk.Context) int64, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:       key,
+		cdc:            cdc,
+		validatorSet:   vs,
+		maxEvidenceAge: maxEvidenceAge,
+		routes:         make(map[string]route),
+		codespace:      codespace,
+	}
+}
+
+// RegisterHandler registers the handler verifying the evidence of a type and
+// the policy punishing the validators it incriminates. An evidence type can
+// only be registered once.
+func (k Keeper) RegisterHandler(evidenceType string, handler Handler, policy Policy) {
+	if evidenceType == "" {
+		panic("evidence type cannot be empty")
+	}
+	if _, ok := k.routes[evidenceType]; ok {
+		panic(fmt.Sprintf("handler already registered for evidence type %s", evidenceType))
+	}
+	if handler == nil || policy.SlashFraction == nil {
+		panic(fmt.Sprintf("missing handler or slash fraction for evidence type %s", evidenceType))
+	}
+	k.routes[evidenceType] = route{handler, policy}
+}
+
+// HandleEvidence routes a piece of evidence to the handler of its type, then
+// slashes and jails the validator it incriminates as the policy of its type
+// says, and stores it. Evidence which was already handled is rejected.
+func (k Keeper) HandleEvidence(ctx sdk.Context, evidence Evidence) sdk.Error {
+	if err := evidence.ValidateBasic(); err != nil {
+		return err
+	}
+
+	route, ok := k.routes[evidence.Type()]
+	if !ok {
+		return ErrUnknownEvidenceType(k.codespace, evidence.Type())
+	}
+
+	hash := Hash(k.cdc, evidence)
+	if _, found := k.GetEvidence(ctx, hash); found {
+		return ErrEvidenceExists(k.codespace, hash)
+	}
+
+	pubkey := evidence.GetConsensusPubKey()
+	if k.validatorSet.ValidatorByPubKey(ctx, pubkey) == nil {
+		return ErrNoValidator(k.codespace, pubkey.Address())
+	}
+
+	err := route.handler(ctx, evidence)
+	if err != nil {
+		return err
+	}
+
+	slashFraction := route.policy.SlashFraction(ctx)
+	if slashFraction.GT(sdk.ZeroRat()) {
+		k.validatorSet.Slash(ctx, pubkey, evidence.GetHeight(), evidence.GetValidatorPower(), slashFraction)
+	}
+	if route.policy.JailDuration != nil {
+		k.validatorSet.Jail(ctx, pubkey, ctx.BlockHeader().Time+route.policy.JailDuration(ctx))
+	}
+
+	k.setRecord(ctx, Record{
+		Hash:     hash,
+		Evidence: evidence,
+		Height:   ctx.BlockHeight(),
+	})
+
+	logger := ctx.Logger().With("module", "x/evidence")
+	logger.Info(fmt.Sprintf("Handled evidence %s: %s", hash, evidence))
+	return nil
+}
+
+// GetEvidence returns the record of a piece of evidence by hash
+func (k Keeper) GetEvidence(ctx sdk.Context, hash cmn.HexBytes) (record Record, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetEvidenceKey(hash))
+	if bz == nil {
+		return record, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &record)
+	return record, true
+}
+
+// GetAllEvidence returns the records of all the evidence handled, ordered by
+// hash
+func (k Keeper) GetAllEvidence(ctx sdk.Context) (records []Record) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, EvidenceKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var record Record
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+
+// PruneEvidence deletes the records of the evidence older than the max
+// evidence age
+func (k Keeper) PruneEvidence(ctx sdk.Context) {
+	minTime := ctx.BlockHeader().Time - k.maxEvidenceAge(ctx)
+	if minTime <= 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(EvidenceByTimeKeyPrefix, GetEvidenceByTimeKey(minTime, nil))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	// delete the records once the iterator is closed
+	for _, key := range keys {
+		hash := key[len(EvidenceByTimeKeyPrefix)+8:]
+		store.Delete(GetEvidenceKey(hash))
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) setRecord(ctx sdk.Context, record Record) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetEvidenceKey(record.Hash), k.cdc.MustMarshalBinary(record))
+	store.Set(GetEvidenceByTimeKey(record.Evidence.GetTime(), record.Hash), []byte{})
+}
+
+//__________________________________________________________________
+
+// nolint
+var (
+	EvidenceKeyPrefix       = []byte{0x00} // prefix of the records of the evidence handled
+	EvidenceByTimeKeyPrefix = []byte{0x01} // prefix of the records indexed by time of the infraction
+)
+
+// GetEvidenceKey returns the key of the record of a piece of evidence by hash
+func GetEvidenceKey(hash cmn.HexBytes) []byte {
+	return append(EvidenceKeyPrefix, hash...)
+}
+
+// GetEvidenceByTimeKey returns the index key of the record of a piece of
+// evidence by time of the infraction, then hash
+func GetEvidenceByTimeKey(timestamp int64, hash cmn.HexBytes) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(timestamp))
+	key := append(append([]byte{}, EvidenceByTimeKeyPrefix...), timeBytes...)
+	return append(key, hash...)
+}
@@ -0,0 +1,121 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRegisterHandler(t *testing.T) {
+	_, _, keeper := createTestInput(t)
+	handler := func(sdk.Context, Evidence) sdk.Error { return nil }
+	policy := FixedPolicy(sdk.ZeroRat(), -1)
+
+	require.Panics(t, func() { keeper.RegisterHandler("", handler, policy) })
+	require.Panics(t, func() { keeper.RegisterHandler(testEvidenceType, nil, policy) })
+	require.Panics(t, func() { keeper.RegisterHandler(testEvidenceType, handler, Policy{}) })
+
+	keeper.RegisterHandler(testEvidenceType, handler, policy)
+	require.Panics(t, func() { keeper.RegisterHandler(testEvidenceType, handler, policy) })
+}
+
+func TestHandleEvidence(t *testing.T) {
+	ctx, sk, keeper := createTestInput(t)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100})
+	ev := testEvidence{pks[0], 0, 100, 0}
+
+	// no handler registered for the evidence type
+	err := keeper.HandleEvidence(ctx, ev)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownEvidenceType), err.ABCICode())
+
+	// a copy of the keeper routes the handlers registered afterwards
+	var handled []Evidence
+	copied := keeper
+	keeper.RegisterHandler(testEvidenceType, func(_ sdk.Context, evidence Evidence) sdk.Error {
+		handled = append(handled, evidence)
+		if evidence.GetHeight() < 0 {
+			return ErrInvalidEvidence(DefaultCodespace, "rejected by handler")
+		}
+		return nil
+	}, FixedPolicy(sdk.NewRat(1, 10), 60))
+
+	// evidence rejected by the handler doesn't punish the validator
+	err = copied.HandleEvidence(ctx, testEvidence{pks[0], -1, 100, 0})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), err.ABCICode())
+	require.Len(t, handled, 1)
+	require.False(t, sk.Validator(ctx, addrs[0]).GetJailed())
+	require.Empty(t, keeper.GetAllEvidence(ctx))
+
+	// the validator is slashed and jailed as the policy says
+	err = copied.HandleEvidence(ctx, ev)
+	require.Nil(t, err)
+	require.Len(t, handled, 2)
+	validator := sk.Validator(ctx, addrs[0])
+	require.True(t, validator.GetJailed())
+	require.Equal(t, int64(160), validator.GetJailedUntil())
+	require.True(t, sdk.NewRat(90).Equal(validator.GetTokens()))
+	require.False(t, sk.Validator(ctx, addrs[1]).GetJailed())
+
+	// the evidence is stored
+	hash := Hash(keeper.cdc, ev)
+	record, found := keeper.GetEvidence(ctx, hash)
+	require.True(t, found)
+	require.Equal(t, Record{hash, ev, 0}, record)
+	require.Equal(t, []Record{record}, keeper.GetAllEvidence(ctx))
+
+	// the same evidence is only handled once
+	err = keeper.HandleEvidence(ctx, ev)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceExists), err.ABCICode())
+	require.Len(t, handled, 2)
+
+	// evidence against an unknown validator
+	err = keeper.HandleEvidence(ctx, testEvidence{newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB59"), 0, 100, 0})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoValidator), err.ABCICode())
+	require.Len(t, handled, 2)
+}
+
+func TestHandleEvidenceNoPunishment(t *testing.T) {
+	ctx, sk, keeper := createTestInput(t)
+	keeper.RegisterHandler(testEvidenceType, func(sdk.Context, Evidence) sdk.Error { return nil },
+		FixedPolicy(sdk.ZeroRat(), -1))
+
+	err := keeper.HandleEvidence(ctx, testEvidence{pks[1], 0, 100, 0})
+	require.Nil(t, err)
+	validator := sk.Validator(ctx, addrs[1])
+	require.False(t, validator.GetJailed())
+	require.True(t, sdk.NewRat(100).Equal(validator.GetTokens()))
+	require.Len(t, keeper.GetAllEvidence(ctx), 1)
+}
+
+func TestPruneEvidence(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	keeper.RegisterHandler(testEvidenceType, func(sdk.Context, Evidence) sdk.Error { return nil },
+		FixedPolicy(sdk.ZeroRat(), -1))
+
+	// invalid evidence is rejected before being routed
+	err := keeper.HandleEvidence(ctx, testEvidence{nil, 0, 100, 0})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), err.ABCICode())
+
+	old := testEvidence{pks[0], 1, 100, 10}
+	recent := testEvidence{pks[1], 2, 100, 20}
+	require.Nil(t, keeper.HandleEvidence(ctx, old))
+	require.Nil(t, keeper.HandleEvidence(ctx, recent))
+
+	// evidence exactly at the max evidence age is kept
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 10 + maxEvidenceAge})
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, keeper)
+	require.Len(t, keeper.GetAllEvidence(ctx), 2)
+
+	// older evidence is pruned
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 11 + maxEvidenceAge})
+	BeginBlocker(ctx, abci.RequestBeginBlock{}, keeper)
+	_, found := keeper.GetEvidence(ctx, Hash(keeper.cdc, old))
+	require.False(t, found)
+	record, found := keeper.GetEvidence(ctx, Hash(keeper.cdc, recent))
+	require.True(t, found)
+	require.Equal(t, []Record{record}, keeper.GetAllEvidence(ctx))
+}
@@ -0,0 +1,65 @@
+package evidence
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "evidence"
+
+// verify interface at compile time
+var _ sdk.Msg = MsgSubmitEvidence{}
+
+// MsgSubmitEvidence - struct for submitting app-level evidence of the
+// misbehaviour of a validator
+type MsgSubmitEvidence struct {
+	Submitter sdk.AccAddress `json:"submitter"`
+	Evidence  Evidence       `json:"evidence"`
+}
+
+func NewMsgSubmitEvidence(submitter sdk.AccAddress, evidence Evidence) MsgSubmitEvidence {
+	return MsgSubmitEvidence{
+		Submitter: submitter,
+		Evidence:  evidence,
+	}
+}
+
+//nolint
+func (msg MsgSubmitEvidence) Type() string                 { return MsgType }
+func (msg MsgSubmitEvidence) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Submitter} }
+
+// get the bytes for the message signer to sign on, the evidence signs its
+// own bytes
+func (msg MsgSubmitEvidence) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Submitter    sdk.AccAddress
+		EvidenceType string
+		Evidence     json.RawMessage
+	}{
+		Submitter:    msg.Submitter,
+		EvidenceType: msg.Evidence.Type(),
+		Evidence:     json.RawMessage(msg.Evidence.GetSignBytes()),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSubmitEvidence) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Submitter.String())
+	}
+	if msg.Evidence == nil {
+		return ErrInvalidEvidence(DefaultCodespace, "missing evidence")
+	}
+	// the evidence reported by tendermint was verified by tendermint, it
+	// cannot be submitted by users
+	if _, ok := msg.Evidence.(ABCIEvidence); ok {
+		return ErrInvalidEvidence(DefaultCodespace, "evidence reported by tendermint cannot be submitted")
+	}
+	return msg.Evidence.ValidateBasic()
+}
@@ -0,0 +1,40 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMsgSubmitEvidenceValidateBasic(t *testing.T) {
+	submitter := sdk.AccAddress("abcd")
+	tests := []struct {
+		name       string
+		msg        MsgSubmitEvidence
+		expectPass bool
+	}{
+		{"valid", NewMsgSubmitEvidence(submitter, testEvidence{pks[0], 1, 100, 0}), true},
+		{"no submitter", NewMsgSubmitEvidence(nil, testEvidence{pks[0], 1, 100, 0}), false},
+		{"no evidence", NewMsgSubmitEvidence(submitter, nil), false},
+		{"invalid evidence", NewMsgSubmitEvidence(submitter, testEvidence{nil, 1, 100, 0}), false},
+		{"tendermint evidence", NewMsgSubmitEvidence(submitter,
+			NewABCIEvidence(tmtypes.ABCIEvidenceTypeDuplicateVote, pks[0], 1, 0, 100)), false},
+	}
+
+	for _, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, tc.msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, tc.msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgSubmitEvidenceGetSignBytes(t *testing.T) {
+	msg := NewMsgSubmitEvidence(sdk.AccAddress("abcd"), testEvidence{pks[0], 1, 100, 0})
+	bytes := msg.GetSignBytes()
+	require.Equal(t, `{"Evidence":{"height":1,"power":100},"EvidenceType":"test","Submitter":"cosmosaccaddr1v93xxeqhyqz5v"}`, string(bytes))
+}
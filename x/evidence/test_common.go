@@ -0,0 +1,153 @@
+package evidence
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	pks = []crypto.PubKey{
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB50"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB51"),
+	}
+	addrs = []sdk.AccAddress{
+		sdk.AccAddress(pks[0].Address()),
+		sdk.AccAddress(pks[1].Address()),
+	}
+	initCoins = sdk.NewInt(200)
+)
+
+// max evidence age of the test keeper
+const maxEvidenceAge int64 = 100
+
+// app-level evidence used by the tests
+type testEvidence struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+	Height int64         `json:"height"`
+	Power  int64         `json:"power"`
+	Time   int64         `json:"time"`
+}
+
+const testEvidenceType = "test"
+
+var _ Evidence = testEvidence{}
+
+// nolint
+func (e testEvidence) Type() string                      { return testEvidenceType }
+func (e testEvidence) String() string                    { return "test evidence" }
+func (e testEvidence) GetConsensusPubKey() crypto.PubKey { return e.PubKey }
+func (e testEvidence) GetHeight() int64                  { return e.Height }
+func (e testEvidence) GetTime() int64                    { return e.Time }
+func (e testEvidence) GetValidatorPower() int64          { return e.Power }
+
+func (e testEvidence) ValidateBasic() sdk.Error {
+	if e.PubKey == nil {
+		return ErrInvalidEvidence(DefaultCodespace, "validator pubkey cannot be empty")
+	}
+	return nil
+}
+
+func (e testEvidence) GetSignBytes() []byte {
+	b, err := json.Marshal(struct {
+		Height int64 `json:"height"`
+		Power  int64 `json:"power"`
+	}{e.Height, e.Power})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func createTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	RegisterWire(cdc)
+	cdc.RegisterConcrete(testEvidence{}, "test/testEvidence", nil)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
+// test input with the validators of addrs bonded
+func createTestInput(t *testing.T) (sdk.Context, stake.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyEvidence := sdk.NewKVStoreKey("evidence")
+	keyParams := sdk.NewKVStoreKey("params")
+	keySupply := sdk.NewKVStoreKey("supply")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyEvidence, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewTMLogger(os.Stdout))
+	cdc := createTestCodec()
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, auth.ProtoBaseAccount)
+	params := params.NewKeeper(cdc, keyParams)
+	ck := bank.NewKeeper(accountMapper, params.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(cdc, keySupply, ck, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+	})
+	sk := stake.NewKeeper(cdc, keyStake, ck, supplyKeeper, stake.DefaultCodespace)
+	genesis := stake.DefaultGenesisState()
+	genesis.Pool.LooseTokens = sdk.NewRat(initCoins.MulRaw(int64(len(addrs))).Int64())
+	_, err = stake.InitGenesis(ctx, sk, genesis)
+	require.Nil(t, err)
+
+	sh := stake.NewHandler(sk)
+	for i, addr := range addrs {
+		coins := sdk.Coins{
+			{sk.GetParams(ctx).BondDenom, initCoins},
+		}
+		_, _, err = ck.AddCoins(ctx, addr, coins)
+		require.Nil(t, err)
+		supplyKeeper.Inflate(ctx, coins)
+		got := sh(ctx, stake.MsgCreateValidator{
+			Description:       stake.Description{},
+			DelegatorAddr:     addr,
+			ValidatorAddr:     addr,
+			PubKey:            pks[i],
+			Delegation:        sdk.Coin{"steak", sdk.NewInt(100)},
+			MinSelfDelegation: sdk.OneInt(),
+		})
+		require.True(t, got.IsOK(), "%v", got)
+	}
+	stake.EndBlocker(ctx, sk)
+
+	keeper := NewKeeper(cdc, keyEvidence, sk, func(sdk.Context) int64 { return maxEvidenceAge }, DefaultCodespace)
+	return ctx, sk, keeper
+}
+
+func newPubKey(pk string) (res crypto.PubKey) {
+	pkBytes, err := hex.DecodeString(pk)
+	if err != nil {
+		panic(err)
+	}
+	var pkEd crypto.PubKeyEd25519
+	copy(pkEd[:], pkBytes[:])
+	return pkEd
+}
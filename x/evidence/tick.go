@@ -0,0 +1,36 @@
+package evidence
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// evidence begin block functionality
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) (tags sdk.Tags) {
+	logger := ctx.Logger().With("module", "x/evidence")
+
+	// Route the evidence of infraction reported by tendermint to the
+	// handlers of their type, which slash and jail the validators who
+	// contributed to valid infractions
+	for _, ev := range req.ByzantineValidators {
+		pk, err := tmtypes.PB2TM.PubKey(ev.Validator.PubKey)
+		if err != nil {
+			panic(err)
+		}
+		evidence := NewABCIEvidence(ev.Type, pk, ev.Height, ev.Time, ev.Validator.Power)
+		if err := k.HandleEvidence(ctx, evidence); err != nil {
+			logger.Info(fmt.Sprintf("Ignored %s: %s", evidence, err.ABCILog()))
+			continue
+		}
+		tags = tags.AppendTag("evidence", []byte(Hash(k.cdc, evidence).String()))
+	}
+
+	// Prune the records of the evidence past the max evidence age, which the
+	// handlers reject anyway
+	k.PruneEvidence(ctx)
+
+	return
+}
@@ -0,0 +1,121 @@
+package evidence
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Evidence is the proof of the misbehaviour of a validator, it is routed by
+// its type to the handler verifying it
+type Evidence interface {
+	// Type returns the type of the evidence, under which its handler is
+	// registered
+	Type() string
+
+	// String returns a human readable description of the evidence
+	String() string
+
+	// ValidateBasic does a stateless check of the evidence
+	ValidateBasic() sdk.Error
+
+	// GetSignBytes returns the canonical bytes of the evidence signed by
+	// the submitter of a MsgSubmitEvidence
+	GetSignBytes() []byte
+
+	// GetConsensusPubKey returns the consensus pubkey of the validator which
+	// misbehaved
+	GetConsensusPubKey() crypto.PubKey
+
+	// GetHeight returns the height of the infraction
+	GetHeight() int64
+
+	// GetTime returns the time of the infraction, in unix seconds
+	GetTime() int64
+
+	// GetValidatorPower returns the power of the validator at the height of
+	// the infraction
+	GetValidatorPower() int64
+}
+
+// Hash returns the hash of a piece of evidence, under which it is stored
+func Hash(cdc *wire.Codec, evidence Evidence) cmn.HexBytes {
+	return tmhash.Sum(cdc.MustMarshalBinary(evidence))
+}
+
+// Record is a piece of evidence handled by the chain
+type Record struct {
+	Hash     cmn.HexBytes `json:"hash"`
+	Evidence Evidence     `json:"evidence"`
+	Height   int64        `json:"height"` // height at which the evidence was handled
+}
+
+// Return human readable record
+func (r Record) HumanReadableString() string {
+	return fmt.Sprintf("Hash: %s, handled at height: %d\nEvidence: %s", r.Hash, r.Height, r.Evidence)
+}
+
+//__________________________________________________________________
+
+// ABCIEvidence is the evidence of misbehaviour tendermint reports in the
+// begin block, such as a validator signing two blocks at the same height
+type ABCIEvidence struct {
+	EvidenceType string        `json:"type"`
+	PubKey       crypto.PubKey `json:"pub_key"`
+	Height       int64         `json:"height"`
+	Time         int64         `json:"time"`
+	Power        int64         `json:"power"`
+}
+
+var _ Evidence = ABCIEvidence{}
+
+// NewABCIEvidence returns the evidence of misbehaviour reported by tendermint
+func NewABCIEvidence(evidenceType string, pubKey crypto.PubKey, height, time, power int64) ABCIEvidence {
+	return ABCIEvidence{
+		EvidenceType: evidenceType,
+		PubKey:       pubKey,
+		Height:       height,
+		Time:         time,
+		Power:        power,
+	}
+}
+
+// nolint
+func (e ABCIEvidence) Type() string                      { return e.EvidenceType }
+func (e ABCIEvidence) GetConsensusPubKey() crypto.PubKey { return e.PubKey }
+func (e ABCIEvidence) GetHeight() int64                  { return e.Height }
+func (e ABCIEvidence) GetTime() int64                    { return e.Time }
+func (e ABCIEvidence) GetValidatorPower() int64          { return e.Power }
+
+func (e ABCIEvidence) String() string {
+	return fmt.Sprintf("%s evidence against validator %s at height %d, time %d, power %d",
+		e.EvidenceType, e.PubKey.Address(), e.Height, e.Time, e.Power)
+}
+
+// ValidateBasic implements Evidence
+func (e ABCIEvidence) ValidateBasic() sdk.Error {
+	if e.EvidenceType == "" {
+		return ErrInvalidEvidence(DefaultCodespace, "evidence type cannot be empty")
+	}
+	if e.PubKey == nil {
+		return ErrInvalidEvidence(DefaultCodespace, "validator pubkey cannot be empty")
+	}
+	if e.Height < 0 || e.Power <= 0 {
+		return ErrInvalidEvidence(DefaultCodespace, "invalid height or power")
+	}
+	return nil
+}
+
+// GetSignBytes implements Evidence
+func (e ABCIEvidence) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(e)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
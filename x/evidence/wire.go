@@ -0,0 +1,20 @@
+package evidence
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec, the modules defining app-level
+// evidence register their evidence types on it as well
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterInterface((*Evidence)(nil), nil)
+	cdc.RegisterConcrete(ABCIEvidence{}, "cosmos-sdk/ABCIEvidence", nil)
+	cdc.RegisterConcrete(MsgSubmitEvidence{}, "cosmos-sdk/MsgSubmitEvidence", nil)
+}
+
+var msgCdc = wire.NewCodec()
+
+func init() {
+	RegisterWire(msgCdc)
+	wire.RegisterCrypto(msgCdc)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,6 +21,7 @@ const (
 	flagDescription  = "description"
 	flagProposalType = "type"
 	flagDeposit      = "deposit"
+	flagParamChange  = "param-change"
 	flagVoter        = "voter"
 	flagOption       = "option"
 )
@@ -53,8 +55,23 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 				return err
 			}
 
+			// parse the parameter changes, formatted as key=value with JSON values
+			paramChanges, err := cmd.Flags().GetStringArray(flagParamChange)
+			if err != nil {
+				return err
+			}
+			var changes []gov.ParamChange
+			for _, change := range paramChanges {
+				kv := strings.SplitN(change, "=", 2)
+				if len(kv) != 2 {
+					return fmt.Errorf("invalid parameter change %s, expected key=value", change)
+				}
+				changes = append(changes, gov.ParamChange{Key: kv[0], Value: kv[1]})
+			}
+
 			// create the message
 			msg := gov.NewMsgSubmitProposal(title, description, proposalType, fromAddr, amount)
+			msg.ParamChanges = changes
 
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -76,6 +93,7 @@ func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagDescription, "", "description of proposal")
 	cmd.Flags().String(flagProposalType, "", "proposalType of proposal")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	cmd.Flags().StringArray(flagParamChange, nil, "parameter change of a ParameterChange proposal as key=value, with a JSON value (e.g. bank/SendEnabled=true)")
 
 	return cmd
 }
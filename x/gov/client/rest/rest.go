@@ -39,12 +39,13 @@ func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 }
 
 type postProposalReq struct {
-	BaseReq        baseReq          `json:"base_req"`
-	Title          string           `json:"title"`           //  Title of the proposal
-	Description    string           `json:"description"`     //  Description of the proposal
-	ProposalType   gov.ProposalKind `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress   `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins        `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        baseReq           `json:"base_req"`
+	Title          string            `json:"title"`           //  Title of the proposal
+	Description    string            `json:"description"`     //  Description of the proposal
+	ProposalType   gov.ProposalKind  `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress    `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	ParamChanges   []gov.ParamChange `json:"param_changes"`   // Parameter changes of a ParameterChange proposal
 }
 
 type depositReq struct {
@@ -73,6 +74,7 @@ func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.Handle
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, req.ProposalType, req.Proposer, req.InitialDeposit)
+		msg.ParamChanges = req.ParamChanges
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
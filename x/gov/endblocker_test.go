@@ -7,6 +7,10 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -166,3 +170,47 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	depositsIterator.Close()
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
 }
+
+func TestTickPassedParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	val1CreateMsg := stake.NewMsgCreateValidator(addrs[0], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), stake.NewDescription("T1", "E", "S", "T"), sdk.OneInt())
+	require.True(t, stakeHandler(ctx, val1CreateMsg).IsOK())
+	val2CreateMsg := stake.NewMsgCreateValidator(addrs[1], crypto.GenPrivKeyEd25519().PubKey(), sdk.NewCoin("steak", 5), stake.NewDescription("T2", "E", "S", "T"), sdk.OneInt())
+	require.True(t, stakeHandler(ctx, val2CreateMsg).IsOK())
+
+	// only registered params can be changed
+	badChanges := []ParamChange{{Key: "unknown/Param", Value: "true"}}
+	newProposalMsg := NewMsgSubmitParamChangeProposal("Test", "test", addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}, badChanges)
+	res := govHandler(ctx, newProposalMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidParamChange), res.Code)
+
+	// values must decode into the registered type
+	badChanges = []ParamChange{{Key: bank.SendEnabledKey, Value: `"no"`}}
+	newProposalMsg = NewMsgSubmitParamChangeProposal("Test", "test", addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}, badChanges)
+	res = govHandler(ctx, newProposalMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidParamChange), res.Code)
+
+	changes := []ParamChange{{Key: bank.SendEnabledKey, Value: "false"}}
+	newProposalMsg = NewMsgSubmitParamChangeProposal("Test", "test", addrs[0], sdk.Coins{sdk.NewCoin("steak", 10)}, changes)
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	require.True(t, govHandler(ctx, NewMsgVote(addrs[0], proposalID, OptionYes)).IsOK())
+	require.True(t, govHandler(ctx, NewMsgVote(addrs[1], proposalID, OptionYes)).IsOK())
+	require.True(t, keeper.ck.SendEnabled(ctx))
+
+	ctx = ctx.WithBlockHeight(215)
+	require.True(t, shouldPopActiveProposalQueue(ctx, keeper))
+	EndBlocker(ctx, keeper)
+
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.False(t, keeper.ck.SendEnabled(ctx))
+}
@@ -24,7 +24,16 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	if msg.ProposalType == ProposalTypeParameterChange {
+		var err sdk.Error
+		proposal, err = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.ParamChanges)
+		if err != nil {
+			return err.Result()
+		}
+	} else {
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -127,6 +136,9 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags, nonVotingVals
 		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
 		var action []byte
 		if passes {
+			if pcp, ok := activeProposal.(*ParameterChangeProposal); ok {
+				resTags = resTags.AppendTags(keeper.applyParamChanges(ctx, pcp))
+			}
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusPassed)
 			action = tags.ActionProposalPassed
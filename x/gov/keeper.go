@@ -1,16 +1,29 @@
 package gov
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov/tags"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
+// ModuleName is the name of the module account holding all proposal deposits
+const ModuleName = "gov"
+
 // Governance Keeper
 type Keeper struct {
 	// The reference to the CoinKeeper to modify balances
 	ck bank.Keeper
 
+	// The reference to the SupplyKeeper to burn deposits
+	sk bank.SupplyKeeper
+
+	// The reference to the params Setter to apply parameter changes
+	ps params.Setter
+
 	// The ValidatorSet to get information about validators
 	vs sdk.ValidatorSet
 
@@ -28,10 +41,12 @@ type Keeper struct {
 }
 
 // NewGovernanceMapper returns a mapper that uses go-wire to (binary) encode and decode gov types.
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, sk bank.SupplyKeeper, ps params.Setter, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
 		storeKey:  key,
 		ck:        ck,
+		sk:        sk,
+		ps:        ps,
 		ds:        ds,
 		vs:        ds.GetValidatorSet(),
 		cdc:       cdc,
@@ -68,6 +83,57 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	return proposal
 }
 
+// Creates a new ParameterChangeProposal, the changed parameters must have a
+// registered type their values decode into
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) (Proposal, sdk.Error) {
+	for _, change := range changes {
+		if !keeper.ps.HasType(change.Key) {
+			return nil, ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("parameter %s cannot be changed", change.Key))
+		}
+		if err := keeper.ps.ValidateJSON(change.Key, []byte(change.Value)); err != nil {
+			return nil, ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: TextProposal{
+			ProposalID:       proposalID,
+			Title:            title,
+			Description:      description,
+			ProposalType:     ProposalTypeParameterChange,
+			Status:           StatusDepositPeriod,
+			TotalDeposit:     sdk.Coins{},
+			SubmitBlock:      ctx.BlockHeight(),
+			VotingStartBlock: -1, // TODO: Make Time
+		},
+		Changes: changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal, nil
+}
+
+// Applies the parameter changes of a passed ParameterChangeProposal, invalid
+// changes are logged, tagged and skipped
+func (keeper Keeper) applyParamChanges(ctx sdk.Context, proposal *ParameterChangeProposal) sdk.Tags {
+	logger := ctx.Logger().With("module", "x/gov")
+	resTags := sdk.NewTags()
+	for _, change := range proposal.Changes {
+		err := keeper.ps.SetJSON(ctx, change.Key, []byte(change.Value))
+		if err != nil {
+			logger.Error(fmt.Sprintf("proposal %d failed to change parameter %s: %v", proposal.GetProposalID(), change.Key, err))
+			resTags = resTags.AppendTag(tags.ParamChangeFailed, []byte(change.Key))
+			continue
+		}
+		logger.Info(fmt.Sprintf("proposal %d changed parameter %s to %s", proposal.GetProposalID(), change.Key, change.Value))
+	}
+	return resTags
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -249,8 +315,8 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 		return ErrAlreadyFinishedProposal(keeper.codespace, proposalID), false
 	}
 
-	// Subtract coins from depositer's account
-	_, _, err := keeper.ck.SubtractCoins(ctx, depositerAddr, depositAmount)
+	// Move coins from depositer's account to the module account
+	_, err := keeper.ck.SendCoinsFromAccountToModule(ctx, depositerAddr, ModuleName, depositAmount)
 	if err != nil {
 		return err, false
 	}
@@ -295,7 +361,7 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
 
-		_, _, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
+		_, err := keeper.ck.SendCoinsFromModuleToAccount(ctx, ModuleName, deposit.Depositer, deposit.Amount)
 		if err != nil {
 			panic("should not happen")
 		}
@@ -306,16 +372,28 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 	depositsIterator.Close()
 }
 
-// Deletes all the deposits on a specific proposal without refunding them
+// Deletes and burns all the deposits on a specific proposal without refunding them
 func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 
+	burned := sdk.Coins{}
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := &Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+		burned = burned.Plus(deposit.Amount)
+
 		store.Delete(depositsIterator.Key())
 	}
 
 	depositsIterator.Close()
+
+	if !burned.IsZero() {
+		err := keeper.sk.BurnCoins(ctx, ModuleName, burned)
+		if err != nil {
+			panic("should not happen")
+		}
+	}
 }
 
 // =====================================================
@@ -17,6 +17,7 @@ type MsgSubmitProposal struct {
 	ProposalType   ProposalKind   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       sdk.AccAddress //  Address of the proposer
 	InitialDeposit sdk.Coins      //  Initial deposit paid by sender. Must be strictly positive.
+	ParamChanges   []ParamChange  //  Parameter changes applied if a ParameterChange proposal passes
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -29,6 +30,17 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParamChangeProposal(title string, description string, proposer sdk.AccAddress, initialDeposit sdk.Coins, changes []ParamChange) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   changes,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 
@@ -52,6 +64,17 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if msg.ProposalType == ProposalTypeParameterChange && len(msg.ParamChanges) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "parameter change proposal without changes")
+	}
+	if msg.ProposalType != ProposalTypeParameterChange && len(msg.ParamChanges) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("%s proposal cannot change parameters", msg.ProposalType))
+	}
+	for _, change := range msg.ParamChanges {
+		if len(change.Key) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "parameter change without key")
+		}
+	}
 	return nil
 }
 
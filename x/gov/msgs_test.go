@@ -30,7 +30,7 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.AccAddress{}, coinsPos, false},
@@ -49,6 +49,34 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for parameter change proposals
+func TestMsgSubmitParamChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{{Key: "bank/SendEnabled", Value: "false"}}, true},
+		{[]ParamChange{{Key: "bank/SendEnabled", Value: "false"}, {Key: "bank/NonTransferableDenoms", Value: `["steak"]`}}, true},
+		{nil, false},
+		{[]ParamChange{{Key: "", Value: "false"}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParamChangeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], coinsPos, tc.changes)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// only parameter change proposals may carry changes
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = []ParamChange{{Key: "bank/SendEnabled", Value: "false"}}
+	require.NotNil(t, msg.ValidateBasic())
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -91,6 +91,24 @@ func (tp *TextProposal) SetVotingStartBlock(votingStartBlock int64) {
 	tp.VotingStartBlock = votingStartBlock
 }
 
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// ParamChange is a change of a single parameter, the value is JSON encoded
+type ParamChange struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ParameterChangeProposal is a proposal applying its parameter changes when it passes
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Parameter changes applied if the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
 //-----------------------------------------------------------
 // ProposalQueue
 type ProposalQueue []int64
@@ -12,8 +12,10 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -26,16 +28,24 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, Keeper, stake.Keeper,
 
 	keyStake := sdk.NewKVStoreKey("stake")
 	keyGov := sdk.NewKVStoreKey("gov")
-
-	ck := bank.NewKeeper(mapp.AccountMapper)
-	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
-	keeper := NewKeeper(mapp.Cdc, keyGov, ck, sk, DefaultCodespace)
+	keySupply := sdk.NewKVStoreKey("supply")
+	keyParams := sdk.NewKVStoreKey("params")
+
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	bank.RegisterParamTypes(paramsKeeper)
+	ck := bank.NewKeeper(mapp.AccountMapper, paramsKeeper.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(mapp.Cdc, keySupply, ck, map[string][]string{
+		stake.ModuleName: {auth.Burner, auth.Staking},
+		ModuleName:       {auth.Burner},
+	})
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, supplyKeeper, mapp.RegisterCodespace(stake.DefaultCodespace))
+	keeper := NewKeeper(mapp.Cdc, keyGov, ck, supplyKeeper, paramsKeeper.Setter(), sk, DefaultCodespace)
 	mapp.Router().AddRoute("gov", NewHandler(keeper))
 
-	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov}))
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov, keySupply, keyParams}))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
-	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk))
+	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, supplyKeeper))
 
 	genAccs, addrs, pubKeys, privKeys := mock.CreateGenAccounts(numGenAccs, sdk.Coins{sdk.NewCoin("steak", 42)})
 	mock.SetGenesis(mapp, genAccs)
@@ -54,10 +64,18 @@ func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 }
 
 // gov and stake initchainer
-func getInitChainer(mapp *mock.App, keeper Keeper, stakeKeeper stake.Keeper) sdk.InitChainer {
+func getInitChainer(mapp *mock.App, keeper Keeper, stakeKeeper stake.Keeper, supplyKeeper bank.SupplyKeeper) sdk.InitChainer {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 		mapp.InitChainer(ctx, req)
 
+		// record the coins of the genesis accounts in the total supply
+		supply := sdk.Coins{}
+		mapp.AccountMapper.IterateAccounts(ctx, func(acc auth.Account) bool {
+			supply = supply.Plus(acc.GetCoins())
+			return false
+		})
+		supplyKeeper.SetSupply(ctx, supply)
+
 		stakeGenesis := stake.DefaultGenesisState()
 		stakeGenesis.Pool.LooseTokens = sdk.NewRat(100000)
 
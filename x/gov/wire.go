@@ -13,6 +13,7 @@ func RegisterWire(cdc *wire.Codec) {
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&TextProposal{}, "gov/TextProposal", nil)
+	cdc.RegisterConcrete(&ParameterChangeProposal{}, "gov/ParameterChangeProposal", nil)
 }
 
 var msgCdc = wire.NewCodec()
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/params"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -20,18 +21,25 @@ func getMockApp(t *testing.T) *mock.App {
 
 	RegisterWire(mapp.Cdc)
 	keyIBC := sdk.NewKVStoreKey("ibc")
+	keyParams := sdk.NewKVStoreKey("params")
+	keySupply := sdk.NewKVStoreKey("supply")
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, mapp.RegisterCodespace(DefaultCodespace))
-	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, coinKeeper))
-
-	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyIBC}))
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	coinKeeper := bank.NewKeeper(mapp.AccountMapper, paramsKeeper.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(mapp.Cdc, keySupply, coinKeeper, map[string][]string{
+		ModuleName: {auth.Minter, auth.Burner},
+	})
+	ibcMapper.BindPort(TransferPort, NewTransferModule(ibcMapper, coinKeeper, supplyKeeper))
+	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper))
+
+	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyIBC, keyParams, keySupply}))
 	return mapp
 }
 
 func TestIBCMsgs(t *testing.T) {
 	mapp := getMockApp(t)
 
-	sourceChain := "source-chain"
+	sourceChain := "" // chain ID of the mock app
 	destChain := "dest-chain"
 
 	priv1 := crypto.GenPrivKeyEd25519()
@@ -52,28 +60,26 @@ func TestIBCMsgs(t *testing.T) {
 	res1 := mapp.AccountMapper.GetAccount(ctxCheck, addr1)
 	require.Equal(t, acc, res1)
 
-	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
-		SrcChain:  sourceChain,
-		DestChain: destChain,
-	}
-
-	transferMsg := IBCTransferMsg{
-		IBCPacket: packet,
-	}
+	transferMsg := NewIBCTransferMsg(addr1, addr1, coins, sourceChain, destChain, 100, 0)
 
 	receiveMsg := IBCReceiveMsg{
-		IBCPacket: packet,
+		IBCPacket: transferMsg.Packet(),
 		Relayer:   addr1,
 		Sequence:  0,
+		Height:    2,
 	}
 
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, emptyCoins)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, priv1)
+	// packets can only be sent from the chain itself
+	forgedMsg := NewIBCTransferMsg(addr1, addr1, coins, "other-chain", destChain, 100, 0)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{forgedMsg}, []int64{0}, []int64{0}, false, priv1)
 	mock.CheckBalance(t, mapp, addr1, coins)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, priv1)
+
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, true, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
+	mock.CheckBalance(t, mapp, EscrowAddress(TransferPort, destChain), coins)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{2}, false, priv1)
+
+	// packets cannot be received without a proof against a verified header
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{3}, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
@@ -0,0 +1,107 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// ConsensusState is the state of the light client of a counterparty chain. It
+// holds the height and time, in unix seconds, of the last verified header of
+// the chain and the validator set which signed it, trusted to sign the
+// following headers. The validator set is only trusted for the trusting
+// period, in seconds, after the time of the last verified header, as the
+// validators may have unbonded since.
+type ConsensusState struct {
+	ChainID        string
+	Height         int64
+	Time           int64
+	TrustingPeriod int64
+	Validators     []*tmtypes.Validator
+}
+
+// NewConsensusState returns the light client state of a chain after the
+// verification of a header signed by the validators
+func NewConsensusState(chainID string, height int64, time int64, trustingPeriod int64,
+	validators []*tmtypes.Validator) ConsensusState {
+
+	return ConsensusState{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time,
+		TrustingPeriod: trustingPeriod,
+		Validators:     validators,
+	}
+}
+
+// Expired returns whether the trusting period of the validator set elapsed at
+// the given time
+func (cs ConsensusState) Expired(time int64) bool {
+	return time > cs.Time+cs.TrustingPeriod
+}
+
+// ValidateBasic checks that a client can be created with the state
+func (cs ConsensusState) ValidateBasic() error {
+	if cs.ChainID == "" {
+		return fmt.Errorf("client must have a chain ID")
+	}
+	if cs.TrustingPeriod <= 0 {
+		return fmt.Errorf("trusting period must be positive, got %d", cs.TrustingPeriod)
+	}
+	if len(cs.Validators) == 0 {
+		return fmt.Errorf("validator set cannot be empty")
+	}
+	return nil
+}
+
+// VerifyHeader checks that a header of the chain is newer than the last
+// verified one and that the commit signs it with more than 2/3 of the voting
+// power of the validator set it commits to. When the validator set changed
+// since the last verified header, the commit must also be signed by more than
+// 2/3 of the voting power of the trusted validator set.
+func (cs ConsensusState) VerifyHeader(header tmtypes.Header, commit tmtypes.Commit,
+	validators []*tmtypes.Validator) error {
+
+	if header.ChainID != cs.ChainID {
+		return fmt.Errorf("header of chain %s cannot update the client of chain %s", header.ChainID, cs.ChainID)
+	}
+	if header.Height <= cs.Height {
+		return fmt.Errorf("header height %d isn't above the last verified height %d", header.Height, cs.Height)
+	}
+
+	valSet := tmtypes.NewValidatorSet(validators)
+	if !bytes.Equal(header.ValidatorsHash, valSet.Hash()) {
+		return fmt.Errorf("header doesn't commit to the validator set %X", valSet.Hash())
+	}
+	if !bytes.Equal(commit.BlockID.Hash, header.Hash()) {
+		return fmt.Errorf("commit doesn't sign the header %X", header.Hash())
+	}
+	err := valSet.VerifyCommit(header.ChainID, commit.BlockID, header.Height, &commit)
+	if err != nil {
+		return err
+	}
+
+	trusted := tmtypes.NewValidatorSet(cs.Validators)
+	if bytes.Equal(valSet.Hash(), trusted.Hash()) {
+		return nil
+	}
+
+	// the signatures were verified against the new validator set, a trusted
+	// validator with the same address signed with the same key
+	tallied := int64(0)
+	for idx, precommit := range commit.Precommits {
+		if precommit == nil || !commit.BlockID.Equals(precommit.BlockID) {
+			continue
+		}
+		_, val := valSet.GetByIndex(idx)
+		if _, trustedVal := trusted.GetByAddress(val.Address); trustedVal != nil {
+			tallied += trustedVal.VotingPower
+		}
+	}
+	if tallied <= trusted.TotalVotingPower()*2/3 {
+		return fmt.Errorf("commit is signed by %d of the %d trusted voting power, more than 2/3 is required",
+			tallied, trusted.TotalVotingPower())
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"encoding/hex"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -17,9 +18,11 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo            = "to"
+	flagAmount        = "amount"
+	flagChain         = "chain"
+	flagTimeoutHeight = "timeout-height"
+	flagTimeout       = "timeout"
 )
 
 // IBC transfer command
@@ -53,6 +56,8 @@ func IBCTransferCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeoutHeight, 0, "Height of the destination chain at which the transfer times out, 0 to disable")
+	cmd.Flags().Duration(flagTimeout, ibc.DefaultPacketLifetime, "Time after which the transfer times out on the destination chain, 0 to disable")
 	return cmd
 }
 
@@ -70,12 +75,13 @@ func buildMsg(from sdk.AccAddress) (sdk.Msg, error) {
 	}
 	to := sdk.AccAddress(bz)
 
-	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
-
-	msg := ibc.IBCTransferMsg{
-		IBCPacket: packet,
+	var timeoutTimestamp int64
+	if timeout := viper.GetDuration(flagTimeout); timeout != 0 {
+		timeoutTimestamp = time.Now().Add(timeout).Unix()
 	}
 
+	msg := ibc.NewIBCTransferMsg(from, to, coins, viper.GetString(client.FlagChainID),
+		viper.GetString(flagChain), viper.GetInt64(flagTimeoutHeight), timeoutTimestamp)
+
 	return msg, nil
 }
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+// GetCmdQueryDenomTrace returns a command to query the trace of a voucher
+// denomination minted by the chain
+func GetCmdQueryDenomTrace(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "denom-trace [denom]",
+		Short: "Query the ports and chains a voucher denomination was received through",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			denom := args[0]
+
+			// perform query
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryStore(ibc.DenomTraceKey(denom), storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no vouchers of denomination %s were minted", denom)
+			}
+
+			// decode the value
+			var denomTrace ibc.DenomTrace
+			err = cdc.UnmarshalBinary(res, &denomTrace)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, denomTrace)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
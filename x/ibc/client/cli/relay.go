@@ -1,20 +1,24 @@
 package cli
 
 import (
+	"fmt"
 	"os"
-	"time"
+	"path/filepath"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	tmcli "github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
-	"github.com/cosmos/cosmos-sdk/x/auth"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
-	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/ibc/relayer"
 )
 
 // flags
@@ -23,40 +27,68 @@ const (
 	FlagFromChainNode = "from-chain-node"
 	FlagToChainID     = "to-chain-id"
 	FlagToChainNode   = "to-chain-node"
+	FlagMaxMsgs       = "max-msgs"
+	FlagPollInterval  = "poll-interval"
+	FlagStatusAddr    = "status-addr"
+	FlagStateFile     = "state-file"
 )
 
-type relayCommander struct {
-	cdc       *wire.Codec
-	address   sdk.AccAddress
-	decoder   auth.AccountDecoder
-	mainStore string
-	ibcStore  string
-	accStore  string
-
-	logger log.Logger
-}
-
 // IBC relay command
 func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
-	cmdr := relayCommander{
-		cdc:       cdc,
-		decoder:   authcmd.GetAccountDecoder(cdc),
-		ibcStore:  "ibc",
-		mainStore: "main",
-		accStore:  "acc",
-
-		logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
-	}
-
 	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
+		Use:   "relay",
+		Short: "Relay the IBC packets between two chains in both directions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromChainID := viper.GetString(FlagFromChainID)
+			toChainID := viper.GetString(FlagToChainID)
+
+			ctx := context.NewCoreContextFromViper()
+			address, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+			if err != nil {
+				return err
+			}
+
+			fromChain := newRPCChain(cdc, fromChainID, viper.GetString(FlagFromChainNode), passphrase)
+			toChain := newRPCChain(cdc, toChainID, viper.GetString(FlagToChainNode), passphrase)
+
+			statePath := viper.GetString(FlagStateFile)
+			if statePath == "" {
+				statePath = filepath.Join(viper.GetString(tmcli.HomeFlag), "relayer",
+					fmt.Sprintf("%s_%s.json", fromChainID, toChainID))
+			}
+
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+			r, err := relayer.NewRelayer(cdc, fromChain, toChain, address, statePath, logger)
+			if err != nil {
+				return err
+			}
+			r = r.WithMaxMsgs(viper.GetInt(FlagMaxMsgs)).WithPollInterval(viper.GetDuration(FlagPollInterval))
+
+			if addr := viper.GetString(FlagStatusAddr); addr != "" {
+				go func() {
+					if err := r.ServeStatus(addr); err != nil {
+						logger.Error("error serving the relayer status", "err", err)
+					}
+				}()
+			}
+
+			r.Run(make(chan struct{}))
+			return nil
+		},
 	}
 
-	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
-	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
-	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
-	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for this chain")
+	cmd.Flags().String(FlagFromChainID, "", "Chain ID of the first chain")
+	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for the first chain")
+	cmd.Flags().String(FlagToChainID, "", "Chain ID of the second chain")
+	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for the second chain")
+	cmd.Flags().Int(FlagMaxMsgs, relayer.DefaultMaxMsgs, "Maximum number of packets relayed in a transaction")
+	cmd.Flags().Duration(FlagPollInterval, relayer.DefaultPollInterval, "Interval between the polls of the chains for new packets")
+	cmd.Flags().String(FlagStatusAddr, "", "<host>:<port> to serve the relayer status at /status, empty to disable")
+	cmd.Flags().String(FlagStateFile, "", "File persisting the progress of the relayer, defaults to <home>/relayer/<from-chain-id>_<to-chain-id>.json")
 
 	cmd.MarkFlagRequired(FlagFromChainID)
 	cmd.MarkFlagRequired(FlagFromChainNode)
@@ -67,134 +99,127 @@ func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
 	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
 	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
 	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
+	viper.BindPFlag(FlagMaxMsgs, cmd.Flags().Lookup(FlagMaxMsgs))
+	viper.BindPFlag(FlagPollInterval, cmd.Flags().Lookup(FlagPollInterval))
+	viper.BindPFlag(FlagStatusAddr, cmd.Flags().Lookup(FlagStatusAddr))
+	viper.BindPFlag(FlagStateFile, cmd.Flags().Lookup(FlagStateFile))
 
 	return cmd
 }
 
-// nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
-	fromChainID := viper.GetString(FlagFromChainID)
-	fromChainNode := viper.GetString(FlagFromChainNode)
-	toChainID := viper.GetString(FlagToChainID)
-	toChainNode := viper.GetString(FlagToChainNode)
-	address, err := context.NewCoreContextFromViper().GetFromAddress()
-	if err != nil {
-		panic(err)
-	}
-	c.address = address
-
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
+// rpcChain is a chain the relayer reaches through the rpc interface of one of
+// its nodes, its transactions are signed with the key of the from flag
+type rpcChain struct {
+	cdc        *wire.Codec
+	chainID    string
+	node       string
+	ibcStore   string
+	passphrase string
 }
 
-// This is nolinted as someone is in the process of refactoring this to remove the goto
-// nolint: gocyclo
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID,
-	toChainNode string) {
-
-	ctx := context.NewCoreContextFromViper()
-	// get password
-	passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
-	if err != nil {
-		panic(err)
-	}
-
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
-
-OUTER:
-	for {
-		time.Sleep(5 * time.Second)
-
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
-		if err != nil {
-			panic(err)
-		}
-
-		var processed int64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinary(processedbz, &processed); err != nil {
-			panic(err)
-		}
-
-		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
-		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
-		}
-		var egressLength int64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinary(egressLengthbz, &egressLength); err != nil {
-			panic(err)
-		}
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
-		}
-
-		seq := c.getSequence(toChainNode)
-
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
-			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
-
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
-			seq++
-			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
+var _ relayer.Chain = rpcChain{}
 
-			c.logger.Info("Relayed IBC packet", "number", i)
-		}
+func newRPCChain(cdc *wire.Codec, chainID, node, passphrase string) rpcChain {
+	return rpcChain{
+		cdc:        cdc,
+		chainID:    chainID,
+		node:       node,
+		ibcStore:   "ibc",
+		passphrase: passphrase,
 	}
 }
 
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCoreContextFromViper().WithNodeURI(node).QueryStore(key, storeName)
+func (c rpcChain) context() context.CoreContext {
+	return context.NewCoreContextFromViper().
+		WithNodeURI(c.node).
+		WithChainID(c.chainID).
+		WithDecoder(authcmd.GetAccountDecoder(c.cdc)).
+		WithAccountStore("acc")
 }
 
-func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
-	_, err := context.NewCoreContextFromViper().WithNodeURI(node).WithSequence(seq + 1).BroadcastTx(tx)
-	return err
+// ChainID implements relayer.Chain
+func (c rpcChain) ChainID() string {
+	return c.chainID
 }
 
-func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
+// LatestHeight implements relayer.Chain
+func (c rpcChain) LatestHeight() (int64, error) {
+	node, err := c.context().GetNode()
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	if nil != res {
-		account, err := c.decoder(res)
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+	if status.NodeInfo.Network != c.chainID {
+		return 0, errors.Errorf("node %s is on chain %s instead of %s", c.node, status.NodeInfo.Network, c.chainID)
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// QueryIBC implements relayer.Chain
+func (c rpcChain) QueryIBC(key []byte, height int64, prove bool) (value []byte, proof store.MultiStoreProof, err error) {
+	// the node queries the state below the latest one by default
+	if height == 0 {
+		height, err = c.LatestHeight()
 		if err != nil {
-			panic(err)
+			return nil, proof, err
 		}
-
-		return account.GetSequence()
+	}
+	ctx := c.context().WithHeight(height)
+	if !prove {
+		value, err = ctx.QueryStore(key, c.ibcStore)
+		return value, proof, err
 	}
 
-	return 0
+	value, proofbz, err := ctx.QueryStoreWithProof(key, c.ibcStore)
+	if err != nil || len(proofbz) == 0 {
+		return value, proof, err
+	}
+	err = c.cdc.UnmarshalBinary(proofbz, &proof)
+	return value, proof, err
 }
 
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
-		panic(err)
+// Header implements relayer.Chain
+func (c rpcChain) Header(height int64) (tmtypes.Header, tmtypes.Commit, []*tmtypes.Validator, error) {
+	node, err := c.context().GetNode()
+	if err != nil {
+		return tmtypes.Header{}, tmtypes.Commit{}, nil, err
+	}
+	commit, err := node.Commit(&height)
+	if err != nil {
+		return tmtypes.Header{}, tmtypes.Commit{}, nil, err
+	}
+	validators, err := node.Validators(&height)
+	if err != nil {
+		return tmtypes.Header{}, tmtypes.Commit{}, nil, err
 	}
+	return *commit.Header, *commit.Commit, validators.Validators, nil
+}
 
-	msg := ibc.IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  sequence,
+// Broadcast implements relayer.Chain, the transaction is signed with the
+// current account number and sequence of the relayer
+func (c rpcChain) Broadcast(msgs []sdk.Msg) error {
+	ctx := c.context()
+	address, err := ctx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+	accnum, err := ctx.GetAccountNumber(address)
+	if err != nil {
+		return err
+	}
+	sequence, err := ctx.NextSequence(address)
+	if err != nil {
+		return err
 	}
 
-	ctx := context.NewCoreContextFromViper().WithSequence(sequence)
-	res, err := ctx.SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{msg}, c.cdc)
+	ctx = ctx.WithAccountNumber(accnum).WithSequence(sequence)
+	txBytes, err := ctx.SignAndBuild(ctx.FromAddressName, c.passphrase, msgs, c.cdc)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return res
+	_, err = ctx.BroadcastTx(txBytes)
+	return err
 }
@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+const ibcStoreName = "ibc"
+
+func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	// voucher denominations contain slashes
+	r.HandleFunc(
+		"/ibc/denom_traces/{denom:.+}",
+		denomTraceHandlerFn(ctx, cdc),
+	).Methods("GET")
+}
+
+// http request handler to query the trace of a voucher denomination
+func denomTraceHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		denom := mux.Vars(r)["denom"]
+
+		res, err := ctx.QueryStore(ibc.DenomTraceKey(denom), ibcStoreName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query denomination trace. Error: %s", err.Error())))
+			return
+		}
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(fmt.Sprintf("no vouchers of denomination %s were minted", denom)))
+			return
+		}
+
+		var denomTrace ibc.DenomTrace
+		err = cdc.UnmarshalBinary(res, &denomTrace)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't parse query result. Result: %s. Error: %s", res, err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(denomTrace)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	"github.com/gorilla/mux"
@@ -16,6 +17,7 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	r.HandleFunc("/ibc/{destchain}/{address}/send", TransferRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	registerQueryRoutes(ctx, r, cdc)
 }
 
 type transferBody struct {
@@ -27,6 +29,8 @@ type transferBody struct {
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
 	Gas              int64     `json:"gas"`
+	TimeoutHeight    int64     `json:"timeout_height"`
+	TimeoutTimestamp int64     `json:"timeout_timestamp"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -67,8 +71,12 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		}
 
 		// build message
-		packet := ibc.NewIBCPacket(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID)
-		msg := ibc.IBCTransferMsg{packet}
+		// default to the lifetime of the packets sent by the clients
+		if m.TimeoutHeight == 0 && m.TimeoutTimestamp == 0 {
+			m.TimeoutTimestamp = time.Now().Add(ibc.DefaultPacketLifetime).Unix()
+		}
+		msg := ibc.NewIBCTransferMsg(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID,
+			m.TimeoutHeight, m.TimeoutTimestamp)
 
 		// add gas to context
 		ctx = ctx.WithGas(m.Gas)
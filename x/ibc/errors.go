@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -9,9 +11,26 @@ const (
 	DefaultCodespace sdk.CodespaceType = 3
 
 	// IBC errors reserve 200 - 299.
-	CodeInvalidSequence sdk.CodeType = 200
-	CodeIdenticalChains sdk.CodeType = 201
-	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
+	CodeInvalidSequence     sdk.CodeType = 200
+	CodeIdenticalChains     sdk.CodeType = 201
+	CodeInvalidHeader       sdk.CodeType = 202
+	CodeUnknownHeader       sdk.CodeType = 203
+	CodeInvalidProof        sdk.CodeType = 204
+	CodeInvalidDestChain    sdk.CodeType = 205
+	CodeInvalidTimeout      sdk.CodeType = 206
+	CodePacketTimedOut      sdk.CodeType = 207
+	CodeUnknownPacket       sdk.CodeType = 208
+	CodeInvalidPort         sdk.CodeType = 209
+	CodeUnknownPort         sdk.CodeType = 210
+	CodeInvalidPayload      sdk.CodeType = 211
+	CodeUnknownChain        sdk.CodeType = 212
+	CodeClientExists        sdk.CodeType = 213
+	CodeClientExpired       sdk.CodeType = 214
+	CodeInvalidClient       sdk.CodeType = 215
+	CodeInvalidSrcChain     sdk.CodeType = 216
+	CodeInvalidSrcPort      sdk.CodeType = 217
+	CodeInvalidMisbehaviour sdk.CodeType = 218
+	CodeUnknownRequest      sdk.CodeType = sdk.CodeUnknownRequest
 )
 
 func codeToDefaultMsg(code sdk.CodeType) string {
@@ -20,6 +39,40 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeInvalidHeader:
+		return "invalid header of the counterparty chain"
+	case CodeUnknownHeader:
+		return "no verified header of the counterparty chain at this height"
+	case CodeInvalidProof:
+		return "invalid proof of the IBC packet"
+	case CodeInvalidDestChain:
+		return "IBC packet isn't destined to this chain"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodePacketTimedOut:
+		return "IBC packet timed out"
+	case CodeUnknownPacket:
+		return "no pending IBC packet with this sequence"
+	case CodeInvalidPort:
+		return "invalid IBC port"
+	case CodeUnknownPort:
+		return "no module is bound to the IBC port"
+	case CodeInvalidPayload:
+		return "invalid IBC packet payload"
+	case CodeUnknownChain:
+		return "no light client of the counterparty chain"
+	case CodeClientExists:
+		return "light client of the counterparty chain already exists"
+	case CodeClientExpired:
+		return "trusting period of the light client elapsed"
+	case CodeInvalidClient:
+		return "invalid light client of the counterparty chain"
+	case CodeInvalidSrcChain:
+		return "IBC packet isn't sent from this chain"
+	case CodeInvalidSrcPort:
+		return "IBC packet isn't sent from the port of the module"
+	case CodeInvalidMisbehaviour:
+		return "invalid evidence of the misbehaviour of a validator"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +85,57 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidHeader, msg)
+}
+func ErrUnknownHeader(codespace sdk.CodespaceType, chainID string, height int64) sdk.Error {
+	return newError(codespace, CodeUnknownHeader, fmt.Sprintf("no verified header of chain %s at height %d", chainID, height))
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrInvalidDestChain(codespace sdk.CodespaceType, destChain string) sdk.Error {
+	return newError(codespace, CodeInvalidDestChain, fmt.Sprintf("IBC packet is destined to chain %s", destChain))
+}
+func ErrInvalidSrcChain(codespace sdk.CodespaceType, srcChain string) sdk.Error {
+	return newError(codespace, CodeInvalidSrcChain, fmt.Sprintf("IBC packet is sent from chain %s", srcChain))
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, msg)
+}
+func ErrPacketTimedOut(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodePacketTimedOut, msg)
+}
+func ErrUnknownPacket(codespace sdk.CodespaceType, destChain string, sequence int64) sdk.Error {
+	return newError(codespace, CodeUnknownPacket, fmt.Sprintf("no pending IBC packet to chain %s with sequence %d", destChain, sequence))
+}
+func ErrInvalidPort(codespace sdk.CodespaceType, port string) sdk.Error {
+	return newError(codespace, CodeInvalidPort, fmt.Sprintf("invalid IBC port %q, ports are lowercase alphanumeric of 2 to 16 characters", port))
+}
+func ErrInvalidSrcPort(codespace sdk.CodespaceType, srcPort, port string) sdk.Error {
+	return newError(codespace, CodeInvalidSrcPort, fmt.Sprintf("IBC packet is sent from port %s instead of %s", srcPort, port))
+}
+func ErrUnknownPort(codespace sdk.CodespaceType, port string) sdk.Error {
+	return newError(codespace, CodeUnknownPort, fmt.Sprintf("no module is bound to the IBC port %s", port))
+}
+func ErrInvalidPayload(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidPayload, msg)
+}
+func ErrUnknownChain(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeUnknownChain, fmt.Sprintf("no light client of chain %s", chainID))
+}
+func ErrClientExists(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeClientExists, fmt.Sprintf("light client of chain %s already exists", chainID))
+}
+func ErrClientExpired(codespace sdk.CodespaceType, chainID string, time int64) sdk.Error {
+	return newError(codespace, CodeClientExpired, fmt.Sprintf("trusting period of the light client of chain %s elapsed at %d", chainID, time))
+}
+func ErrInvalidClient(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidClient, msg)
+}
+func ErrInvalidMisbehaviour(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidMisbehaviour, msg)
+}
 
 // -------------------------
 // Helpers
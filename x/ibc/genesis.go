@@ -0,0 +1,47 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all ibc state that must be provided at genesis
+type GenesisState struct {
+	Clients []ConsensusState `json:"clients"`
+}
+
+func NewGenesisState(clients []ConsensusState) GenesisState {
+	return GenesisState{
+		Clients: clients,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - create the light clients of the counterparty chains, their
+// validator sets are the roots of trust of the clients
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) error {
+	for _, client := range data.Clients {
+		err := ibcm.CreateClient(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteGenesis - output the light clients of the counterparty chains
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	var clients []ConsensusState
+	store := ctx.KVStore(ibcm.key)
+	iter := sdk.KVStorePrefixIterator(store, ConsensusStateKey(""))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var client ConsensusState
+		unmarshalBinaryPanic(ibcm.cdc, iter.Value(), &client)
+		clients = append(clients, client)
+	}
+	return NewGenesisState(clients)
+}
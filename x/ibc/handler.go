@@ -4,16 +4,24 @@ import (
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
-func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
+// NewHandler returns a handler for the IBC messages, which routes the packets
+// to the modules bound to their ports. Transfers are sent by the transfer
+// module, which must be bound to the TransferPort with NewTransferModule.
+func NewHandler(ibcm Mapper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case IBCTransferMsg:
-			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
+			return handleIBCTransferMsg(ctx, ibcm, msg)
 		case IBCReceiveMsg:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, msg)
+		case IBCUpdateClientMsg:
+			return handleIBCUpdateClientMsg(ctx, ibcm, msg)
+		case IBCAcknowledgementMsg:
+			return handleIBCAcknowledgementMsg(ctx, ibcm, msg)
+		case IBCTimeoutMsg:
+			return handleIBCTimeoutMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,16 +29,16 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
-func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
-	packet := msg.IBCPacket
-
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
-	if err != nil {
-		return err.Result()
+// IBCTransferMsg escrows or burns coins of the account and creates an egress
+// IBC packet from the transfer port, through the transfer module bound to it.
+func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, msg IBCTransferMsg) sdk.Result {
+	module, found := ibcm.GetModule(TransferPort)
+	transfer, ok := module.(TransferModule)
+	if !found || !ok {
+		return ErrUnknownPort(ibcm.codespace, TransferPort).Result()
 	}
 
-	err = ibcm.PostIBCPacket(ctx, packet)
+	err := transfer.SendTransfer(ctx, msg)
 	if err != nil {
 		return err.Result()
 	}
@@ -38,8 +46,12 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCT
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
+// IBCReceiveMsg verifies the proof of the IBC packet on the source chain,
+// delivers it to the module bound to its destination port and creates an
+// ingress IBC packet. Packets which timed out or fail are received without
+// any state change of the module, with an error acknowledgement for the
+// source chain.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
 	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
@@ -47,12 +59,83 @@ func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCRe
 		return ErrInvalidSequence(ibcm.codespace).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	err := ibcm.ReceiveIBCPacket(ctx, packet, msg.Sequence, msg.Height, msg.Proof)
 	if err != nil {
 		return err.Result()
 	}
 
+	ack := NewSuccessAcknowledgement()
+	module, found := ibcm.GetModule(packet.DestPort)
+	switch {
+	case !found:
+		ack = NewErrorAcknowledgement(ErrUnknownPort(ibcm.codespace, packet.DestPort))
+	case packet.TimedOut(ctx.BlockHeight(), ctx.BlockHeader().Time):
+		ack = NewErrorAcknowledgement(ErrPacketTimedOut(ibcm.codespace, ""))
+	default:
+		cacheCtx, write := ctx.CacheContext()
+		err = module.OnRecvPacket(cacheCtx, packet)
+		if err != nil {
+			ack = NewErrorAcknowledgement(err)
+		} else {
+			write()
+		}
+	}
+
+	ibcm.SetAcknowledgement(ctx, packet.SrcChain, seq, ack)
 	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
 
 	return sdk.Result{}
 }
+
+// IBCUpdateClientMsg verifies a header of the counterparty chain and updates
+// its light client.
+func handleIBCUpdateClientMsg(ctx sdk.Context, ibcm Mapper, msg IBCUpdateClientMsg) sdk.Result {
+	err := ibcm.UpdateClient(ctx, msg.Header, msg.Commit, msg.Validators)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// IBCAcknowledgementMsg verifies the proof of the acknowledgement of a pending
+// IBC packet on the destination chain and passes it to the module bound to the
+// source port of the packet.
+func handleIBCAcknowledgementMsg(ctx sdk.Context, ibcm Mapper, msg IBCAcknowledgementMsg) sdk.Result {
+	packet, err := ibcm.AcknowledgePacket(ctx, msg.DestChain, msg.Sequence, msg.Acknowledgement, msg.Height, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	module, found := ibcm.GetModule(packet.SrcPort)
+	if !found {
+		return ErrUnknownPort(ibcm.codespace, packet.SrcPort).Result()
+	}
+	err = module.OnAcknowledge(ctx, packet, msg.Acknowledgement)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// IBCTimeoutMsg verifies that a pending IBC packet timed out before the
+// destination chain received it and passes it to the module bound to the
+// source port of the packet.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, msg IBCTimeoutMsg) sdk.Result {
+	packet, err := ibcm.TimeoutPacket(ctx, msg.DestChain, msg.Sequence, msg.Height, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	module, found := ibcm.GetModule(packet.SrcPort)
+	if !found {
+		return ErrUnknownPort(ibcm.codespace, packet.SrcPort).Result()
+	}
+	err = module.OnTimeout(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
@@ -1,7 +1,9 @@
 package ibc
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -9,23 +11,25 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // AccountMapper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultContext(key sdk.StoreKey, chainID string) (sdk.Context, store.CommitMultiStore) {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
 	cms.LoadLatestVersion()
-	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
-	return ctx
+	ctx := sdk.NewContext(cms, abci.Header{ChainID: chainID}, false, log.NewNopLogger())
+	return ctx, cms
 }
 
 func newAddress() sdk.AccAddress {
@@ -44,13 +48,16 @@ func makeCodec() *wire.Codec {
 	// Register Msgs
 	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
 	cdc.RegisterConcrete(bank.MsgSend{}, "test/ibc/Send", nil)
-	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateClientMsg{}, "test/ibc/IBCUpdateClientMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "test/ibc/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "test/ibc/IBCTimeoutMsg", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/ibc/Account", nil)
+	cdc.RegisterConcrete(&auth.ModuleAccount{}, "test/ibc/ModuleAccount", nil)
 	wire.RegisterCrypto(cdc)
 
 	cdc.Seal()
@@ -58,79 +65,592 @@ func makeCodec() *wire.Codec {
 	return cdc
 }
 
+// validators signing the headers of a counterparty chain
+type testValidators []crypto.PrivKeyEd25519
+
+func newTestValidators(n int) testValidators {
+	privs := make(testValidators, n)
+	for i := range privs {
+		privs[i] = crypto.GenPrivKeyEd25519()
+	}
+	return privs
+}
+
+func (privs testValidators) validators() []*tmtypes.Validator {
+	validators := make([]*tmtypes.Validator, len(privs))
+	for i, priv := range privs {
+		validators[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	return validators
+}
+
+// signHeader returns a header of the chain committing to the app hash and to
+// the validator set, with a commit of all the validators signing it
+func (privs testValidators) signHeader(t *testing.T, chainID string, height int64,
+	appHash []byte) (tmtypes.Header, tmtypes.Commit) {
+
+	valSet := tmtypes.NewValidatorSet(privs.validators())
+	header := tmtypes.Header{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time.Now().UTC(),
+		ValidatorsHash: valSet.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, len(valSet.Validators))
+	for idx, val := range valSet.Validators {
+		for _, priv := range privs {
+			if !bytes.Equal(priv.PubKey().Address(), val.Address) {
+				continue
+			}
+			vote := &tmtypes.Vote{
+				ValidatorAddress: val.Address,
+				ValidatorIndex:   idx,
+				Height:           height,
+				Timestamp:        header.Time,
+				Type:             tmtypes.VoteTypePrecommit,
+				BlockID:          blockID,
+			}
+			sig, err := priv.Sign(vote.SignBytes(chainID))
+			require.Nil(t, err)
+			vote.Signature = sig
+			precommits[idx] = vote
+		}
+	}
+	return header, tmtypes.Commit{BlockID: blockID, Precommits: precommits}
+}
+
+// queryProof returns the proof of a key of the ibc store at a version
+func queryProof(t *testing.T, cms store.CommitMultiStore, cdc *wire.Codec, key []byte,
+	version int64) store.MultiStoreProof {
+
+	res := cms.(store.Queryable).Query(abci.RequestQuery{
+		Path:   "/ibc/key",
+		Data:   key,
+		Height: version,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+
+	var proof store.MultiStoreProof
+	require.Nil(t, cdc.UnmarshalBinary(res.Proof, &proof))
+	return proof
+}
+
+// testChain is a chain taking part in the IBC tests
+type testChain struct {
+	chainID    string
+	ctx        sdk.Context
+	cms        store.CommitMultiStore
+	ibcm       Mapper
+	ck         bank.Keeper
+	sk         bank.SupplyKeeper
+	handler    sdk.Handler
+	validators testValidators
+}
+
+func newTestChain(cdc *wire.Codec, key sdk.StoreKey, chainID string) testChain {
+	ctx, cms := defaultContext(key, chainID)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewKeeper(am, params.NewKeeper(cdc, key).Getter(), nil)
+	sk := bank.NewSupplyKeeper(cdc, key, ck, map[string][]string{
+		ModuleName: {auth.Minter, auth.Burner},
+	})
+	ibcm.BindPort(TransferPort, NewTransferModule(ibcm, ck, sk))
+	return testChain{
+		chainID:    chainID,
+		ctx:        ctx,
+		cms:        cms,
+		ibcm:       ibcm,
+		ck:         ck,
+		sk:         sk,
+		handler:    NewHandler(ibcm),
+		validators: newTestValidators(4),
+	}
+}
+
+// trusting period of the clients of the tests, in seconds
+const testTrustingPeriod = 24 * 60 * 60
+
+// connect creates the clients of both chains on each other, as it would be at
+// genesis
+func connect(t *testing.T, chainA, chainB testChain) {
+	now := time.Now().UTC().Unix()
+	clientB := NewConsensusState(chainB.chainID, 0, now, testTrustingPeriod, chainB.validators.validators())
+	require.Nil(t, chainA.ibcm.CreateClient(chainA.ctx, clientB))
+	clientA := NewConsensusState(chainA.chainID, 0, now, testTrustingPeriod, chainA.validators.validators())
+	require.Nil(t, chainB.ibcm.CreateClient(chainB.ctx, clientA))
+}
+
+// prove commits the chain and returns the message updating its client on a
+// counterparty chain, along with the proof of a key of its ibc store
+func prove(t *testing.T, cdc *wire.Codec, chain testChain, key []byte,
+	relayer sdk.AccAddress) (IBCUpdateClientMsg, store.MultiStoreProof) {
+
+	cid := chain.cms.Commit()
+	proof := queryProof(t, chain.cms, cdc, key, cid.Version)
+	header, commit := chain.validators.signHeader(t, chain.chainID, cid.Version+1, cid.Hash)
+
+	updateMsg := IBCUpdateClientMsg{
+		Header:     header,
+		Commit:     commit,
+		Validators: chain.validators.validators(),
+		Relayer:    relayer,
+	}
+	return updateMsg, proof
+}
+
+// relay commits the source chain and returns the messages updating its
+// client on the destination chain and receiving its egress packet
+func relay(t *testing.T, cdc *wire.Codec, src testChain, destChain string, sequence int64,
+	relayer sdk.AccAddress) (IBCUpdateClientMsg, IBCReceiveMsg) {
+
+	packet, found := src.ibcm.GetEgressPacket(src.ctx, destChain, sequence)
+	require.True(t, found)
+
+	updateMsg, proof := prove(t, cdc, src, EgressKey(destChain, sequence), relayer)
+	receiveMsg := IBCReceiveMsg{
+		IBCPacket: packet,
+		Relayer:   relayer,
+		Sequence:  sequence,
+		Height:    updateMsg.Header.Height,
+		Proof:     proof,
+	}
+	return updateMsg, receiveMsg
+}
+
 func TestIBC(t *testing.T) {
 	cdc := makeCodec()
 
 	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
-
-	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
-	ck := bank.NewKeeper(am)
+	srcChain := newTestChain(cdc, key, "src-chain")
+	destChain := newTestChain(cdc, key, "dest-chain")
+	connect(t, srcChain, destChain)
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	escrow := EscrowAddress(TransferPort, destChain.chainID)
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, _, err := srcChain.ck.AddCoins(srcChain.ctx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
-	ibcm := NewMapper(cdc, key, DefaultCodespace)
-	h := NewHandler(ibcm, ck)
-	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
-	}
-
-	store := ctx.KVStore(key)
+	srcStore := srcChain.ctx.KVStore(key)
 
 	var msg sdk.Msg
 	var res sdk.Result
 	var egl int64
 	var igs int64
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = srcChain.ibcm.getEgressLength(srcStore, destChain.chainID)
 	require.Equal(t, egl, int64(0))
 
-	msg = IBCTransferMsg{
-		IBCPacket: packet,
-	}
-	res = h(ctx, msg)
+	msg = NewIBCTransferMsg(src, dest, mycoins, srcChain.chainID, destChain.chainID, 100, 0)
+	res = srcChain.handler(srcChain.ctx, msg)
 	require.True(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, src)
+	// the native coins are escrowed for the destination chain
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
+	require.Equal(t, mycoins, srcChain.ck.GetCoins(srcChain.ctx, escrow))
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = srcChain.ibcm.getEgressLength(srcStore, destChain.chainID)
 	require.Equal(t, egl, int64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(0))
 
-	msg = IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   src,
-		Sequence:  0,
-	}
-	res = h(ctx, msg)
-	require.True(t, res.IsOK())
+	updateMsg, receiveMsg := relay(t, cdc, srcChain, destChain.chainID, 0, src)
+	require.Equal(t, TransferPort, receiveMsg.DestPort)
 
-	coins, err = getCoins(ck, ctx, dest)
+	// the header of the source chain isn't verified yet
+	res = destChain.handler(destChain.ctx, receiveMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownHeader), res.Code, res.Log)
+
+	res = destChain.handler(destChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte(updateMsg.Header.AppHash), destChain.ibcm.GetAppHash(destChain.ctx, srcChain.chainID, updateMsg.Header.Height))
+
+	// headers cannot be replayed
+	res = destChain.handler(destChain.ctx, updateMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidHeader), res.Code, res.Log)
+
+	// the proof doesn't prove a forged packet
+	forged := receiveMsg
+	forged.Payload = TransferPacketData{src, dest, sdk.Coins{sdk.NewCoin("mycoin", 1000)}}.GetBytes()
+	res = destChain.handler(destChain.ctx, forged)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code, res.Log)
+
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, zero, coins)
+
+	res = destChain.handler(destChain.ctx, receiveMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// vouchers prefixed by the source chain are minted
+	voucherDenom := VoucherPrefix(TransferPort, srcChain.chainID) + "mycoin"
+	vouchers := sdk.Coins{sdk.NewCoin(voucherDenom, 10)}
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, vouchers, coins)
+	require.Equal(t, vouchers, destChain.sk.GetSupply(destChain.ctx))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	denomTrace, found := destChain.ibcm.GetDenomTrace(destChain.ctx, voucherDenom)
+	require.True(t, found)
+	require.Equal(t, DenomTrace{Path: "transfer/src-chain", BaseDenom: "mycoin"}, denomTrace)
+	require.Equal(t, voucherDenom, denomTrace.Denom())
+
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(1))
 
-	res = h(ctx, msg)
+	ack, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 0)
+	require.True(t, found)
+	require.Equal(t, NewSuccessAcknowledgement(), ack)
+
+	res = destChain.handler(destChain.ctx, receiveMsg)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID)
 	require.Equal(t, igs, int64(1))
+
+	// sending the vouchers back burns them
+	msg = NewIBCTransferMsg(dest, src, vouchers, destChain.chainID, srcChain.chainID, 100, 0)
+	res = destChain.handler(destChain.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	coins, err = getCoins(destChain.ck, destChain.ctx, dest)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+	require.True(t, destChain.sk.GetSupply(destChain.ctx).IsZero())
+
+	// and releases the escrowed coins on the source chain
+	updateMsg, receiveMsg = relay(t, cdc, destChain, srcChain.chainID, 0, dest)
+	res = srcChain.handler(srcChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+	res = srcChain.handler(srcChain.ctx, receiveMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	coins, err = getCoins(srcChain.ck, srcChain.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	require.True(t, srcChain.ck.GetCoins(srcChain.ctx, escrow).IsZero())
+}
+
+func TestIBCAcknowledgement(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	srcChain := newTestChain(cdc, key, "src-chain")
+	destChain := newTestChain(cdc, key, "dest-chain")
+	connect(t, srcChain, destChain)
+
+	src := newAddress()
+	dest := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	escrow := EscrowAddress(TransferPort, destChain.chainID)
+
+	_, _, err := srcChain.ck.AddCoins(srcChain.ctx, src, mycoins)
+	require.Nil(t, err)
+
+	// the packet has timed out when the destination chain receives it
+	now := time.Now().UTC().Unix()
+	msg := NewIBCTransferMsg(src, dest, mycoins, srcChain.chainID, destChain.chainID, 0, now)
+	res := srcChain.handler(srcChain.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, mycoins, srcChain.ck.GetCoins(srcChain.ctx, escrow))
+
+	destChain.ctx = destChain.ctx.WithBlockHeader(abci.Header{ChainID: destChain.chainID, Time: now})
+	updateMsg, receiveMsg := relay(t, cdc, srcChain, destChain.chainID, 0, src)
+	res = destChain.handler(destChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+	res = destChain.handler(destChain.ctx, receiveMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// no vouchers are minted and an error is acknowledged
+	require.True(t, destChain.ck.GetCoins(destChain.ctx, dest).IsZero())
+	require.Equal(t, int64(1), destChain.ibcm.GetIngressSequence(destChain.ctx, srcChain.chainID))
+	ack, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 0)
+	require.True(t, found)
+	require.False(t, ack.Success)
+	require.NotEmpty(t, ack.Error)
+
+	updateMsg, proof := prove(t, cdc, destChain, AcknowledgementKey(srcChain.chainID, 0), src)
+	res = srcChain.handler(srcChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// a received packet cannot time out
+	timeoutMsg := IBCTimeoutMsg{destChain.chainID, 0, updateMsg.Header.Height, proof, src}
+	res = srcChain.handler(srcChain.ctx, timeoutMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code, res.Log)
+
+	// the proof doesn't prove a forged acknowledgement
+	ackMsg := IBCAcknowledgementMsg{destChain.chainID, 0, NewSuccessAcknowledgement(), updateMsg.Header.Height, proof, src}
+	res = srcChain.handler(srcChain.ctx, ackMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidProof), res.Code, res.Log)
+	require.True(t, srcChain.ck.GetCoins(srcChain.ctx, src).IsZero())
+
+	// the error acknowledgement refunds the sender
+	ackMsg.Acknowledgement = ack
+	res = srcChain.handler(srcChain.ctx, ackMsg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, mycoins, srcChain.ck.GetCoins(srcChain.ctx, src))
+	require.True(t, srcChain.ck.GetCoins(srcChain.ctx, escrow).IsZero())
+
+	// packets are only acknowledged once
+	res = srcChain.handler(srcChain.ctx, ackMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownPacket), res.Code, res.Log)
+	require.Equal(t, mycoins, srcChain.ck.GetCoins(srcChain.ctx, src))
+}
+
+func TestIBCTimeout(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	srcChain := newTestChain(cdc, key, "src-chain")
+	destChain := newTestChain(cdc, key, "dest-chain")
+	connect(t, srcChain, destChain)
+
+	src := newAddress()
+	dest := newAddress()
+	vouchers := sdk.Coins{sdk.NewCoin(VoucherPrefix(TransferPort, destChain.chainID)+"mycoin", 10)}
+
+	// the sender holds vouchers of the destination chain
+	require.Nil(t, srcChain.sk.MintCoins(srcChain.ctx, ModuleName, vouchers))
+	_, err := srcChain.ck.SendCoinsFromModuleToAccount(srcChain.ctx, ModuleName, src, vouchers)
+	require.Nil(t, err)
+
+	msg := NewIBCTransferMsg(src, dest, vouchers, srcChain.chainID, destChain.chainID, 3, 0)
+	res := srcChain.handler(srcChain.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, srcChain.sk.GetSupply(srcChain.ctx).IsZero())
+
+	// the ibc store of the destination chain must hold some state to prove
+	// the absence of a key
+	_, _, err = destChain.ck.AddCoins(destChain.ctx, newAddress(), sdk.Coins{sdk.NewCoin("mycoin", 10)})
+	require.Nil(t, err)
+
+	// the packet hasn't timed out at height 2 of the destination chain
+	ackKey := AcknowledgementKey(srcChain.chainID, 0)
+	updateMsg, proof := prove(t, cdc, destChain, ackKey, src)
+	require.Equal(t, int64(2), updateMsg.Header.Height)
+	res = srcChain.handler(srcChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	timeoutMsg := IBCTimeoutMsg{destChain.chainID, 0, updateMsg.Header.Height, proof, src}
+	res = srcChain.handler(srcChain.ctx, timeoutMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidTimeout), res.Code, res.Log)
+
+	// it has timed out at height 3, the burned vouchers are minted back
+	updateMsg, proof = prove(t, cdc, destChain, ackKey, src)
+	res = srcChain.handler(srcChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	timeoutMsg = IBCTimeoutMsg{destChain.chainID, 0, updateMsg.Header.Height, proof, src}
+	res = srcChain.handler(srcChain.ctx, timeoutMsg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, vouchers, srcChain.ck.GetCoins(srcChain.ctx, src))
+	require.Equal(t, vouchers, srcChain.sk.GetSupply(srcChain.ctx))
+
+	// packets only time out once
+	res = srcChain.handler(srcChain.ctx, timeoutMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownPacket), res.Code, res.Log)
+
+	// the packet is received as timed out
+	destChain.ctx = destChain.ctx.WithBlockHeight(3)
+	updateMsg, receiveMsg := relay(t, cdc, srcChain, destChain.chainID, 0, src)
+	res = destChain.handler(destChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+	res = destChain.handler(destChain.ctx, receiveMsg)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, destChain.ck.GetCoins(destChain.ctx, dest).IsZero())
+	ack, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 0)
+	require.True(t, found)
+	require.False(t, ack.Success)
+}
+
+// testModule records the packets of its port in the store, a packet with the
+// "fail" payload fails
+type testModule struct {
+	key sdk.StoreKey
+}
+
+func (tm testModule) OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	ctx.KVStore(tm.key).Set([]byte("received"), packet.Payload)
+	if string(packet.Payload) == "fail" {
+		return sdk.ErrUnknownRequest("failed")
+	}
+	return nil
+}
+
+func (tm testModule) OnAcknowledge(ctx sdk.Context, packet IBCPacket, ack Acknowledgement) sdk.Error {
+	ctx.KVStore(tm.key).Set([]byte("acknowledged"), packet.Payload)
+	return nil
+}
+
+func (tm testModule) OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	ctx.KVStore(tm.key).Set([]byte("timedout"), packet.Payload)
+	return nil
+}
+
+func TestPortRouting(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	srcChain := newTestChain(cdc, key, "src-chain")
+	destChain := newTestChain(cdc, key, "dest-chain")
+	connect(t, srcChain, destChain)
+	oracle := srcChain.ibcm.BindPort("oracle", testModule{key})
+	destChain.ibcm.BindPort("oracle", testModule{key})
+	relayer := newAddress()
+
+	// ports are bound once
+	require.Panics(t, func() { srcChain.ibcm.BindPort("oracle", testModule{key}) })
+	require.Panics(t, func() { srcChain.ibcm.BindPort("Oracle", testModule{key}) })
+	_, found := srcChain.ibcm.GetModule("oracle")
+	require.True(t, found)
+
+	// packets are only sent from bound ports, by the module bound to them
+	packet := NewIBCPacket("unbound", "oracle", srcChain.chainID, destChain.chainID, []byte("price"), 100, 0)
+	err := srcChain.ibcm.postIBCPacket(srcChain.ctx, packet)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownPort), err.ABCICode())
+	packet = NewIBCPacket(TransferPort, "oracle", srcChain.chainID, destChain.chainID, []byte("price"), 100, 0)
+	err = oracle.PostIBCPacket(srcChain.ctx, packet)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidSrcPort), err.ABCICode())
+	require.Equal(t, "oracle", oracle.Name())
+
+	payloads := []string{"price", "fail", "unbound"}
+	for _, payload := range payloads {
+		destPort := "oracle"
+		if payload == "unbound" {
+			destPort = payload
+		}
+		packet = NewIBCPacket("oracle", destPort, srcChain.chainID, destChain.chainID, []byte(payload), 100, 0)
+		require.Nil(t, oracle.PostIBCPacket(srcChain.ctx, packet))
+	}
+
+	// the packets are delivered to the module bound to their destination port
+	destStore := destChain.ctx.KVStore(key)
+	for seq, payload := range payloads {
+		updateMsg, receiveMsg := relay(t, cdc, srcChain, destChain.chainID, int64(seq), relayer)
+		res := destChain.handler(destChain.ctx, updateMsg)
+		require.True(t, res.IsOK(), res.Log)
+		res = destChain.handler(destChain.ctx, receiveMsg)
+		require.True(t, res.IsOK(), res.Log)
+
+		ack, found := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, int64(seq))
+		require.True(t, found)
+		require.Equal(t, payload == "price", ack.Success, ack.Error)
+
+		// the state changes of failed packets are discarded
+		require.Equal(t, []byte("price"), destStore.Get([]byte("received")))
+	}
+
+	// the acknowledgement is passed to the module bound to the source port
+	updateMsg, proof := prove(t, cdc, destChain, AcknowledgementKey(srcChain.chainID, 1), relayer)
+	res := srcChain.handler(srcChain.ctx, updateMsg)
+	require.True(t, res.IsOK(), res.Log)
+	ack, _ := destChain.ibcm.GetAcknowledgement(destChain.ctx, srcChain.chainID, 1)
+	res = srcChain.handler(srcChain.ctx, IBCAcknowledgementMsg{destChain.chainID, 1, ack, updateMsg.Header.Height, proof, relayer})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte("fail"), srcChain.ctx.KVStore(key).Get([]byte("acknowledged")))
+}
+
+func TestDenomTrace(t *testing.T) {
+	cases := []struct {
+		denom string
+		trace DenomTrace
+	}{
+		{"mycoin", DenomTrace{BaseDenom: "mycoin"}},
+		{"transfer/chain-1/mycoin", DenomTrace{Path: "transfer/chain-1", BaseDenom: "mycoin"}},
+		{"transfer/chain-2/transfer/chain-1/mycoin", DenomTrace{Path: "transfer/chain-2/transfer/chain-1", BaseDenom: "mycoin"}},
+	}
+
+	for i, tc := range cases {
+		trace := NewDenomTrace(tc.denom)
+		require.Equal(t, tc.trace, trace, "%d", i)
+		require.Equal(t, tc.denom, trace.Denom(), "%d", i)
+	}
+}
+
+func TestUpdateClient(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	ctx, _ := defaultContext(key, "dest-chain")
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	chainID := "src-chain"
+	appHash := []byte("apphash")
+
+	// headers of a chain without a client are rejected
+	trusted := newTestValidators(4)
+	header, commit := trusted.signHeader(t, chainID, 2, appHash)
+	err := ibcm.UpdateClient(ctx, header, commit, trusted.validators())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownChain), err.ABCICode())
+
+	// clients are created once, with a positive trusting period and never
+	// for the chain itself
+	now := time.Now().UTC().Unix()
+	require.NotNil(t, ibcm.CreateClient(ctx, NewConsensusState(chainID, 0, now, 0, trusted.validators())))
+	require.NotNil(t, ibcm.CreateClient(ctx, NewConsensusState("dest-chain", 0, now, 100, trusted.validators())))
+	require.Nil(t, ibcm.CreateClient(ctx, NewConsensusState(chainID, 0, now, 100, trusted.validators())))
+	err = ibcm.CreateClient(ctx, NewConsensusState(chainID, 0, now, 100, newTestValidators(4).validators()))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeClientExists), err.ABCICode())
+
+	require.Nil(t, ibcm.UpdateClient(ctx, header, commit, trusted.validators()))
+	consensusState, found := ibcm.GetConsensusState(ctx, chainID)
+	require.True(t, found)
+	require.Equal(t, int64(2), consensusState.Height)
+	require.Equal(t, header.Time.Unix(), consensusState.Time)
+	require.Equal(t, int64(100), consensusState.TrustingPeriod)
+
+	// the client of the chain itself cannot be updated
+	header, commit = trusted.signHeader(t, "dest-chain", 2, appHash)
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, trusted.validators()))
+
+	// headers must be newer than the last verified one
+	header, commit = trusted.signHeader(t, chainID, 1, appHash)
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, trusted.validators()))
+
+	// headers must commit to the validator set
+	header, commit = trusted.signHeader(t, chainID, 3, appHash)
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, trusted[:3].validators()))
+
+	// headers must be signed by more than 2/3 of the validator set
+	header, commit = trusted.signHeader(t, chainID, 3, appHash)
+	commit.Precommits[0], commit.Precommits[1] = nil, nil
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, trusted.validators()))
+
+	// the commit must sign the header
+	header, commit = trusted.signHeader(t, chainID, 3, appHash)
+	header.AppHash = []byte("forged")
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, trusted.validators()))
+
+	// a validator set sharing more than 2/3 of the trusted power is accepted
+	changed := append(testValidators{}, trusted[:3]...)
+	changed = append(changed, newTestValidators(1)...)
+	header, commit = changed.signHeader(t, chainID, 3, appHash)
+	require.Nil(t, ibcm.UpdateClient(ctx, header, commit, changed.validators()))
+	require.Equal(t, appHash, ibcm.GetAppHash(ctx, chainID, 3))
+
+	// a validator set sharing less than 2/3 of the trusted power is rejected
+	replaced := append(testValidators{}, changed[:2]...)
+	replaced = append(replaced, newTestValidators(2)...)
+	header, commit = replaced.signHeader(t, chainID, 4, appHash)
+	require.NotNil(t, ibcm.UpdateClient(ctx, header, commit, replaced.validators()))
+	require.Nil(t, ibcm.GetAppHash(ctx, chainID, 4))
+
+	// the validator set is no longer trusted once the trusting period elapsed
+	consensusState, _ = ibcm.GetConsensusState(ctx, chainID)
+	header, commit = changed.signHeader(t, chainID, 4, appHash)
+	expired := ctx.WithBlockHeader(abci.Header{ChainID: "dest-chain", Time: consensusState.Time + 101})
+	err = ibcm.UpdateClient(expired, header, commit, changed.validators())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeClientExpired), err.ABCICode())
+	notExpired := ctx.WithBlockHeader(abci.Header{ChainID: "dest-chain", Time: consensusState.Time + 100})
+	require.Nil(t, ibcm.UpdateClient(notExpired, header, commit, changed.validators()))
 }
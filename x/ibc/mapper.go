@@ -3,6 +3,9 @@ package ibc
 import (
 	"fmt"
 
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 )
@@ -12,6 +15,7 @@ type Mapper struct {
 	key       sdk.StoreKey
 	cdc       *wire.Codec
 	codespace sdk.CodespaceType
+	ports     map[string]Module // modules bound with BindPort
 }
 
 // XXX: The Mapper should not take a CoinKeeper. Rather have the CoinKeeper
@@ -22,14 +26,42 @@ func NewMapper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) M
 		key:       key,
 		cdc:       cdc,
 		codespace: codespace,
+		ports:     make(map[string]Module),
+	}
+}
+
+// BindPort binds a module to a port, the callbacks of the module are called
+// with the packets of the port and the returned Port sends packets from it. A
+// port can only be bound once.
+func (ibcm Mapper) BindPort(port string, module Module) Port {
+	if !isValidPort(port) {
+		panic(fmt.Sprintf("invalid IBC port %q, ports are lowercase alphanumeric of 2 to 16 characters", port))
 	}
+	if _, found := ibcm.ports[port]; found {
+		panic(fmt.Sprintf("IBC port %s is already bound", port))
+	}
+	ibcm.ports[port] = module
+	return Port{port, ibcm}
+}
+
+// GetModule returns the module bound to a port
+func (ibcm Mapper) GetModule(port string) (module Module, found bool) {
+	module, found = ibcm.ports[port]
+	return module, found
 }
 
-// XXX: This is not the public API. This will change in MVP2 and will henceforth
-// only be invoked from another module directly and not through a user
-// transaction.
-// TODO: Handle invalid IBC packets and return errors.
-func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+// postIBCPacket sends a packet from the port of a module to the destination
+// chain. The packet is written to the egress queue of the destination chain,
+// from which relayers prove it, and is pending until it is acknowledged or
+// times out.
+func (ibcm Mapper) postIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if packet.SrcChain != ctx.ChainID() {
+		return ErrInvalidSrcChain(ibcm.codespace, packet.SrcChain)
+	}
+	if _, found := ibcm.GetModule(packet.SrcPort); !found {
+		return ErrUnknownPort(ibcm.codespace, packet.SrcPort)
+	}
+
 	// write everything into the state
 	store := ctx.KVStore(ibcm.key)
 	index := ibcm.getEgressLength(store, packet.DestChain)
@@ -45,18 +77,149 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	}
 	store.Set(EgressLengthKey(packet.DestChain), bz)
 
+	// the packet is pending until it is acknowledged or times out
+	store.Set(PendingPacketKey(packet.DestChain, index), []byte{0x01})
+
+	return nil
+}
+
+// ReceiveIBCPacket verifies that the packet was posted by the source chain
+// under the given sequence, by checking the proof of its egress key in the
+// ibc store of the source chain against the app hash of the verified header
+// of the source chain at the given height.
+func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket, sequence int64,
+	height int64, proof store.MultiStoreProof) sdk.Error {
+
+	if packet.DestChain != ctx.ChainID() {
+		return ErrInvalidDestChain(ibcm.codespace, packet.DestChain)
+	}
+
+	// the header at a height commits to the app hash of the previous height
+	appHash := ibcm.GetAppHash(ctx, packet.SrcChain, height)
+	if appHash == nil {
+		return ErrUnknownHeader(ibcm.codespace, packet.SrcChain, height)
+	}
+
+	// the counterparty chain mounts its ibc store under the same name
+	bz := marshalBinaryPanic(ibcm.cdc, packet)
+	err := proof.Verify(appHash, ibcm.key.Name(), EgressKey(packet.DestChain, sequence), bz)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
 	return nil
 }
 
-// XXX: In the future every module is able to register it's own handler for
-// handling it's own IBC packets. The "ibc" handler will only route the packets
-// to the appropriate callbacks.
-// XXX: For now this handles all interactions with the CoinKeeper.
-// XXX: This needs to do some authentication checking.
-func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+// CreateClient creates the light client of a counterparty chain, its
+// validator set is the root of trust of the client. Clients are only created
+// at genesis, they are never replaced.
+func (ibcm Mapper) CreateClient(ctx sdk.Context, consensusState ConsensusState) sdk.Error {
+	if consensusState.ChainID == ctx.ChainID() {
+		return ErrIdenticalChains(ibcm.codespace)
+	}
+	err := consensusState.ValidateBasic()
+	if err != nil {
+		return ErrInvalidClient(ibcm.codespace, err.Error())
+	}
+	if _, found := ibcm.GetConsensusState(ctx, consensusState.ChainID); found {
+		return ErrClientExists(ibcm.codespace, consensusState.ChainID)
+	}
+
+	ibcm.SetConsensusState(ctx, consensusState)
+	return nil
+}
+
+// UpdateClient verifies a header of a counterparty chain against the light
+// client of the chain and records its app hash. The client must exist and
+// its trusting period must not have elapsed, a client whose validator set
+// expired can't be updated anymore.
+func (ibcm Mapper) UpdateClient(ctx sdk.Context, header tmtypes.Header, commit tmtypes.Commit,
+	validators []*tmtypes.Validator) sdk.Error {
+
+	if header.ChainID == ctx.ChainID() {
+		return ErrIdenticalChains(ibcm.codespace)
+	}
+
+	consensusState, found := ibcm.GetConsensusState(ctx, header.ChainID)
+	if !found {
+		return ErrUnknownChain(ibcm.codespace, header.ChainID)
+	}
+	if consensusState.Expired(ctx.BlockHeader().Time) {
+		return ErrClientExpired(ibcm.codespace, header.ChainID, consensusState.Time+consensusState.TrustingPeriod)
+	}
+	err := consensusState.VerifyHeader(header, commit, validators)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	ibcm.SetConsensusState(ctx, NewConsensusState(header.ChainID, header.Height, header.Time.Unix(),
+		consensusState.TrustingPeriod, validators))
+	ibcm.SetAppHash(ctx, header.ChainID, header.Height, header.AppHash)
+	ibcm.SetHeaderTime(ctx, header.ChainID, header.Height, header.Time.Unix())
 	return nil
 }
 
+// AcknowledgePacket verifies that the destination chain of a pending packet
+// wrote the acknowledgement for it, by checking the proof of its
+// acknowledgement key in the ibc store of the destination chain against the
+// app hash of the verified header of the destination chain at the given
+// height. The packet is no longer pending and is returned.
+func (ibcm Mapper) AcknowledgePacket(ctx sdk.Context, destChain string, sequence int64,
+	ack Acknowledgement, height int64, proof store.MultiStoreProof) (packet IBCPacket, err sdk.Error) {
+
+	packet, found := ibcm.getPendingPacket(ctx, destChain, sequence)
+	if !found {
+		return packet, ErrUnknownPacket(ibcm.codespace, destChain, sequence)
+	}
+
+	appHash := ibcm.GetAppHash(ctx, destChain, height)
+	if appHash == nil {
+		return packet, ErrUnknownHeader(ibcm.codespace, destChain, height)
+	}
+
+	bz := marshalBinaryPanic(ibcm.cdc, ack)
+	verifyErr := proof.Verify(appHash, ibcm.key.Name(), AcknowledgementKey(packet.SrcChain, sequence), bz)
+	if verifyErr != nil {
+		return packet, ErrInvalidProof(ibcm.codespace, verifyErr.Error())
+	}
+
+	ctx.KVStore(ibcm.key).Delete(PendingPacketKey(destChain, sequence))
+	return packet, nil
+}
+
+// TimeoutPacket verifies that a pending packet timed out before the
+// destination chain received it, by checking the proof of the absence of its
+// acknowledgement key in the ibc store of the destination chain against the
+// app hash of a verified header of the destination chain at which the packet
+// timed out. The destination chain can no longer process the packet, which is
+// no longer pending and is returned.
+func (ibcm Mapper) TimeoutPacket(ctx sdk.Context, destChain string, sequence int64,
+	height int64, proof store.MultiStoreProof) (packet IBCPacket, err sdk.Error) {
+
+	packet, found := ibcm.getPendingPacket(ctx, destChain, sequence)
+	if !found {
+		return packet, ErrUnknownPacket(ibcm.codespace, destChain, sequence)
+	}
+
+	// the blocks following the header are at least as high and as late, so
+	// they can only receive the packet as timed out
+	appHash := ibcm.GetAppHash(ctx, destChain, height)
+	if appHash == nil {
+		return packet, ErrUnknownHeader(ibcm.codespace, destChain, height)
+	}
+	if !packet.TimedOut(height, ibcm.GetHeaderTime(ctx, destChain, height)) {
+		return packet, ErrInvalidTimeout(ibcm.codespace,
+			fmt.Sprintf("packet hasn't timed out at height %d of chain %s", height, destChain))
+	}
+
+	verifyErr := proof.VerifyAbsence(appHash, ibcm.key.Name(), AcknowledgementKey(packet.SrcChain, sequence))
+	if verifyErr != nil {
+		return packet, ErrInvalidProof(ibcm.codespace, verifyErr.Error())
+	}
+
+	ctx.KVStore(ibcm.key).Delete(PendingPacketKey(destChain, sequence))
+	return packet, nil
+}
+
 // --------------------------
 // Functions for accessing the underlying KVStore.
 
@@ -101,6 +264,119 @@ func (ibcm Mapper) SetIngressSequence(ctx sdk.Context, srcChain string, sequence
 	store.Set(key, bz)
 }
 
+// GetEgressPacket returns the packet sent to a chain with a sequence
+func (ibcm Mapper) GetEgressPacket(ctx sdk.Context, destChain string, sequence int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EgressKey(destChain, sequence))
+	if bz == nil {
+		return packet, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &packet)
+	return packet, true
+}
+
+// returns the packet sent to a chain with a sequence if it is neither
+// acknowledged nor timed out
+func (ibcm Mapper) getPendingPacket(ctx sdk.Context, destChain string, sequence int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	if !store.Has(PendingPacketKey(destChain, sequence)) {
+		return packet, false
+	}
+	return ibcm.GetEgressPacket(ctx, destChain, sequence)
+}
+
+// GetAcknowledgement returns the acknowledgement of the packet received from
+// a chain with a sequence
+func (ibcm Mapper) GetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64) (ack Acknowledgement, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(AcknowledgementKey(srcChain, sequence))
+	if bz == nil {
+		return ack, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &ack)
+	return ack, true
+}
+
+// SetAcknowledgement sets the acknowledgement of the packet received from a
+// chain with a sequence
+func (ibcm Mapper) SetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64, ack Acknowledgement) {
+	store := ctx.KVStore(ibcm.key)
+	bz := marshalBinaryPanic(ibcm.cdc, ack)
+	store.Set(AcknowledgementKey(srcChain, sequence), bz)
+}
+
+// GetConsensusState returns the light client state of a counterparty chain
+func (ibcm Mapper) GetConsensusState(ctx sdk.Context, chainID string) (consensusState ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ConsensusStateKey(chainID))
+	if bz == nil {
+		return consensusState, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &consensusState)
+	return consensusState, true
+}
+
+// SetConsensusState sets the light client state of a counterparty chain
+func (ibcm Mapper) SetConsensusState(ctx sdk.Context, consensusState ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	bz := marshalBinaryPanic(ibcm.cdc, consensusState)
+	store.Set(ConsensusStateKey(consensusState.ChainID), bz)
+}
+
+// GetAppHash returns the app hash of the verified header of a counterparty
+// chain at a height, nil if no header was verified at this height
+func (ibcm Mapper) GetAppHash(ctx sdk.Context, chainID string, height int64) []byte {
+	store := ctx.KVStore(ibcm.key)
+	return store.Get(AppHashKey(chainID, height))
+}
+
+// SetAppHash sets the app hash of the verified header of a counterparty chain
+// at a height
+func (ibcm Mapper) SetAppHash(ctx sdk.Context, chainID string, height int64, appHash []byte) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(AppHashKey(chainID, height), appHash)
+}
+
+// GetHeaderTime returns the time, in unix seconds, of the verified header of
+// a counterparty chain at a height
+func (ibcm Mapper) GetHeaderTime(ctx sdk.Context, chainID string, height int64) int64 {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(HeaderTimeKey(chainID, height))
+	if bz == nil {
+		return 0
+	}
+	var res int64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &res)
+	return res
+}
+
+// SetHeaderTime sets the time, in unix seconds, of the verified header of a
+// counterparty chain at a height
+func (ibcm Mapper) SetHeaderTime(ctx sdk.Context, chainID string, height int64, time int64) {
+	store := ctx.KVStore(ibcm.key)
+	bz := marshalBinaryPanic(ibcm.cdc, time)
+	store.Set(HeaderTimeKey(chainID, height), bz)
+}
+
+// GetDenomTrace returns the trace of a voucher denomination minted by this
+// chain
+func (ibcm Mapper) GetDenomTrace(ctx sdk.Context, denom string) (denomTrace DenomTrace, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(DenomTraceKey(denom))
+	if bz == nil {
+		return denomTrace, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &denomTrace)
+	return denomTrace, true
+}
+
+// SetDenomTrace sets the trace of a voucher denomination
+func (ibcm Mapper) SetDenomTrace(ctx sdk.Context, denomTrace DenomTrace) {
+	store := ctx.KVStore(ibcm.key)
+	bz := marshalBinaryPanic(ibcm.cdc, denomTrace)
+	store.Set(DenomTraceKey(denomTrace.Denom()), bz)
+}
+
 // Retrieves the index of the currently stored outgoing IBC packets.
 func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
 	bz := store.Get(EgressLengthKey(destChain))
@@ -128,3 +404,34 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Marks an outgoing IBC packet which is neither acknowledged nor timed out under
+// "pending/chain_id/index".
+func PendingPacketKey(destChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("pending/%s/%d", destChain, index))
+}
+
+// Stores the acknowledgement of an incoming IBC packet under "ack/chain_id/index".
+func AcknowledgementKey(srcChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("ack/%s/%d", srcChain, index))
+}
+
+// Stores the light client state of a counterparty chain under "client/chain_id".
+func ConsensusStateKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("client/%s", chainID))
+}
+
+// Stores the app hash of a verified header under "apphash/chain_id/height".
+func AppHashKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("apphash/%s/%d", chainID, height))
+}
+
+// Stores the time of a verified header under "headertime/chain_id/height".
+func HeaderTimeKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("headertime/%s/%d", chainID, height))
+}
+
+// Stores the trace of a voucher denomination under "denomtrace/denom".
+func DenomTraceKey(denom string) []byte {
+	return []byte(fmt.Sprintf("denomtrace/%s", denom))
+}
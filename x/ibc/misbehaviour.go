@@ -0,0 +1,153 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+)
+
+// MisbehaviourEvidenceType is the evidence type under which the handler of
+// Misbehaviour is registered
+const MisbehaviourEvidenceType = "ibc/misbehaviour"
+
+// punishment of a misbehaving validator
+var (
+	misbehaviourSlashFraction       = sdk.NewRat(1, 20)
+	misbehaviourJailDuration  int64 = 60 * 60 * 24
+)
+
+// Misbehaviour is the evidence of a validator of this chain signing two votes
+// for different blocks at the same height and round, such as the commits of
+// conflicting headers shown to the light client of this chain on a
+// counterparty chain. Such a fork never reaches the consensus of this chain,
+// so tendermint can't report it and it is submitted with a MsgSubmitEvidence.
+// The votes are ordered by block ID so that the same misbehaviour always has
+// the same hash. The power is the current power of the validator, the votes
+// don't prove its power at the height of the infraction.
+type Misbehaviour struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+	VoteA  *tmtypes.Vote `json:"vote_a"`
+	VoteB  *tmtypes.Vote `json:"vote_b"`
+	Power  int64         `json:"power"`
+}
+
+var _ evidence.Evidence = Misbehaviour{}
+
+// NewMisbehaviour returns the evidence of the validator signing both votes,
+// in canonical order
+func NewMisbehaviour(pubKey crypto.PubKey, voteA, voteB *tmtypes.Vote, power int64) Misbehaviour {
+	if voteA != nil && voteB != nil && voteA.BlockID.Key() > voteB.BlockID.Key() {
+		voteA, voteB = voteB, voteA
+	}
+	return Misbehaviour{
+		PubKey: pubKey,
+		VoteA:  voteA,
+		VoteB:  voteB,
+		Power:  power,
+	}
+}
+
+// nolint
+func (m Misbehaviour) Type() string                      { return MisbehaviourEvidenceType }
+func (m Misbehaviour) GetConsensusPubKey() crypto.PubKey { return m.PubKey }
+func (m Misbehaviour) GetHeight() int64                  { return m.VoteA.Height }
+func (m Misbehaviour) GetTime() int64                    { return m.VoteA.Timestamp.Unix() }
+func (m Misbehaviour) GetValidatorPower() int64          { return m.Power }
+
+func (m Misbehaviour) String() string {
+	return fmt.Sprintf("misbehaviour of validator %s at height %d, round %d, power %d",
+		m.PubKey.Address(), m.VoteA.Height, m.VoteA.Round, m.Power)
+}
+
+// ValidateBasic implements Evidence
+func (m Misbehaviour) ValidateBasic() sdk.Error {
+	if m.PubKey == nil {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "validator pubkey cannot be empty")
+	}
+	if m.VoteA == nil || m.VoteB == nil {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "both votes are required")
+	}
+	if m.Power <= 0 {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "power must be positive")
+	}
+	a, b := m.VoteA, m.VoteB
+	address := m.PubKey.Address()
+	if !bytes.Equal(a.ValidatorAddress, address) || !bytes.Equal(b.ValidatorAddress, address) {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "votes aren't signed by the validator")
+	}
+	if a.Height <= 0 || a.Height != b.Height || a.Round != b.Round || a.Type != b.Type {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "votes must be of the same positive height, round and type")
+	}
+	if a.BlockID.Key() >= b.BlockID.Key() {
+		return ErrInvalidMisbehaviour(DefaultCodespace, "votes must be for different blocks, ordered by block ID")
+	}
+	return nil
+}
+
+// GetSignBytes implements Evidence
+func (m Misbehaviour) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(m)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Verify checks the signatures of both votes for the chain
+func (m Misbehaviour) Verify(chainID string) error {
+	if !m.PubKey.VerifyBytes(m.VoteA.SignBytes(chainID), m.VoteA.Signature) {
+		return fmt.Errorf("invalid signature of the first vote")
+	}
+	if !m.PubKey.VerifyBytes(m.VoteB.SignBytes(chainID), m.VoteB.Signature) {
+		return fmt.Errorf("invalid signature of the second vote")
+	}
+	return nil
+}
+
+// MisbehaviourHandler returns the evidence handler verifying the misbehaviour
+// of the validators of this chain. Misbehaviour older than the max evidence
+// age, as the evidence keeper no longer remembers it, or of a validator which
+// is jailed already is rejected.
+func (ibcm Mapper) MisbehaviourHandler(vs sdk.ValidatorSet, maxEvidenceAge func(ctx sdk.Context) int64) evidence.Handler {
+	return func(ctx sdk.Context, ev evidence.Evidence) sdk.Error {
+		m, ok := ev.(Misbehaviour)
+		if !ok {
+			return ErrInvalidMisbehaviour(ibcm.codespace, fmt.Sprintf("unexpected evidence %T", ev))
+		}
+
+		if m.GetHeight() > ctx.BlockHeight() {
+			return ErrInvalidMisbehaviour(ibcm.codespace,
+				fmt.Sprintf("votes at height %d are above the current height %d", m.GetHeight(), ctx.BlockHeight()))
+		}
+		age := ctx.BlockHeader().Time - m.GetTime()
+		if age > maxEvidenceAge(ctx) {
+			return ErrInvalidMisbehaviour(ibcm.codespace,
+				fmt.Sprintf("age of %d is past the max evidence age of %d", age, maxEvidenceAge(ctx)))
+		}
+		err := m.Verify(ctx.ChainID())
+		if err != nil {
+			return ErrInvalidMisbehaviour(ibcm.codespace, err.Error())
+		}
+
+		validator := vs.ValidatorByPubKey(ctx, m.PubKey)
+		if validator.GetJailed() {
+			return ErrInvalidMisbehaviour(ibcm.codespace, "validator is already jailed")
+		}
+		if power := validator.GetPower().RoundInt64(); m.Power != power {
+			return ErrInvalidMisbehaviour(ibcm.codespace,
+				fmt.Sprintf("power %d isn't the power %d of the validator", m.Power, power))
+		}
+		return nil
+	}
+}
+
+// MisbehaviourPolicy is the punishment of a misbehaving validator, it is
+// slashed by 5% and jailed for a day
+func MisbehaviourPolicy() evidence.Policy {
+	return evidence.FixedPolicy(misbehaviourSlashFraction, misbehaviourJailDuration)
+}
@@ -0,0 +1,185 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+const misbehaviourChainID = "misbehaving-chain"
+
+// signVote returns a precommit of the validator for the block at the height
+func signVote(t *testing.T, priv crypto.PrivKeyEd25519, height int64, blockHash string, timestamp time.Time) *tmtypes.Vote {
+	vote := &tmtypes.Vote{
+		ValidatorAddress: priv.PubKey().Address(),
+		Height:           height,
+		Timestamp:        timestamp,
+		Type:             tmtypes.VoteTypePrecommit,
+		BlockID:          tmtypes.BlockID{Hash: []byte(blockHash)},
+	}
+	sig, err := priv.Sign(vote.SignBytes(misbehaviourChainID))
+	require.Nil(t, err)
+	vote.Signature = sig
+	return vote
+}
+
+// test input with a validator bonded with the key of priv, along with the
+// evidence keeper handling misbehaviour
+func createMisbehaviourInput(t *testing.T, priv crypto.PrivKeyEd25519) (sdk.Context, stake.Keeper, evidence.Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyEvidence := sdk.NewKVStoreKey("evidence")
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	keyParams := sdk.NewKVStoreKey("params")
+	keySupply := sdk.NewKVStoreKey("supply")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyEvidence, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: misbehaviourChainID}, false, log.NewNopLogger())
+
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	evidence.RegisterWire(cdc)
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, auth.ProtoBaseAccount)
+	pk := params.NewKeeper(cdc, keyParams)
+	ck := bank.NewKeeper(accountMapper, pk.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(cdc, keySupply, ck, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+	})
+	sk := stake.NewKeeper(cdc, keyStake, ck, supplyKeeper, stake.DefaultCodespace)
+	genesis := stake.DefaultGenesisState()
+	genesis.Pool.LooseTokens = sdk.NewRat(200)
+	_, err = stake.InitGenesis(ctx, sk, genesis)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	coins := sdk.Coins{{"steak", sdk.NewInt(200)}}
+	_, _, err = ck.AddCoins(ctx, addr, coins)
+	require.Nil(t, err)
+	supplyKeeper.Inflate(ctx, coins)
+	got := stake.NewHandler(sk)(ctx, stake.MsgCreateValidator{
+		Description:       stake.Description{},
+		DelegatorAddr:     addr,
+		ValidatorAddr:     addr,
+		PubKey:            priv.PubKey(),
+		Delegation:        sdk.Coin{"steak", sdk.NewInt(100)},
+		MinSelfDelegation: sdk.OneInt(),
+	})
+	require.True(t, got.IsOK(), "%v", got)
+	stake.EndBlocker(ctx, sk)
+
+	maxEvidenceAge := func(sdk.Context) int64 { return 100 }
+	ibcm := NewMapper(cdc, keyIBC, DefaultCodespace)
+	ek := evidence.NewKeeper(cdc, keyEvidence, sk, maxEvidenceAge, evidence.DefaultCodespace)
+	ek.RegisterHandler(MisbehaviourEvidenceType, ibcm.MisbehaviourHandler(sk, maxEvidenceAge), MisbehaviourPolicy())
+	return ctx, sk, ek
+}
+
+func TestMisbehaviourValidation(t *testing.T) {
+	priv := crypto.GenPrivKeyEd25519()
+	other := crypto.GenPrivKeyEd25519()
+	now := time.Unix(1000, 0).UTC()
+	voteA := signVote(t, priv, 10, "blockA", now)
+	voteB := signVote(t, priv, 10, "blockB", now)
+	otherHeight := signVote(t, priv, 11, "blockB", now)
+	otherSigner := signVote(t, other, 10, "blockB", now)
+
+	tests := []struct {
+		name         string
+		misbehaviour Misbehaviour
+		expectPass   bool
+	}{
+		{"conflicting votes", NewMisbehaviour(priv.PubKey(), voteA, voteB, 100), true},
+		{"votes in any order", NewMisbehaviour(priv.PubKey(), voteB, voteA, 100), true},
+		{"unordered votes", Misbehaviour{priv.PubKey(), voteB, voteA, 100}, false},
+		{"same vote", NewMisbehaviour(priv.PubKey(), voteA, voteA, 100), false},
+		{"different heights", NewMisbehaviour(priv.PubKey(), voteA, otherHeight, 100), false},
+		{"other signer", NewMisbehaviour(priv.PubKey(), voteA, otherSigner, 100), false},
+		{"missing vote", NewMisbehaviour(priv.PubKey(), voteA, nil, 100), false},
+		{"missing pubkey", NewMisbehaviour(nil, voteA, voteB, 100), false},
+		{"no power", NewMisbehaviour(priv.PubKey(), voteA, voteB, 0), false},
+	}
+
+	for _, tc := range tests {
+		err := tc.misbehaviour.ValidateBasic()
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", tc.name)
+		} else {
+			require.NotNil(t, err, "test: %v", tc.name)
+		}
+	}
+}
+
+func TestSubmitMisbehaviour(t *testing.T) {
+	priv := crypto.GenPrivKeyEd25519()
+	ctx, sk, ek := createMisbehaviourInput(t, priv)
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: misbehaviourChainID, Height: 20, Time: 1050})
+	handler := evidence.NewHandler(ek)
+	submitter := newAddress()
+
+	now := time.Unix(1000, 0).UTC()
+	voteA := signVote(t, priv, 10, "blockA", now)
+	voteB := signVote(t, priv, 10, "blockB", now)
+
+	// rejected when the power isn't the power of the validator
+	got := handler(ctx, evidence.NewMsgSubmitEvidence(submitter, NewMisbehaviour(priv.PubKey(), voteA, voteB, 50)))
+	require.False(t, got.IsOK())
+
+	// rejected when a vote isn't signed for this chain
+	forged := *voteB
+	forged.Signature = voteA.Signature
+	got = handler(ctx, evidence.NewMsgSubmitEvidence(submitter, NewMisbehaviour(priv.PubKey(), voteA, &forged, 100)))
+	require.False(t, got.IsOK())
+
+	// rejected when the votes are older than the max evidence age
+	old := time.Unix(900, 0).UTC()
+	got = handler(ctx, evidence.NewMsgSubmitEvidence(submitter,
+		NewMisbehaviour(priv.PubKey(), signVote(t, priv, 10, "blockA", old), signVote(t, priv, 10, "blockB", old), 100)))
+	require.False(t, got.IsOK())
+
+	// the validator is slashed and jailed
+	misbehaviour := NewMisbehaviour(priv.PubKey(), voteA, voteB, 100)
+	got = handler(ctx, evidence.NewMsgSubmitEvidence(submitter, misbehaviour))
+	require.True(t, got.IsOK(), "%v", got)
+	_, found := ek.GetEvidence(ctx, got.Data)
+	require.True(t, found)
+	validator, found := sk.GetValidatorByPubKey(ctx, priv.PubKey())
+	require.True(t, found)
+	require.True(t, validator.GetJailed())
+	require.Equal(t, int64(1050+misbehaviourJailDuration), validator.GetJailedUntil())
+	require.Equal(t, sdk.NewRat(95), validator.GetTokens())
+
+	// the same misbehaviour is handled only once
+	got = handler(ctx, evidence.NewMsgSubmitEvidence(submitter, NewMisbehaviour(priv.PubKey(), voteB, voteA, 100)))
+	require.False(t, got.IsOK())
+}
@@ -0,0 +1,48 @@
+package ibc
+
+import (
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Module is implemented by the modules which exchange packets with other
+// chains through a port, the callbacks are called with the packets of the
+// port once their proof is verified.
+type Module interface {
+	// OnRecvPacket processes a packet received from the source chain, the
+	// state changes of a packet which fails are discarded and its error is
+	// acknowledged to the source chain
+	OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error
+
+	// OnAcknowledge processes the acknowledgement of a packet sent to the
+	// destination chain, whether it succeeded or failed
+	OnAcknowledge(ctx sdk.Context, packet IBCPacket, ack Acknowledgement) sdk.Error
+
+	// OnTimeout processes a packet which timed out before the destination
+	// chain received it
+	OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error
+}
+
+// Port is the capability of sending packets from a port, it is returned by
+// BindPort to the module bound to the port
+type Port struct {
+	name string
+	ibcm Mapper
+}
+
+// Name returns the name of the port
+func (p Port) Name() string {
+	return p.name
+}
+
+// PostIBCPacket sends a packet from the port to the destination chain
+func (p Port) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if packet.SrcPort != p.name {
+		return ErrInvalidSrcPort(p.ibcm.codespace, packet.SrcPort, p.name)
+	}
+	return p.ibcm.postIBCPacket(ctx, packet)
+}
+
+// ports are lowercase alphanumeric, they prefix voucher denominations
+var isValidPort = regexp.MustCompile(`^[a-z][a-z0-9]{1,15}$`).MatchString
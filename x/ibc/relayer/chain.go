@@ -0,0 +1,31 @@
+package relayer
+
+import (
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Chain is a chain the relayer relays packets from and to
+type Chain interface {
+	// ChainID returns the ID of the chain
+	ChainID() string
+
+	// LatestHeight returns the height of the latest header of the chain. The
+	// header commits to the app hash of the state at the previous height.
+	LatestHeight() (int64, error)
+
+	// QueryIBC returns the value of a key of the ibc store at a height, along
+	// with its proof against the app hash if prove is set. A zero height
+	// queries the latest state.
+	QueryIBC(key []byte, height int64, prove bool) (value []byte, proof store.MultiStoreProof, err error)
+
+	// Header returns the header of the chain at a height, the commit signing
+	// it and the validator set it commits to
+	Header(height int64) (tmtypes.Header, tmtypes.Commit, []*tmtypes.Validator, error)
+
+	// Broadcast signs the messages in a single transaction, broadcasts it and
+	// waits for it to be committed
+	Broadcast(msgs []sdk.Msg) error
+}
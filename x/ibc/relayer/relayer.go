@@ -0,0 +1,389 @@
+package relayer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+// nolint
+const (
+	DefaultMaxMsgs      = 20
+	DefaultPollInterval = 5 * time.Second
+	MinBackoff          = time.Second
+	MaxBackoff          = time.Minute
+)
+
+// Relayer relays the packets between two chains in both directions. It
+// receives the packets sent by each chain on the other one, and settles them
+// on their source chain with their acknowledgement or their timeout, along
+// with the headers verifying the proofs.
+type Relayer struct {
+	cdc          *wire.Codec
+	chains       [2]Chain
+	relayer      sdk.AccAddress
+	maxMsgs      int
+	pollInterval time.Duration
+	statePath    string
+	logger       log.Logger
+
+	mtx    sync.Mutex
+	state  State
+	status Status
+}
+
+// NewRelayer returns a relayer between two chains, signing its messages with
+// the relayer address. The progress of the relayer is persisted to the state
+// file.
+func NewRelayer(cdc *wire.Codec, chainA, chainB Chain, relayer sdk.AccAddress, statePath string,
+	logger log.Logger) (*Relayer, error) {
+
+	if chainA.ChainID() == chainB.ChainID() {
+		return nil, fmt.Errorf("cannot relay packets from chain %s to itself", chainA.ChainID())
+	}
+	state, err := LoadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Relayer{
+		cdc:          cdc,
+		chains:       [2]Chain{chainA, chainB},
+		relayer:      relayer,
+		maxMsgs:      DefaultMaxMsgs,
+		pollInterval: DefaultPollInterval,
+		statePath:    statePath,
+		logger:       logger,
+		state:        state,
+	}
+	for _, path := range r.paths() {
+		pathState := r.state.path(path[0].ChainID(), path[1].ChainID())
+		r.status.Paths = append(r.status.Paths, PathStatus{
+			SrcChain:  path[0].ChainID(),
+			DestChain: path[1].ChainID(),
+			Settled:   pathState.Settled,
+		})
+	}
+	return r, nil
+}
+
+// WithMaxMsgs sets the maximum number of packets relayed in a transaction
+func (r *Relayer) WithMaxMsgs(maxMsgs int) *Relayer {
+	r.maxMsgs = maxMsgs
+	return r
+}
+
+// WithPollInterval sets the time the relayer waits for new packets after
+// relaying all the packets
+func (r *Relayer) WithPollInterval(pollInterval time.Duration) *Relayer {
+	r.pollInterval = pollInterval
+	return r
+}
+
+// the source and destination chains of each direction
+func (r *Relayer) paths() [][2]Chain {
+	return [][2]Chain{
+		{r.chains[0], r.chains[1]},
+		{r.chains[1], r.chains[0]},
+	}
+}
+
+// Run relays the packets until stop is closed. Failed steps are retried
+// with an exponential backoff.
+func (r *Relayer) Run(stop <-chan struct{}) {
+	backoff := MinBackoff
+	for {
+		wait := r.pollInterval
+		err := r.Step()
+		if err != nil {
+			r.logger.Error("error relaying packets, retrying", "err", err, "backoff", backoff)
+			wait = backoff
+			backoff *= 2
+			if backoff > MaxBackoff {
+				backoff = MaxBackoff
+			}
+		} else {
+			backoff = MinBackoff
+		}
+		r.setError(err)
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Step relays the pending packets of both directions once, it receives the
+// packets sent by each chain, then settles the packets which were
+// acknowledged or timed out
+func (r *Relayer) Step() error {
+	var firstErr error
+	for _, path := range r.paths() {
+		err := r.RelayPackets(path[0], path[1])
+		if err == nil {
+			err = r.SettlePackets(path[0], path[1])
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s -> %s: %v", path[0].ChainID(), path[1].ChainID(), err)
+		}
+	}
+	return firstErr
+}
+
+// RelayPackets receives the packets sent by the source chain which the
+// destination chain didn't receive yet, in a single transaction along with
+// the header of the source chain verifying their proofs
+func (r *Relayer) RelayPackets(src, dest Chain) error {
+	height, err := src.LatestHeight()
+	if err != nil {
+		return err
+	}
+	// the packets are proven at the height before the latest one, as the
+	// latest header commits to its app hash
+	proofHeight := height - 1
+	if proofHeight < 1 {
+		return nil
+	}
+
+	sent, err := r.queryInt64(src, ibc.EgressLengthKey(dest.ChainID()), proofHeight)
+	if err != nil {
+		return err
+	}
+	received, err := r.queryInt64(dest, ibc.IngressSequenceKey(src.ChainID()), 0)
+	if err != nil {
+		return err
+	}
+	r.updateStatus(src, dest, func(status *PathStatus) {
+		status.Sent = sent
+		status.Received = received
+	})
+	if received >= sent {
+		return nil
+	}
+
+	var msgs []sdk.Msg
+	for seq := received; seq < sent && len(msgs) < r.maxMsgs; seq++ {
+		bz, proof, err := src.QueryIBC(ibc.EgressKey(dest.ChainID(), seq), proofHeight, true)
+		if err != nil {
+			return err
+		}
+		var packet ibc.IBCPacket
+		if err = r.cdc.UnmarshalBinary(bz, &packet); err != nil {
+			return err
+		}
+		msgs = append(msgs, ibc.IBCReceiveMsg{
+			IBCPacket: packet,
+			Relayer:   r.relayer,
+			Sequence:  seq,
+			Height:    height,
+			Proof:     proof,
+		})
+	}
+
+	err = r.broadcastWithClientUpdate(src, dest, height, msgs)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info("Relayed IBC packets", "src", src.ChainID(), "dest", dest.ChainID(),
+		"from", received, "to", received+int64(len(msgs))-1)
+	r.updateStatus(src, dest, func(status *PathStatus) {
+		status.Received = received + int64(len(msgs))
+		status.LastRelay = time.Now().UTC()
+	})
+	return nil
+}
+
+// SettlePackets acknowledges on the source chain the pending packets which the
+// destination chain received, and times out the ones which it can no longer
+// receive, in a single transaction along with the header of the destination
+// chain verifying their proofs
+func (r *Relayer) SettlePackets(src, dest Chain) error {
+	path := r.getPathState(src, dest)
+
+	sent, err := r.queryInt64(src, ibc.EgressLengthKey(dest.ChainID()), 0)
+	if err != nil {
+		return err
+	}
+	if path.Settled >= sent {
+		return nil
+	}
+
+	height, err := dest.LatestHeight()
+	if err != nil {
+		return err
+	}
+	proofHeight := height - 1
+	if proofHeight < 1 {
+		return nil
+	}
+	header, _, _, err := dest.Header(height)
+	if err != nil {
+		return err
+	}
+
+	// the packets below the first one which can't be settled yet are settled
+	// once the messages are committed
+	settled := path.Settled
+	contiguous := true
+	var msgs []sdk.Msg
+	for seq := path.Settled; seq < sent && len(msgs) < r.maxMsgs; seq++ {
+		pending, _, err := src.QueryIBC(ibc.PendingPacketKey(dest.ChainID(), seq), 0, false)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			msg, err := r.getSettleMsg(src, dest, seq, header.Height, header.Time.Unix())
+			if err != nil {
+				return err
+			}
+			if msg == nil {
+				contiguous = false
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+		if contiguous {
+			settled = seq + 1
+		}
+	}
+
+	if len(msgs) != 0 {
+		err = r.broadcastWithClientUpdate(dest, src, height, msgs)
+		if err != nil {
+			return err
+		}
+		r.logger.Info("Settled IBC packets", "src", src.ChainID(), "dest", dest.ChainID(), "number", len(msgs))
+	}
+
+	if settled != path.Settled {
+		err = r.setSettled(src, dest, settled)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSettleMsg returns the message acknowledging a pending packet, or timing
+// it out, at the height of the destination chain, nil if the packet can't be
+// settled yet
+func (r *Relayer) getSettleMsg(src, dest Chain, sequence, height, time int64) (sdk.Msg, error) {
+	ackbz, proof, err := dest.QueryIBC(ibc.AcknowledgementKey(src.ChainID(), sequence), height-1, true)
+	if err != nil {
+		return nil, err
+	}
+	if ackbz != nil {
+		var ack ibc.Acknowledgement
+		if err = r.cdc.UnmarshalBinary(ackbz, &ack); err != nil {
+			return nil, err
+		}
+		return ibc.IBCAcknowledgementMsg{
+			DestChain:       dest.ChainID(),
+			Sequence:        sequence,
+			Acknowledgement: ack,
+			Height:          height,
+			Proof:           proof,
+			Relayer:         r.relayer,
+		}, nil
+	}
+
+	bz, _, err := src.QueryIBC(ibc.EgressKey(dest.ChainID(), sequence), 0, false)
+	if err != nil {
+		return nil, err
+	}
+	var packet ibc.IBCPacket
+	if err = r.cdc.UnmarshalBinary(bz, &packet); err != nil {
+		return nil, err
+	}
+	if !packet.TimedOut(height, time) {
+		return nil, nil
+	}
+	return ibc.IBCTimeoutMsg{
+		DestChain: dest.ChainID(),
+		Sequence:  sequence,
+		Height:    height,
+		Proof:     proof,
+		Relayer:   r.relayer,
+	}, nil
+}
+
+// broadcastWithClientUpdate broadcasts the messages to a chain, after the
+// message updating the client of the counterparty chain with its header at
+// the height, unless it was already verified
+func (r *Relayer) broadcastWithClientUpdate(counterparty, chain Chain, height int64, msgs []sdk.Msg) error {
+	appHash, _, err := chain.QueryIBC(ibc.AppHashKey(counterparty.ChainID(), height), 0, false)
+	if err != nil {
+		return err
+	}
+	if appHash == nil {
+		bz, _, err := chain.QueryIBC(ibc.ConsensusStateKey(counterparty.ChainID()), 0, false)
+		if err != nil {
+			return err
+		}
+		if bz == nil {
+			return fmt.Errorf("chain %s has no client of chain %s, it must be created at genesis",
+				chain.ChainID(), counterparty.ChainID())
+		}
+		var consensusState ibc.ConsensusState
+		if err = r.cdc.UnmarshalBinary(bz, &consensusState); err != nil {
+			return err
+		}
+		if consensusState.Height >= height {
+			return fmt.Errorf("the client of chain %s on chain %s is at height %d, above the latest height %d",
+				counterparty.ChainID(), chain.ChainID(), consensusState.Height, height)
+		}
+
+		header, commit, validators, err := counterparty.Header(height)
+		if err != nil {
+			return err
+		}
+		updateMsg := ibc.IBCUpdateClientMsg{
+			Header:     header,
+			Commit:     commit,
+			Validators: validators,
+			Relayer:    r.relayer,
+		}
+		msgs = append([]sdk.Msg{updateMsg}, msgs...)
+	}
+	return chain.Broadcast(msgs)
+}
+
+// queryInt64 returns an integer of the ibc store of a chain, zero if unset
+func (r *Relayer) queryInt64(chain Chain, key []byte, height int64) (int64, error) {
+	bz, _, err := chain.QueryIBC(key, height, false)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	var res int64
+	err = r.cdc.UnmarshalBinary(bz, &res)
+	return res, err
+}
+
+func (r *Relayer) getPathState(src, dest Chain) PathState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return *r.state.path(src.ChainID(), dest.ChainID())
+}
+
+// setSettled records and persists that the packets below a sequence are
+// settled
+func (r *Relayer) setSettled(src, dest Chain, settled int64) error {
+	r.mtx.Lock()
+	r.state.path(src.ChainID(), dest.ChainID()).Settled = settled
+	state := r.state
+	r.mtx.Unlock()
+
+	r.updateStatus(src, dest, func(status *PathStatus) {
+		status.Settled = settled
+	})
+	return state.Save(r.statePath)
+}
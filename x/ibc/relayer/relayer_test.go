@@ -0,0 +1,482 @@
+package relayer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// the time of the blocks of the mock chains at height 0
+var genesisTime = time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+
+const trustingPeriod = 24 * 60 * 60
+
+func makeCodec() *wire.Codec {
+	var cdc = wire.NewCodec()
+
+	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
+	ibc.RegisterWire(cdc)
+
+	cdc.RegisterInterface((*auth.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/relayer/Account", nil)
+	cdc.RegisterConcrete(&auth.ModuleAccount{}, "test/relayer/ModuleAccount", nil)
+	wire.RegisterCrypto(cdc)
+
+	cdc.Seal()
+
+	return cdc
+}
+
+func newAddress() sdk.AccAddress {
+	return sdk.AccAddress(crypto.GenPrivKeyEd25519().PubKey().Address())
+}
+
+// mockChain is an in-process chain committing a block for each broadcasted
+// transaction, its headers are signed by a static validator set
+type mockChain struct {
+	t          *testing.T
+	cdc        *wire.Codec
+	chainID    string
+	cms        store.CommitMultiStore
+	ibcm       ibc.Mapper
+	ck         bank.Keeper
+	handler    sdk.Handler
+	validators []crypto.PrivKeyEd25519
+	appHashes  map[int64][]byte
+}
+
+var _ Chain = &mockChain{}
+
+func newMockChain(t *testing.T, cdc *wire.Codec, chainID string) *mockChain {
+	key := sdk.NewKVStoreKey("ibc")
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.Nil(t, cms.LoadLatestVersion())
+
+	ibcm := ibc.NewMapper(cdc, key, ibc.DefaultCodespace)
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewKeeper(am, params.NewKeeper(cdc, key).Getter(), nil)
+	sk := bank.NewSupplyKeeper(cdc, key, ck, map[string][]string{
+		ibc.ModuleName: {auth.Minter, auth.Burner},
+	})
+	ibcm.BindPort(ibc.TransferPort, ibc.NewTransferModule(ibcm, ck, sk))
+
+	chain := &mockChain{
+		t:          t,
+		cdc:        cdc,
+		chainID:    chainID,
+		cms:        cms,
+		ibcm:       ibcm,
+		ck:         ck,
+		handler:    ibc.NewHandler(ibcm),
+		validators: []crypto.PrivKeyEd25519{crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()},
+		appHashes:  make(map[int64][]byte),
+	}
+	chain.commit()
+	return chain
+}
+
+func (chain *mockChain) ChainID() string {
+	return chain.chainID
+}
+
+func (chain *mockChain) LatestHeight() (int64, error) {
+	return chain.cms.LastCommitID().Version, nil
+}
+
+func (chain *mockChain) QueryIBC(key []byte, height int64, prove bool) ([]byte, store.MultiStoreProof, error) {
+	var proof store.MultiStoreProof
+	if height == 0 {
+		height = chain.cms.LastCommitID().Version
+	}
+	res := chain.cms.(store.Queryable).Query(abci.RequestQuery{
+		Path:   "/ibc/key",
+		Data:   key,
+		Height: height,
+		Prove:  prove,
+	})
+	if !res.IsOK() {
+		return nil, proof, fmt.Errorf("query failed: (%d) %s", res.Code, res.Log)
+	}
+	if len(res.Proof) != 0 {
+		if err := chain.cdc.UnmarshalBinary(res.Proof, &proof); err != nil {
+			return nil, proof, err
+		}
+	}
+	return res.Value, proof, nil
+}
+
+func (chain *mockChain) Header(height int64) (tmtypes.Header, tmtypes.Commit, []*tmtypes.Validator, error) {
+	appHash, ok := chain.appHashes[height-1]
+	if !ok {
+		return tmtypes.Header{}, tmtypes.Commit{}, nil, fmt.Errorf("no header at height %d", height)
+	}
+
+	validators := chain.validatorSet()
+	valSet := tmtypes.NewValidatorSet(validators)
+	header := tmtypes.Header{
+		ChainID:        chain.chainID,
+		Height:         height,
+		Time:           blockTime(height),
+		ValidatorsHash: valSet.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, len(valSet.Validators))
+	for idx, val := range valSet.Validators {
+		for _, priv := range chain.validators {
+			if !bytes.Equal(priv.PubKey().Address(), val.Address) {
+				continue
+			}
+			vote := &tmtypes.Vote{
+				ValidatorAddress: val.Address,
+				ValidatorIndex:   idx,
+				Height:           height,
+				Timestamp:        header.Time,
+				Type:             tmtypes.VoteTypePrecommit,
+				BlockID:          blockID,
+			}
+			sig, err := priv.Sign(vote.SignBytes(chain.chainID))
+			if err != nil {
+				return header, tmtypes.Commit{}, nil, err
+			}
+			vote.Signature = sig
+			precommits[idx] = vote
+		}
+	}
+	return header, tmtypes.Commit{BlockID: blockID, Precommits: precommits}, validators, nil
+}
+
+// Broadcast delivers the messages in the next block, which is only committed
+// if they all succeed
+func (chain *mockChain) Broadcast(msgs []sdk.Msg) error {
+	height := chain.cms.LastCommitID().Version + 1
+	msCache := chain.cms.CacheMultiStore()
+	ctx := chain.context(msCache, height)
+	for _, msg := range msgs {
+		res := chain.handler(ctx, msg)
+		if !res.IsOK() {
+			return fmt.Errorf("deliverTx failed: (%d) %s", res.Code, res.Log)
+		}
+	}
+	msCache.Write()
+	chain.commit()
+	return nil
+}
+
+func (chain *mockChain) validatorSet() []*tmtypes.Validator {
+	validators := make([]*tmtypes.Validator, len(chain.validators))
+	for i, priv := range chain.validators {
+		validators[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	return validators
+}
+
+func (chain *mockChain) context(ms sdk.MultiStore, height int64) sdk.Context {
+	header := abci.Header{ChainID: chain.chainID, Height: height, Time: blockTime(height).Unix()}
+	return sdk.NewContext(ms, header, false, log.NewNopLogger())
+}
+
+// commit commits a block, empty unless the state was written
+func (chain *mockChain) commit() {
+	cid := chain.cms.Commit()
+	chain.appHashes[cid.Version] = cid.Hash
+}
+
+// commitBlocks commits empty blocks up to a height
+func (chain *mockChain) commitBlocks(height int64) {
+	for chain.cms.LastCommitID().Version < height {
+		chain.commit()
+	}
+}
+
+// createClient creates the client of the counterparty chain in a new block,
+// as it would be at genesis
+func (chain *mockChain) createClient(counterparty *mockChain) {
+	ctx := chain.context(chain.cms, chain.cms.LastCommitID().Version+1)
+	client := ibc.NewConsensusState(counterparty.chainID, 0, genesisTime.Unix(),
+		trustingPeriod, counterparty.validatorSet())
+	require.Nil(chain.t, chain.ibcm.CreateClient(ctx, client))
+	chain.commit()
+}
+
+// connect creates the clients of both chains on each other
+func connect(chainA, chainB *mockChain) {
+	chainA.createClient(chainB)
+	chainB.createClient(chainA)
+}
+
+// fund gives coins to an account in a new block
+func (chain *mockChain) fund(addr sdk.AccAddress, coins sdk.Coins) {
+	ctx := chain.context(chain.cms, chain.cms.LastCommitID().Version+1)
+	_, _, err := chain.ck.AddCoins(ctx, addr, coins)
+	require.Nil(chain.t, err)
+	chain.commit()
+}
+
+func (chain *mockChain) getCoins(addr sdk.AccAddress) sdk.Coins {
+	return chain.ck.GetCoins(chain.context(chain.cms, 0), addr)
+}
+
+func blockTime(height int64) time.Time {
+	return genesisTime.Add(time.Duration(height) * 5 * time.Second)
+}
+
+func newTestRelayer(t *testing.T, cdc *wire.Codec, chainA, chainB Chain, statePath string) *Relayer {
+	r, err := NewRelayer(cdc, chainA, chainB, newAddress(), statePath, log.NewNopLogger())
+	require.Nil(t, err)
+	return r
+}
+
+func TestRelayTransfer(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chain-a")
+	chainB := newMockChain(t, cdc, "chain-b")
+	connect(chainA, chainB)
+
+	dir, err := ioutil.TempDir("", "relayer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "state.json")
+
+	sender := newAddress()
+	recipient := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	chainA.fund(sender, mycoins)
+
+	r := newTestRelayer(t, cdc, chainA, chainB, statePath)
+	require.Nil(t, r.Step())
+
+	// the packet is relayed once it is provable
+	msg := ibc.NewIBCTransferMsg(sender, recipient, mycoins, chainA.chainID, chainB.chainID, 100, 0)
+	require.Nil(t, chainA.Broadcast([]sdk.Msg{msg}))
+	require.Nil(t, r.Step())
+	require.True(t, chainB.getCoins(recipient).IsZero())
+
+	chainA.commit()
+	require.Nil(t, r.Step())
+	vouchers := sdk.Coins{sdk.NewCoin(ibc.VoucherPrefix(ibc.TransferPort, chainA.chainID)+"mycoin", 10)}
+	require.Equal(t, vouchers, chainB.getCoins(recipient))
+
+	status := r.Status()
+	require.Equal(t, "chain-a", status.Paths[0].SrcChain)
+	require.Equal(t, int64(1), status.Paths[0].Sent)
+	require.Equal(t, int64(1), status.Paths[0].Received)
+	require.Equal(t, int64(0), status.Paths[0].Settled)
+
+	// the packet is acknowledged once its acknowledgement is provable
+	chainB.commit()
+	require.Nil(t, r.Step())
+	pending, _, err := chainA.QueryIBC(ibc.PendingPacketKey(chainB.chainID, 0), 0, false)
+	require.Nil(t, err)
+	require.Nil(t, pending)
+	require.Equal(t, int64(1), r.Status().Paths[0].Settled)
+
+	// the progress is persisted
+	state, err := LoadState(statePath)
+	require.Nil(t, err)
+	require.Equal(t, []PathState{
+		{SrcChain: "chain-a", DestChain: "chain-b", Settled: 1},
+		{SrcChain: "chain-b", DestChain: "chain-a", Settled: 0},
+	}, state.Paths)
+
+	restarted := newTestRelayer(t, cdc, chainA, chainB, statePath)
+	require.Equal(t, int64(1), restarted.Status().Paths[0].Settled)
+
+	// the vouchers are sent back in the other direction
+	back := ibc.NewIBCTransferMsg(recipient, sender, vouchers, chainB.chainID, chainA.chainID, 100, 0)
+	require.Nil(t, chainB.Broadcast([]sdk.Msg{back}))
+	chainB.commit()
+	require.Nil(t, restarted.Step())
+	require.Equal(t, mycoins, chainA.getCoins(sender))
+	require.True(t, chainB.getCoins(recipient).IsZero())
+
+	// the status is served as JSON
+	rec := httptest.NewRecorder()
+	restarted.StatusHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/status", nil))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var served Status
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, int64(1), served.Paths[1].Sent)
+	require.Equal(t, int64(1), served.Paths[1].Received)
+	require.Empty(t, served.LastError)
+}
+
+func TestRelayBatches(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chain-a")
+	chainB := newMockChain(t, cdc, "chain-b")
+	connect(chainA, chainB)
+
+	dir, err := ioutil.TempDir("", "relayer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sender := newAddress()
+	recipient := newAddress()
+	chainA.fund(sender, sdk.Coins{sdk.NewCoin("mycoin", 10)})
+
+	// a single transaction sends three packets
+	var msgs []sdk.Msg
+	for i := 0; i < 3; i++ {
+		msgs = append(msgs, ibc.NewIBCTransferMsg(sender, recipient, sdk.Coins{sdk.NewCoin("mycoin", 1)},
+			chainA.chainID, chainB.chainID, 100, 0))
+	}
+	require.Nil(t, chainA.Broadcast(msgs))
+	chainA.commit()
+
+	r := newTestRelayer(t, cdc, chainA, chainB, filepath.Join(dir, "state.json")).WithMaxMsgs(2)
+	require.Nil(t, r.RelayPackets(chainA, chainB))
+	require.Equal(t, int64(2), r.Status().Paths[0].Received)
+
+	// the header of chain-a at the same height was already verified
+	require.Nil(t, r.RelayPackets(chainA, chainB))
+	require.Equal(t, int64(3), r.Status().Paths[0].Received)
+
+	voucherDenom := ibc.VoucherPrefix(ibc.TransferPort, chainA.chainID) + "mycoin"
+	require.Equal(t, sdk.Coins{sdk.NewCoin(voucherDenom, 3)}, chainB.getCoins(recipient))
+
+	// the packets are settled in batches as well
+	chainB.commit()
+	require.Nil(t, r.SettlePackets(chainA, chainB))
+	require.Equal(t, int64(2), r.Status().Paths[0].Settled)
+	require.Nil(t, r.SettlePackets(chainA, chainB))
+	require.Equal(t, int64(3), r.Status().Paths[0].Settled)
+}
+
+func TestRelayTimeout(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chain-a")
+	chainB := newMockChain(t, cdc, "chain-b")
+	connect(chainA, chainB)
+
+	dir, err := ioutil.TempDir("", "relayer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sender := newAddress()
+	recipient := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	chainA.fund(sender, mycoins)
+	// the ibc store of chain-b must hold some state to prove the absence of
+	// the acknowledgement
+	chainB.fund(newAddress(), mycoins)
+
+	msg := ibc.NewIBCTransferMsg(sender, recipient, mycoins, chainA.chainID, chainB.chainID, 5, 0)
+	require.Nil(t, chainA.Broadcast([]sdk.Msg{msg}))
+	chainA.commit()
+	require.True(t, chainA.getCoins(sender).IsZero())
+
+	r := newTestRelayer(t, cdc, chainA, chainB, filepath.Join(dir, "state.json"))
+
+	// the packet can't be settled before it times out
+	require.Nil(t, r.SettlePackets(chainA, chainB))
+	require.Equal(t, int64(0), r.Status().Paths[0].Settled)
+
+	// the sender is refunded once chain-b reaches the timeout height
+	chainB.commitBlocks(5)
+	require.Nil(t, r.SettlePackets(chainA, chainB))
+	require.Equal(t, int64(1), r.Status().Paths[0].Settled)
+	require.Equal(t, mycoins, chainA.getCoins(sender))
+
+	// the packet is still received by chain-b, as timed out
+	require.Nil(t, r.RelayPackets(chainA, chainB))
+	require.True(t, chainB.getCoins(recipient).IsZero())
+	ackbz, _, err := chainB.QueryIBC(ibc.AcknowledgementKey(chainA.chainID, 0), 0, false)
+	require.Nil(t, err)
+	var ack ibc.Acknowledgement
+	require.Nil(t, cdc.UnmarshalBinary(ackbz, &ack))
+	require.False(t, ack.Success)
+
+	// and the relayer doesn't settle it twice
+	chainB.commit()
+	require.Nil(t, r.Step())
+	require.Equal(t, mycoins, chainA.getCoins(sender))
+}
+
+func TestRelayError(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chain-a")
+	chainB := newMockChain(t, cdc, "chain-b")
+	connect(chainA, chainB)
+
+	dir, err := ioutil.TempDir("", "relayer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewRelayer(cdc, chainA, chainA, newAddress(), filepath.Join(dir, "state.json"), log.NewNopLogger())
+	require.NotNil(t, err)
+
+	sender := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	chainA.fund(sender, mycoins)
+	msg := ibc.NewIBCTransferMsg(sender, newAddress(), mycoins, chainA.chainID, chainB.chainID, 100, 0)
+	require.Nil(t, chainA.Broadcast([]sdk.Msg{msg}))
+	chainA.commit()
+
+	r := newTestRelayer(t, cdc, chainA, chainB, filepath.Join(dir, "state.json"))
+	require.Nil(t, r.Step())
+	require.Equal(t, int64(1), r.Status().Paths[0].Received)
+
+	// the headers of a chain with the same ID but other validators are
+	// rejected by the client of chain-a
+	forged := newMockChain(t, cdc, chainA.chainID)
+	forged.fund(sender, mycoins)
+	var msgs []sdk.Msg
+	for i := 0; i < 2; i++ {
+		msgs = append(msgs, ibc.NewIBCTransferMsg(sender, sender, sdk.Coins{sdk.NewCoin("mycoin", 1)},
+			forged.chainID, chainB.chainID, 100, 0))
+	}
+	require.Nil(t, forged.Broadcast(msgs))
+	forged.commitBlocks(10)
+
+	r.chains[0] = forged
+	err = r.Step()
+	require.NotNil(t, err)
+	require.Equal(t, int64(1), r.Status().Paths[0].Received)
+
+	// the packets of a chain without a client are never received
+	unknown := newMockChain(t, cdc, "chain-c")
+	unknown.fund(sender, mycoins)
+	msg = ibc.NewIBCTransferMsg(sender, sender, mycoins, unknown.chainID, chainB.chainID, 100, 0)
+	require.Nil(t, unknown.Broadcast([]sdk.Msg{msg}))
+	unknown.commit()
+
+	r.chains[0] = unknown
+	require.NotNil(t, r.Step())
+	require.True(t, chainB.getCoins(sender).IsZero())
+
+	r.setError(err)
+	r.setError(err)
+	status := r.Status()
+	require.Equal(t, 2, status.Retries)
+	require.Equal(t, err.Error(), status.LastError)
+
+	r.setError(nil)
+	require.Equal(t, 0, r.Status().Retries)
+	require.Empty(t, r.Status().LastError)
+}
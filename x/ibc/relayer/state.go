@@ -0,0 +1,68 @@
+package relayer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// PathState is the progress of the relayer on the packets sent from a chain
+// to another. The packets below Settled were acknowledged or timed out on the
+// source chain.
+type PathState struct {
+	SrcChain  string `json:"src_chain"`
+	DestChain string `json:"dest_chain"`
+	Settled   int64  `json:"settled"`
+}
+
+// State is the progress of the relayer persisted to disk, so that a restarted
+// relayer doesn't go over the settled packets again
+type State struct {
+	Paths []PathState `json:"paths"`
+}
+
+// LoadState reads the state of the relayer from a file, an empty state is
+// returned if the file doesn't exist
+func LoadState(path string) (State, error) {
+	var state State
+	bz, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(bz, &state)
+	return state, err
+}
+
+// Save writes the state of the relayer to a file, the previous state is
+// replaced atomically
+func (state State) Save(path string) error {
+	bz, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	err = ioutil.WriteFile(tmp, bz, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// path returns the progress on the packets sent from a chain to another
+func (state *State) path(srcChain, destChain string) *PathState {
+	for i := range state.Paths {
+		if state.Paths[i].SrcChain == srcChain && state.Paths[i].DestChain == destChain {
+			return &state.Paths[i]
+		}
+	}
+	state.Paths = append(state.Paths, PathState{SrcChain: srcChain, DestChain: destChain})
+	return &state.Paths[len(state.Paths)-1]
+}
@@ -0,0 +1,78 @@
+package relayer
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// PathStatus is the status of the packets sent from a chain to another
+type PathStatus struct {
+	SrcChain  string    `json:"src_chain"`
+	DestChain string    `json:"dest_chain"`
+	Sent      int64     `json:"sent"`
+	Received  int64     `json:"received"`
+	Settled   int64     `json:"settled"`
+	LastRelay time.Time `json:"last_relay"`
+}
+
+// Status is the status of the relayer
+type Status struct {
+	Paths     []PathStatus `json:"paths"`
+	LastError string       `json:"last_error"`
+	Retries   int          `json:"retries"`
+}
+
+// Status returns the status of the relayer
+func (r *Relayer) Status() Status {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	status := r.status
+	status.Paths = append([]PathStatus(nil), r.status.Paths...)
+	return status
+}
+
+// StatusHandler returns the handler serving the status of the relayer as JSON
+func (r *Relayer) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		bz, err := json.MarshalIndent(r.Status(), "", "  ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bz)
+	})
+}
+
+// ServeStatus serves the status of the relayer at /status on an address
+func (r *Relayer) ServeStatus(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/status", r.StatusHandler())
+	return http.ListenAndServe(addr, mux)
+}
+
+func (r *Relayer) updateStatus(src, dest Chain, update func(*PathStatus)) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for i := range r.status.Paths {
+		if r.status.Paths[i].SrcChain == src.ChainID() && r.status.Paths[i].DestChain == dest.ChainID() {
+			update(&r.status.Paths[i])
+		}
+	}
+}
+
+// setError records the error of the last step, the retries count the
+// consecutive failed steps
+func (r *Relayer) setError(err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err == nil {
+		r.status.LastError = ""
+		r.status.Retries = 0
+		return
+	}
+	r.status.LastError = err.Error()
+	r.status.Retries++
+}
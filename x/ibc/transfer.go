@@ -0,0 +1,258 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	// ModuleName is the name of the module account minting and burning the
+	// vouchers of tokens received from other chains
+	ModuleName = "ibc"
+
+	// TransferPort is the port of the token transfers
+	TransferPort = "transfer"
+)
+
+// EscrowAddress returns the address of the account escrowing the native
+// tokens sent to a chain through a port, until they are sent back
+func EscrowAddress(port, chainID string) sdk.AccAddress {
+	return auth.NewModuleAddress(fmt.Sprintf("%s/%s/%s", ModuleName, port, chainID))
+}
+
+// VoucherPrefix returns the prefix of the denominations of the vouchers of
+// tokens received from a chain through a port
+func VoucherPrefix(port, chainID string) string {
+	return fmt.Sprintf("%s/%s/", port, chainID)
+}
+
+// DenomTrace is the trace of a voucher denomination, the ports and chains
+// its tokens were received through, most recent first, and its denomination
+// on the chain it originates from
+type DenomTrace struct {
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+// NewDenomTrace returns the trace of a voucher denomination
+func NewDenomTrace(denom string) DenomTrace {
+	i := strings.LastIndex(denom, "/")
+	if i == -1 {
+		return DenomTrace{BaseDenom: denom}
+	}
+	return DenomTrace{
+		Path:      denom[:i],
+		BaseDenom: denom[i+1:],
+	}
+}
+
+// Denom returns the voucher denomination of the trace
+func (dt DenomTrace) Denom() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return dt.Path + "/" + dt.BaseDenom
+}
+
+// TransferPacketData is the payload of the packets of the transfer port
+type TransferPacketData struct {
+	SrcAddr  sdk.AccAddress
+	DestAddr sdk.AccAddress
+	Coins    sdk.Coins
+}
+
+// GetBytes returns the payload of a transfer packet
+func (data TransferPacketData) GetBytes() []byte {
+	bz, err := msgCdc.MarshalBinary(data)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// TransferModule is the module bound to the transfer port, it escrows and
+// releases native tokens, and mints and burns the vouchers of tokens received
+// from other chains
+type TransferModule struct {
+	ibcm Mapper
+	ck   bank.Keeper
+	sk   bank.SupplyKeeper
+}
+
+var _ Module = TransferModule{}
+
+// NewTransferModule returns the transfer module, the supply keeper mints and
+// burns vouchers with the ModuleName module account which must have the
+// Minter and Burner permissions
+func NewTransferModule(ibcm Mapper, ck bank.Keeper, sk bank.SupplyKeeper) TransferModule {
+	return TransferModule{
+		ibcm: ibcm,
+		ck:   ck,
+		sk:   sk,
+	}
+}
+
+// SendTransfer takes the coins of a transfer and sends its packet from the
+// TransferPort, it is called by the handler with the module bound to it
+func (tm TransferModule) SendTransfer(ctx sdk.Context, msg IBCTransferMsg) sdk.Error {
+	err := sendTransfer(ctx, tm.ck, tm.sk, msg.DestChain, msg.Data())
+	if err != nil {
+		return err
+	}
+	return tm.ibcm.postIBCPacket(ctx, msg.Packet())
+}
+
+// OnRecvPacket implements Module
+func (tm TransferModule) OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	data, err := tm.getData(packet)
+	if err != nil {
+		return err
+	}
+	return receiveTransfer(ctx, tm.ibcm, tm.ck, tm.sk, packet.SrcChain, packet.DestChain, data)
+}
+
+// OnAcknowledge implements Module, the sender of a failed transfer is
+// refunded
+func (tm TransferModule) OnAcknowledge(ctx sdk.Context, packet IBCPacket, ack Acknowledgement) sdk.Error {
+	if ack.Success {
+		return nil
+	}
+	data, err := tm.getData(packet)
+	if err != nil {
+		return err
+	}
+	return refundTransfer(ctx, tm.ck, tm.sk, packet.DestChain, data)
+}
+
+// OnTimeout implements Module, the sender of the transfer is refunded
+func (tm TransferModule) OnTimeout(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	data, err := tm.getData(packet)
+	if err != nil {
+		return err
+	}
+	return refundTransfer(ctx, tm.ck, tm.sk, packet.DestChain, data)
+}
+
+func (tm TransferModule) getData(packet IBCPacket) (data TransferPacketData, err sdk.Error) {
+	if decodeErr := msgCdc.UnmarshalBinary(packet.Payload, &data); decodeErr != nil {
+		return data, ErrInvalidPayload(tm.ibcm.codespace, decodeErr.Error())
+	}
+	if len(data.DestAddr) == 0 || !data.Coins.IsValid() {
+		return data, ErrInvalidPayload(tm.ibcm.codespace, "transfer must have a recipient and valid coins")
+	}
+	return data, nil
+}
+
+// sendTransfer takes the coins of a transfer from the sender. Vouchers of
+// tokens received from the destination chain are burned, as their tokens are
+// released from escrow there, other coins are escrowed for the destination
+// chain.
+func sendTransfer(ctx sdk.Context, ck bank.Keeper, sk bank.SupplyKeeper, destChain string,
+	data TransferPacketData) sdk.Error {
+
+	vouchers, escrowed := splitVouchers(data.Coins, destChain)
+
+	if len(escrowed) != 0 {
+		_, err := ck.SendCoins(ctx, data.SrcAddr, EscrowAddress(TransferPort, destChain), escrowed)
+		if err != nil {
+			return err
+		}
+	}
+	if len(vouchers) != 0 {
+		_, err := ck.SendCoinsFromAccountToModule(ctx, data.SrcAddr, ModuleName, vouchers)
+		if err != nil {
+			return err
+		}
+		err = sk.BurnCoins(ctx, ModuleName, vouchers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveTransfer gives the coins of a transfer to the recipient. Coins which
+// were escrowed for the source chain, received as vouchers prefixed by this
+// chain, are released from escrow, vouchers are minted for the other coins.
+func receiveTransfer(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, sk bank.SupplyKeeper, srcChain string,
+	destChain string, data TransferPacketData) sdk.Error {
+
+	prefix := VoucherPrefix(TransferPort, destChain)
+
+	var released, vouchers sdk.Coins
+	for _, coin := range data.Coins {
+		if strings.HasPrefix(coin.Denom, prefix) {
+			released = append(released, sdk.NewIntCoin(strings.TrimPrefix(coin.Denom, prefix), coin.Amount))
+		} else {
+			denom := VoucherPrefix(TransferPort, srcChain) + coin.Denom
+			vouchers = append(vouchers, sdk.NewIntCoin(denom, coin.Amount))
+		}
+	}
+
+	if len(released) != 0 {
+		_, err := ck.SendCoins(ctx, EscrowAddress(TransferPort, srcChain), data.DestAddr, released.Sort())
+		if err != nil {
+			return err
+		}
+	}
+	if len(vouchers) != 0 {
+		vouchers = vouchers.Sort()
+		err := sk.MintCoins(ctx, ModuleName, vouchers)
+		if err != nil {
+			return err
+		}
+		_, err = ck.SendCoinsFromModuleToAccount(ctx, ModuleName, data.DestAddr, vouchers)
+		if err != nil {
+			return err
+		}
+		for _, voucher := range vouchers {
+			ibcm.SetDenomTrace(ctx, NewDenomTrace(voucher.Denom))
+		}
+	}
+	return nil
+}
+
+// refundTransfer gives the coins of a transfer which failed or timed out back
+// to the sender. Escrowed coins are released, burned vouchers are minted
+// again.
+func refundTransfer(ctx sdk.Context, ck bank.Keeper, sk bank.SupplyKeeper, destChain string,
+	data TransferPacketData) sdk.Error {
+
+	vouchers, escrowed := splitVouchers(data.Coins, destChain)
+
+	if len(escrowed) != 0 {
+		_, err := ck.SendCoins(ctx, EscrowAddress(TransferPort, destChain), data.SrcAddr, escrowed)
+		if err != nil {
+			return err
+		}
+	}
+	if len(vouchers) != 0 {
+		err := sk.MintCoins(ctx, ModuleName, vouchers)
+		if err != nil {
+			return err
+		}
+		_, err = ck.SendCoinsFromModuleToAccount(ctx, ModuleName, data.SrcAddr, vouchers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splits coins sent to a chain between the vouchers of tokens received from
+// it and the other coins
+func splitVouchers(coins sdk.Coins, destChain string) (vouchers, others sdk.Coins) {
+	prefix := VoucherPrefix(TransferPort, destChain)
+	for _, coin := range coins {
+		if strings.HasPrefix(coin.Denom, prefix) {
+			vouchers = append(vouchers, coin)
+		} else {
+			others = append(others, coin)
+		}
+	}
+	return vouchers, others
+}
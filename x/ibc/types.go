@@ -2,7 +2,11 @@ package ibc
 
 import (
 	"encoding/json"
+	"time"
 
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 )
@@ -13,34 +17,54 @@ var (
 
 func init() {
 	msgCdc = wire.NewCodec()
+	wire.RegisterCrypto(msgCdc)
 }
 
 // ------------------------------
 // IBCPacket
 
+// DefaultPacketLifetime is the time after which the packets sent by the
+// clients time out when no timeout is given
+const DefaultPacketLifetime = 10 * time.Minute
+
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains. The packet is sent by the module bound to the source port on
+// the source chain and delivered to the module bound to the destination port
+// on the destination chain, which alone interpret its payload. The packet
+// times out once the destination chain reaches the timeout height or a block
+// time, in unix seconds, of the timeout timestamp, a zero timeout is disabled.
 type IBCPacket struct {
-	SrcAddr   sdk.AccAddress
-	DestAddr  sdk.AccAddress
-	Coins     sdk.Coins
-	SrcChain  string
-	DestChain string
+	SrcPort          string
+	DestPort         string
+	SrcChain         string
+	DestChain        string
+	Payload          []byte
+	TimeoutHeight    int64
+	TimeoutTimestamp int64
 }
 
-func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+func NewIBCPacket(srcPort string, destPort string, srcChain string, destChain string,
+	payload []byte, timeoutHeight int64, timeoutTimestamp int64) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		SrcPort:          srcPort,
+		DestPort:         destPort,
+		SrcChain:         srcChain,
+		DestChain:        destChain,
+		Payload:          payload,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
 	}
 }
 
+// TimedOut returns whether the packet timed out at a block of the destination
+// chain with the given height and time
+func (p IBCPacket) TimedOut(height int64, time int64) bool {
+	return (p.TimeoutHeight != 0 && height >= p.TimeoutHeight) ||
+		(p.TimeoutTimestamp != 0 && time >= p.TimeoutTimestamp)
+}
+
 //nolint
 func (p IBCPacket) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(p)
@@ -55,19 +79,74 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if p.SrcChain == p.DestChain {
 		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
 	}
-	if !p.Coins.IsValid() {
-		return sdk.ErrInvalidCoins("")
+	if !isValidPort(p.SrcPort) {
+		return ErrInvalidPort(DefaultCodespace, p.SrcPort).TraceSDK("")
+	}
+	if !isValidPort(p.DestPort) {
+		return ErrInvalidPort(DefaultCodespace, p.DestPort).TraceSDK("")
+	}
+	if len(p.Payload) == 0 {
+		return ErrInvalidPayload(DefaultCodespace, "payload cannot be empty").TraceSDK("")
+	}
+	if p.TimeoutHeight < 0 || p.TimeoutTimestamp < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "timeouts cannot be negative").TraceSDK("")
+	}
+	if p.TimeoutHeight == 0 && p.TimeoutTimestamp == 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "packet must time out at a height or a timestamp").TraceSDK("")
 	}
 	return nil
 }
 
+// ------------------------------
+// Acknowledgement
+
+// Acknowledgement is written by the destination chain for every packet it
+// receives, with the error of the packets which failed or timed out
+type Acknowledgement struct {
+	Success bool
+	Error   string
+}
+
+// NewSuccessAcknowledgement returns the acknowledgement of a packet which was
+// processed
+func NewSuccessAcknowledgement() Acknowledgement {
+	return Acknowledgement{Success: true}
+}
+
+// NewErrorAcknowledgement returns the acknowledgement of a packet which
+// failed or timed out
+func NewErrorAcknowledgement(err sdk.Error) Acknowledgement {
+	return Acknowledgement{Error: err.ABCILog()}
+}
+
 // ----------------------------------
 // IBCTransferMsg
 
 // nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
-// IBCTransferMsg defines how another module can send an IBCPacket.
+// IBCTransferMsg defines how a user sends coins to an address of another
+// chain, through the transfer port.
 type IBCTransferMsg struct {
-	IBCPacket
+	SrcAddr          sdk.AccAddress
+	DestAddr         sdk.AccAddress
+	Coins            sdk.Coins
+	SrcChain         string
+	DestChain        string
+	TimeoutHeight    int64
+	TimeoutTimestamp int64
+}
+
+func NewIBCTransferMsg(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
+	srcChain string, destChain string, timeoutHeight int64, timeoutTimestamp int64) IBCTransferMsg {
+
+	return IBCTransferMsg{
+		SrcAddr:          srcAddr,
+		DestAddr:         destAddr,
+		Coins:            coins,
+		SrcChain:         srcChain,
+		DestChain:        destChain,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
+	}
 }
 
 // nolint
@@ -78,12 +157,40 @@ func (msg IBCTransferMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddres
 
 // get the sign bytes for ibc transfer message
 func (msg IBCTransferMsg) GetSignBytes() []byte {
-	return msg.IBCPacket.GetSignBytes()
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
 }
 
 // validate ibc transfer message
 func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
-	return msg.IBCPacket.ValidateBasic()
+	if len(msg.SrcAddr) == 0 {
+		return sdk.ErrInvalidAddress(msg.SrcAddr.String())
+	}
+	if len(msg.DestAddr) == 0 {
+		return sdk.ErrInvalidAddress(msg.DestAddr.String())
+	}
+	if !msg.Coins.IsValid() {
+		return sdk.ErrInvalidCoins("")
+	}
+	return msg.Packet().ValidateBasic()
+}
+
+// Data returns the payload of the transfer packet
+func (msg IBCTransferMsg) Data() TransferPacketData {
+	return TransferPacketData{
+		SrcAddr:  msg.SrcAddr,
+		DestAddr: msg.DestAddr,
+		Coins:    msg.Coins,
+	}
+}
+
+// Packet returns the packet sending the transfer through the transfer port
+func (msg IBCTransferMsg) Packet() IBCPacket {
+	return NewIBCPacket(TransferPort, TransferPort, msg.SrcChain, msg.DestChain,
+		msg.Data().GetBytes(), msg.TimeoutHeight, msg.TimeoutTimestamp)
 }
 
 // ----------------------------------
@@ -91,16 +198,19 @@ func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. The proof proves the packet under its egress key
+// in the ibc store of the source chain, against the app hash of the verified
+// header of the source chain at the given height.
 type IBCReceiveMsg struct {
 	IBCPacket
 	Relayer  sdk.AccAddress
 	Sequence int64
+	Height   int64
+	Proof    store.MultiStoreProof
 }
 
 // nolint
-func (msg IBCReceiveMsg) Type() string             { return "ibc" }
-func (msg IBCReceiveMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) Type() string { return "ibc" }
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
@@ -111,13 +221,165 @@ func (msg IBCReceiveMsg) GetSignBytes() []byte {
 		IBCPacket json.RawMessage
 		Relayer   sdk.AccAddress
 		Sequence  int64
+		Height    int64
+		Proof     store.MultiStoreProof
 	}{
 		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
 		Relayer:   msg.Relayer,
 		Sequence:  msg.Sequence,
+		Height:    msg.Height,
+		Proof:     msg.Proof,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return sdk.MustSortJSON(b)
 }
+
+// validate ibc receive message
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	if msg.Height <= 0 {
+		return ErrUnknownHeader(DefaultCodespace, msg.SrcChain, msg.Height).TraceSDK("")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// ----------------------------------
+// IBCUpdateClientMsg
+
+// IBCUpdateClientMsg defines the message that a relayer uses to update the
+// light client of a counterparty chain with a header, the commit signing it
+// and the validator set committed to by the header. The client of the chain
+// must have been created at genesis.
+type IBCUpdateClientMsg struct {
+	Header     tmtypes.Header
+	Commit     tmtypes.Commit
+	Validators []*tmtypes.Validator
+	Relayer    sdk.AccAddress
+}
+
+// nolint
+func (msg IBCUpdateClientMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCUpdateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc update client message
+func (msg IBCUpdateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc update client message
+func (msg IBCUpdateClientMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	if msg.Header.ChainID == "" || msg.Header.Height <= 0 {
+		return ErrInvalidHeader(DefaultCodespace, "header must have a chain ID and a positive height").TraceSDK("")
+	}
+	if len(msg.Validators) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "validator set cannot be empty").TraceSDK("")
+	}
+	if len(msg.Commit.Precommits) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "commit cannot be empty").TraceSDK("")
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCAcknowledgementMsg
+
+// IBCAcknowledgementMsg defines the message that a relayer uses to post the
+// acknowledgement of a packet back to its source chain. The proof proves the
+// acknowledgement under its key in the ibc store of the destination chain,
+// against the app hash of the verified header of the destination chain at the
+// given height. The sender of a packet which failed is refunded.
+type IBCAcknowledgementMsg struct {
+	DestChain       string
+	Sequence        int64
+	Acknowledgement Acknowledgement
+	Height          int64
+	Proof           store.MultiStoreProof
+	Relayer         sdk.AccAddress
+}
+
+// nolint
+func (msg IBCAcknowledgementMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCAcknowledgementMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	if msg.DestChain == "" || msg.Sequence < 0 {
+		return ErrUnknownPacket(DefaultCodespace, msg.DestChain, msg.Sequence).TraceSDK("")
+	}
+	if msg.Height <= 0 {
+		return ErrUnknownHeader(DefaultCodespace, msg.DestChain, msg.Height).TraceSDK("")
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCTimeoutMsg
+
+// IBCTimeoutMsg defines the message that a relayer uses to time out a packet
+// on its source chain. The proof proves the absence of the acknowledgement of
+// the packet in the ibc store of the destination chain, against the app hash
+// of the verified header of the destination chain at the given height, at
+// which the packet timed out. The sender of the packet is refunded.
+type IBCTimeoutMsg struct {
+	DestChain string
+	Sequence  int64
+	Height    int64
+	Proof     store.MultiStoreProof
+	Relayer   sdk.AccAddress
+}
+
+// nolint
+func (msg IBCTimeoutMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCTimeoutMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc timeout message
+func (msg IBCTimeoutMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc timeout message
+func (msg IBCTimeoutMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	if msg.DestChain == "" || msg.Sequence < 0 {
+		return ErrUnknownPacket(DefaultCodespace, msg.DestChain, msg.Sequence).TraceSDK("")
+	}
+	if msg.Height <= 0 {
+		return ErrUnknownHeader(DefaultCodespace, msg.DestChain, msg.Height).TraceSDK("")
+	}
+	return nil
+}
@@ -5,6 +5,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -12,12 +16,23 @@ import (
 // IBCPacket Tests
 
 func TestIBCPacketValidation(t *testing.T) {
+	payload := []byte("payload")
+
 	cases := []struct {
 		valid  bool
 		packet IBCPacket
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{true, NewIBCPacket("transfer", "oracle", "source-chain", "dest-chain", payload, 10, 0)},
+		{true, NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", payload, 0, 1000)},
+		{false, NewIBCPacket("", "transfer", "source-chain", "dest-chain", payload, 10, 0)},
+		{false, NewIBCPacket("transfer", "Transfer", "source-chain", "dest-chain", payload, 10, 0)},
+		{false, NewIBCPacket("transfer", "trans/fer", "source-chain", "dest-chain", payload, 10, 0)},
+		{false, NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", nil, 10, 0)},
+		{false, NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", payload, 0, 0)},
+		{false, NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", payload, -1, 1000)},
+		{false, NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", payload, 10, -1)},
 	}
 
 	for i, tc := range cases {
@@ -30,26 +45,61 @@ func TestIBCPacketValidation(t *testing.T) {
 	}
 }
 
+func TestIBCPacketTimedOut(t *testing.T) {
+	cases := []struct {
+		timeoutHeight, timeoutTimestamp int64
+		height, time                    int64
+		timedOut                        bool
+	}{
+		{10, 0, 9, 2000, false},
+		{10, 0, 10, 0, true},
+		{0, 1000, 100, 999, false},
+		{0, 1000, 1, 1000, true},
+		{10, 1000, 9, 999, false},
+		{10, 1000, 10, 999, true},
+		{10, 1000, 9, 1001, true},
+	}
+
+	for i, tc := range cases {
+		packet := NewIBCPacket("transfer", "transfer", "source-chain", "dest-chain", []byte("payload"),
+			tc.timeoutHeight, tc.timeoutTimestamp)
+		require.Equal(t, tc.timedOut, packet.TimedOut(tc.height, tc.time), "%d", i)
+	}
+}
+
 // -------------------------------
 // IBCTransferMsg Tests
 
 func TestIBCTransferMsg(t *testing.T) {
-	packet := constructIBCPacket(true)
-	msg := IBCTransferMsg{packet}
+	srcAddr := sdk.AccAddress([]byte("source"))
+	destAddr := sdk.AccAddress([]byte("destination"))
+	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
+	msg := NewIBCTransferMsg(srcAddr, destAddr, coins, "source-chain", "dest-chain", 100, 0)
 
 	require.Equal(t, msg.Type(), "ibc")
+
+	// the packet is sent through the transfer port
+	packet := msg.Packet()
+	require.Equal(t, NewIBCPacket(TransferPort, TransferPort, "source-chain", "dest-chain",
+		TransferPacketData{srcAddr, destAddr, coins}.GetBytes(), 100, 0), packet)
+	require.Nil(t, packet.ValidateBasic())
 }
 
 func TestIBCTransferMsgValidation(t *testing.T) {
-	validPacket := constructIBCPacket(true)
-	invalidPacket := constructIBCPacket(false)
+	srcAddr := sdk.AccAddress([]byte("source"))
+	destAddr := sdk.AccAddress([]byte("destination"))
+	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
 
 	cases := []struct {
 		valid bool
 		msg   IBCTransferMsg
 	}{
-		{true, IBCTransferMsg{validPacket}},
-		{false, IBCTransferMsg{invalidPacket}},
+		{true, NewIBCTransferMsg(srcAddr, destAddr, coins, "source-chain", "dest-chain", 100, 0)},
+		{false, NewIBCTransferMsg(srcAddr, destAddr, coins, "source-chain", "source-chain", 100, 0)},
+		{false, NewIBCTransferMsg(nil, destAddr, coins, "source-chain", "dest-chain", 100, 0)},
+		{false, NewIBCTransferMsg(srcAddr, nil, coins, "source-chain", "dest-chain", 100, 0)},
+		{false, NewIBCTransferMsg(srcAddr, destAddr, sdk.Coins{sdk.NewCoin("atom", -10)}, "source-chain", "dest-chain", 100, 0)},
+		{false, NewIBCTransferMsg(srcAddr, destAddr, coins, "source-chain", "dest-chain", 0, 0)},
 	}
 
 	for i, tc := range cases {
@@ -67,7 +117,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0}
+	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0, 1, store.MultiStoreProof{}}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
@@ -80,8 +130,10 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 		valid bool
 		msg   IBCReceiveMsg
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0}},
+		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 1, store.MultiStoreProof{}}},
+		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0, 1, store.MultiStoreProof{}}},
+		{false, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 0, store.MultiStoreProof{}}},
+		{false, IBCReceiveMsg{validPacket, nil, 0, 1, store.MultiStoreProof{}}},
 	}
 
 	for i, tc := range cases {
@@ -94,18 +146,105 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 	}
 }
 
+// -------------------------------
+// IBCUpdateClientMsg Tests
+
+func TestIBCUpdateClientMsgValidation(t *testing.T) {
+	relayer := sdk.AccAddress([]byte("relayer"))
+	header := tmtypes.Header{ChainID: "source-chain", Height: 1}
+	validators := []*tmtypes.Validator{tmtypes.NewValidator(crypto.GenPrivKeyEd25519().PubKey(), 1)}
+	commit := tmtypes.Commit{Precommits: []*tmtypes.Vote{nil}}
+
+	cases := []struct {
+		valid bool
+		msg   IBCUpdateClientMsg
+	}{
+		{true, IBCUpdateClientMsg{header, commit, validators, relayer}},
+		{false, IBCUpdateClientMsg{header, commit, validators, nil}},
+		{false, IBCUpdateClientMsg{tmtypes.Header{Height: 1}, commit, validators, relayer}},
+		{false, IBCUpdateClientMsg{tmtypes.Header{ChainID: "source-chain"}, commit, validators, relayer}},
+		{false, IBCUpdateClientMsg{header, commit, nil, relayer}},
+		{false, IBCUpdateClientMsg{header, tmtypes.Commit{}, validators, relayer}},
+	}
+
+	for i, tc := range cases {
+		require.Equal(t, "ibc", tc.msg.Type())
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+// -------------------------------
+// IBCAcknowledgementMsg Tests
+
+func TestIBCAcknowledgementMsgValidation(t *testing.T) {
+	relayer := sdk.AccAddress([]byte("relayer"))
+	ack := NewSuccessAcknowledgement()
+
+	cases := []struct {
+		valid bool
+		msg   IBCAcknowledgementMsg
+	}{
+		{true, IBCAcknowledgementMsg{"dest-chain", 0, ack, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCAcknowledgementMsg{"dest-chain", 0, ack, 1, store.MultiStoreProof{}, nil}},
+		{false, IBCAcknowledgementMsg{"", 0, ack, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCAcknowledgementMsg{"dest-chain", -1, ack, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCAcknowledgementMsg{"dest-chain", 0, ack, 0, store.MultiStoreProof{}, relayer}},
+	}
+
+	for i, tc := range cases {
+		require.Equal(t, "ibc", tc.msg.Type())
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+// -------------------------------
+// IBCTimeoutMsg Tests
+
+func TestIBCTimeoutMsgValidation(t *testing.T) {
+	relayer := sdk.AccAddress([]byte("relayer"))
+
+	cases := []struct {
+		valid bool
+		msg   IBCTimeoutMsg
+	}{
+		{true, IBCTimeoutMsg{"dest-chain", 0, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCTimeoutMsg{"dest-chain", 0, 1, store.MultiStoreProof{}, nil}},
+		{false, IBCTimeoutMsg{"", 0, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCTimeoutMsg{"dest-chain", -1, 1, store.MultiStoreProof{}, relayer}},
+		{false, IBCTimeoutMsg{"dest-chain", 0, 0, store.MultiStoreProof{}, relayer}},
+	}
+
+	for i, tc := range cases {
+		require.Equal(t, "ibc", tc.msg.Type())
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
 // -------------------------------
 // Helpers
 
 func constructIBCPacket(valid bool) IBCPacket {
-	srcAddr := sdk.AccAddress([]byte("source"))
-	destAddr := sdk.AccAddress([]byte("destination"))
-	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
+	payload := []byte("payload")
 	srcChain := "source-chain"
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCPacket("transfer", "transfer", srcChain, destChain, payload, 100, 0)
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCPacket("transfer", "transfer", srcChain, srcChain, payload, 100, 0)
 }
@@ -0,0 +1,18 @@
+package mint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Called every block, update the inflation rate and the annual provisions
+// and mint the provisions of the block
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	minter := k.GetMinter(ctx)
+	params := k.GetParams(ctx)
+
+	minter.Inflation = minter.NextInflationRate(params, k.sk.BondedRatio(ctx))
+	minter.AnnualProvisions = minter.NextAnnualProvisions(params, k.sk.StakingTokenSupply(ctx))
+	k.SetMinter(ctx, minter)
+
+	k.MintCoins(ctx, minter.BlockProvision(params))
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+)
+
+// GetCmdQueryInflation returns a command to query the current annual
+// inflation rate
+func GetCmdQueryInflation(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "inflation",
+		Short: "Query the current annual inflation rate",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minter, err := queryMinter(storeName, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Println(minter.Inflation.FloatString())
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryAnnualProvisions returns a command to query the current annual
+// provisions
+func GetCmdQueryAnnualProvisions(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "annual-provisions",
+		Short: "Query the current annual provisions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minter, err := queryMinter(storeName, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Println(minter.AnnualProvisions.FloatString())
+			return nil
+		},
+	}
+}
+
+// query and decode the minter
+func queryMinter(storeName string, cdc *wire.Codec) (minter mint.Minter, err error) {
+	ctx := context.NewCoreContextFromViper()
+	res, err := ctx.QueryStore(mint.MinterKey, storeName)
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		err = fmt.Errorf("no minter found")
+		return
+	}
+	err = cdc.UnmarshalBinary(res, &minter)
+	return
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+)
+
+const mintStoreName = "mint"
+
+// RegisterRoutes registers mint-related REST handlers to a router
+func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc(
+		"/mint/inflation",
+		minterHandlerFn(ctx, cdc, func(minter mint.Minter) interface{} { return minter.Inflation }),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/mint/annual-provisions",
+		minterHandlerFn(ctx, cdc, func(minter mint.Minter) interface{} { return minter.AnnualProvisions }),
+	).Methods("GET")
+}
+
+// http request handler to query a field of the minter
+func minterHandlerFn(ctx context.CoreContext, cdc *wire.Codec, field func(mint.Minter) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := ctx.QueryStore(mint.MinterKey, mintStoreName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query minter. Error: %s", err.Error())))
+			return
+		}
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var minter mint.Minter
+		err = cdc.UnmarshalBinary(res, &minter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't parse query result. Result: %s. Error: %s", res, err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(field(minter))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -0,0 +1,51 @@
+package mint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all mint state that must be provided at genesis
+type GenesisState struct {
+	Minter Minter `json:"minter"`
+	Params Params `json:"params"`
+}
+
+func NewGenesisState(minter Minter, params Params) GenesisState {
+	return GenesisState{
+		Minter: minter,
+		Params: params,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Minter: DefaultInitialMinter(),
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis - store the minter and params
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) error {
+	err := validateGenesis(data)
+	if err != nil {
+		return err
+	}
+	k.SetMinter(ctx, data.Minter)
+	k.SetParams(ctx, data.Params)
+	return nil
+}
+
+// WriteGenesis - output the minter and params
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return NewGenesisState(k.GetMinter(ctx), k.GetParams(ctx))
+}
+
+// validate the minter and params of a genesis state
+func validateGenesis(data GenesisState) error {
+	err := validateParams(data.Params)
+	if err != nil {
+		return err
+	}
+	return validateMinter(data.Minter)
+}
@@ -0,0 +1,105 @@
+package mint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// ModuleName is the name of the module account which mints the provisions
+const ModuleName = "mint"
+
+// nolint
+var (
+	MinterKey = []byte{0x00} // key for the minter
+	ParamsKey = []byte{0x01} // key for the mint params
+)
+
+// expected stake keeper
+type StakeKeeper interface {
+	BondedRatio(ctx sdk.Context) sdk.Rat
+	StakingTokenSupply(ctx sdk.Context) sdk.Rat
+	InflateSupply(ctx sdk.Context, newTokens sdk.Int)
+}
+
+// keeper of the mint store
+type Keeper struct {
+	storeKey     sdk.StoreKey
+	cdc          *wire.Codec
+	sk           StakeKeeper
+	coinKeeper   bank.Keeper
+	supplyKeeper bank.SupplyKeeper
+}
+
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, sk StakeKeeper, ck bank.Keeper, supplyKeeper bank.SupplyKeeper) Keeper {
+	keeper := Keeper{
+		storeKey:     key,
+		cdc:          cdc,
+		sk:           sk,
+		coinKeeper:   ck,
+		supplyKeeper: supplyKeeper,
+	}
+	return keeper
+}
+
+//______________________________________________________________________
+
+// get the minter
+func (k Keeper) GetMinter(ctx sdk.Context) (minter Minter) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(MinterKey)
+	if b == nil {
+		panic("Stored minter should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &minter)
+	return
+}
+
+// set the minter
+func (k Keeper) SetMinter(ctx sdk.Context, minter Minter) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(minter)
+	store.Set(MinterKey, b)
+}
+
+//______________________________________________________________________
+
+// get the mint params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(ParamsKey)
+	if b == nil {
+		panic("Stored params should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &params)
+	return
+}
+
+// set the mint params
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(params)
+	store.Set(ParamsKey, b)
+}
+
+//______________________________________________________________________
+
+// MintCoins mints the provisions of a block and moves them to the fee
+// collector module account for distribution, the staking token supply is
+// inflated accordingly
+func (k Keeper) MintCoins(ctx sdk.Context, provision sdk.Coin) {
+	if provision.IsZero() {
+		return
+	}
+	coins := sdk.Coins{provision}
+	err := k.supplyKeeper.MintCoins(ctx, ModuleName, coins)
+	if err != nil {
+		panic(err)
+	}
+	_, err = k.coinKeeper.SendCoinsFromModuleToModule(ctx, ModuleName, auth.FeeCollectorName, coins)
+	if err != nil {
+		panic(err)
+	}
+	k.sk.InflateSupply(ctx, provision.Amount)
+}
@@ -0,0 +1,56 @@
+package mint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestMinterGetSet(t *testing.T) {
+	input := createTestInput(t, 0)
+	ctx, keeper := input.ctx, input.mintKeeper
+
+	require.Equal(t, DefaultInitialMinter(), keeper.GetMinter(ctx))
+	require.Equal(t, DefaultParams(), keeper.GetParams(ctx))
+
+	minter := NewMinter(sdk.NewRat(1, 10), sdk.NewRat(100))
+	keeper.SetMinter(ctx, minter)
+	require.Equal(t, minter, keeper.GetMinter(ctx))
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, NewGenesisState(minter, DefaultParams()), genesis)
+}
+
+// Test that the provisions of every block are minted into the fee collector
+func TestBeginBlocker(t *testing.T) {
+	initialSupply := int64(100000000000)
+	input := createTestInput(t, initialSupply)
+	ctx, keeper := input.ctx, input.mintKeeper
+	params := keeper.GetParams(ctx)
+	feeCollector := auth.NewModuleAddress(auth.FeeCollectorName)
+
+	// nothing is bonded so the inflation increases
+	BeginBlocker(ctx, keeper)
+	minter := keeper.GetMinter(ctx)
+	require.True(t, minter.Inflation.GT(DefaultInitialMinter().Inflation))
+	require.True(sdk.RatEq(t, minter.Inflation.Mul(sdk.NewRat(initialSupply)), minter.AnnualProvisions))
+
+	provision := minter.BlockProvision(params)
+	require.False(t, provision.IsZero())
+	require.Equal(t, sdk.Coins{provision}, input.coinKeeper.GetCoins(ctx, feeCollector))
+	require.Equal(t, provision.Amount, input.supplyKeeper.GetSupplyOf(ctx, params.MintDenom))
+	require.True(sdk.RatEq(t, sdk.NewRat(initialSupply).Add(sdk.NewRatFromInt(provision.Amount)),
+		input.stakeKeeper.StakingTokenSupply(ctx)))
+
+	// provisions keep being minted every block
+	for i := 0; i < 9; i++ {
+		BeginBlocker(ctx, keeper)
+	}
+	minted := input.coinKeeper.GetCoins(ctx, feeCollector).AmountOf(params.MintDenom)
+	require.True(t, minted.GT(provision.Amount.MulRaw(9)))
+	require.True(sdk.RatEq(t, sdk.NewRat(initialSupply).Add(sdk.NewRatFromInt(minted)),
+		input.stakeKeeper.StakingTokenSupply(ctx)))
+}
@@ -0,0 +1,77 @@
+package mint
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const precision = 100000000000 // increased to this precision for accuracy
+
+// Minter represents the minting state
+type Minter struct {
+	Inflation        sdk.Rat `json:"inflation"`         // current annual inflation rate
+	AnnualProvisions sdk.Rat `json:"annual_provisions"` // current annual expected provisions
+}
+
+// NewMinter returns a new Minter
+func NewMinter(inflation, annualProvisions sdk.Rat) Minter {
+	return Minter{
+		Inflation:        inflation,
+		AnnualProvisions: annualProvisions,
+	}
+}
+
+// InitialMinter returns an initial Minter with the given inflation rate and
+// no provisions yet
+func InitialMinter(inflation sdk.Rat) Minter {
+	return NewMinter(inflation, sdk.ZeroRat())
+}
+
+// DefaultInitialMinter returns an initial Minter with a 13% inflation rate
+func DefaultInitialMinter() Minter {
+	return InitialMinter(sdk.NewRat(13, 100))
+}
+
+func validateMinter(minter Minter) error {
+	if minter.Inflation.LT(sdk.ZeroRat()) {
+		return fmt.Errorf("mint parameter Inflation should be positive, is %s", minter.Inflation)
+	}
+	return nil
+}
+
+// get the new inflation rate for the next block
+func (m Minter) NextInflationRate(params Params, bondedRatio sdk.Rat) (inflation sdk.Rat) {
+
+	// The target annual inflation rate is recalculated for each block. The
+	// inflation is also subject to a rate change (positive or negative)
+	// depending on the distance from the desired ratio (67%). The maximum rate
+	// change possible is defined to be 13% per year, however the annual
+	// inflation is capped as between 7% and 20%.
+
+	// (1 - bondedRatio/GoalBonded) * InflationRateChange
+	inflationRateChangePerYear := sdk.OneRat().Sub(bondedRatio.Quo(params.GoalBonded)).Mul(params.InflationRateChange)
+	inflationRateChange := inflationRateChangePerYear.Quo(sdk.NewRat(params.BlocksPerYear))
+
+	// adjust the new annual inflation for this next block
+	inflation = m.Inflation.Add(inflationRateChange)
+	if inflation.GT(params.InflationMax) {
+		inflation = params.InflationMax
+	}
+	if inflation.LT(params.InflationMin) {
+		inflation = params.InflationMin
+	}
+
+	return inflation.Round(precision)
+}
+
+// get the annual provisions for the current inflation rate and total supply
+func (m Minter) NextAnnualProvisions(params Params, totalSupply sdk.Rat) (provisions sdk.Rat) {
+	return m.Inflation.Mul(totalSupply)
+}
+
+// get the provisions for a block based on the annual provisions rate
+func (m Minter) BlockProvision(params Params) sdk.Coin {
+	provisionAmt := m.AnnualProvisions.Quo(sdk.NewRat(params.BlocksPerYear))
+	return sdk.Coin{params.MintDenom, provisionAmt.RoundInt()}
+}
@@ -0,0 +1,106 @@
+package mint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestNextInflation(t *testing.T) {
+	minter := DefaultInitialMinter()
+	params := DefaultParams()
+	blocksPerYr := sdk.NewRat(params.BlocksPerYear)
+
+	// Governing Mechanism:
+	//    inflationRateChangePerYear = (1- BondedRatio/ GoalBonded) * MaxInflationRateChange
+
+	tests := []struct {
+		bondedRatio, setInflation, expChange sdk.Rat
+	}{
+		// with 0% bonded atom supply the inflation should increase by InflationRateChange
+		{sdk.ZeroRat(), sdk.NewRat(7, 100), params.InflationRateChange.Quo(blocksPerYr).Round(precision)},
+
+		// 100% bonded, starting at 20% inflation and being reduced
+		// (1 - (1/0.67))*(0.13/8667)
+		{sdk.OneRat(), sdk.NewRat(20, 100),
+			sdk.OneRat().Sub(sdk.OneRat().Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(blocksPerYr).Round(precision)},
+
+		// 50% bonded, starting at 10% inflation and being increased
+		{sdk.NewRat(1, 2), sdk.NewRat(10, 100),
+			sdk.OneRat().Sub(sdk.NewRat(1, 2).Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(blocksPerYr).Round(precision)},
+
+		// test 7% minimum stop (testing with 100% bonded)
+		{sdk.OneRat(), sdk.NewRat(7, 100), sdk.ZeroRat()},
+		{sdk.OneRat(), sdk.NewRat(70001, 1000000), sdk.NewRat(-1, 1000000).Round(precision)},
+
+		// test 20% maximum stop (testing with 0% bonded)
+		{sdk.ZeroRat(), sdk.NewRat(20, 100), sdk.ZeroRat()},
+		{sdk.ZeroRat(), sdk.NewRat(199999, 1000000), sdk.NewRat(1, 1000000).Round(precision)},
+
+		// perfect balance shouldn't change inflation
+		{sdk.NewRat(67, 100), sdk.NewRat(15, 100), sdk.ZeroRat()},
+	}
+	for i, tc := range tests {
+		minter.Inflation = tc.setInflation
+
+		inflation := minter.NextInflationRate(params, tc.bondedRatio)
+		diffInflation := inflation.Sub(tc.setInflation)
+
+		require.True(t, diffInflation.Equal(tc.expChange),
+			"Test Index: %v\nDiff:  %v\nExpected: %v\n", i, diffInflation, tc.expChange)
+	}
+}
+
+func TestBlockProvision(t *testing.T) {
+	minter := InitialMinter(sdk.NewRat(1, 10))
+	params := DefaultParams()
+
+	secondsPerYear := int64(60 * 60 * 8766)
+
+	tests := []struct {
+		annualProvisions int64
+		expProvisions    int64
+	}{
+		{secondsPerYear / 5, 1},
+		{secondsPerYear/5 + 1, 1},
+		{(secondsPerYear / 5) * 2, 2},
+		{(secondsPerYear / 5) / 2, 0}, // bankers rounding of a half
+		{(secondsPerYear / 5) / 3, 0},
+	}
+	for i, tc := range tests {
+		minter.AnnualProvisions = sdk.NewRat(tc.annualProvisions)
+		provisions := minter.BlockProvision(params)
+
+		expProvisions := sdk.NewCoin(params.MintDenom, tc.expProvisions)
+		require.True(t, expProvisions.IsEqual(provisions),
+			"test: %v\n\tExp: %v\n\tGot: %v\n", i, tc.expProvisions, provisions)
+	}
+}
+
+func TestNextAnnualProvisions(t *testing.T) {
+	minter := InitialMinter(sdk.NewRat(1, 10))
+	params := DefaultParams()
+
+	provisions := minter.NextAnnualProvisions(params, sdk.NewRat(1000))
+	require.True(sdk.RatEq(t, sdk.NewRat(100), provisions))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, validateGenesis(DefaultGenesisState()))
+
+	params := DefaultParams()
+	params.GoalBonded = sdk.ZeroRat()
+	require.NotNil(t, validateGenesis(NewGenesisState(DefaultInitialMinter(), params)))
+
+	params = DefaultParams()
+	params.InflationMax = sdk.NewRat(1, 100)
+	require.NotNil(t, validateGenesis(NewGenesisState(DefaultInitialMinter(), params)))
+
+	params = DefaultParams()
+	params.BlocksPerYear = 0
+	require.NotNil(t, validateGenesis(NewGenesisState(DefaultInitialMinter(), params)))
+
+	require.NotNil(t, validateGenesis(NewGenesisState(InitialMinter(sdk.NewRat(-1, 100)), DefaultParams())))
+}
@@ -0,0 +1,50 @@
+package mint
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Params defines the parameters of the minting
+type Params struct {
+	MintDenom           string  `json:"mint_denom"`            // type of coin to mint
+	InflationRateChange sdk.Rat `json:"inflation_rate_change"` // maximum annual change in inflation rate
+	InflationMax        sdk.Rat `json:"inflation_max"`         // maximum inflation rate
+	InflationMin        sdk.Rat `json:"inflation_min"`         // minimum inflation rate
+	GoalBonded          sdk.Rat `json:"goal_bonded"`           // goal of percent bonded atoms
+	BlocksPerYear       int64   `json:"blocks_per_year"`       // expected blocks per year
+}
+
+// DefaultParams returns a default set of parameters, expecting a block every
+// 5 seconds
+func DefaultParams() Params {
+	return Params{
+		MintDenom:           "steak",
+		InflationRateChange: sdk.NewRat(13, 100),
+		InflationMax:        sdk.NewRat(20, 100),
+		InflationMin:        sdk.NewRat(7, 100),
+		GoalBonded:          sdk.NewRat(67, 100),
+		BlocksPerYear:       60 * 60 * 8766 / 5, // as defined by a julian year of 365.25 days
+	}
+}
+
+func validateParams(params Params) error {
+	if params.MintDenom == "" {
+		return fmt.Errorf("mint parameter MintDenom can't be an empty string")
+	}
+	if params.GoalBonded.LTE(sdk.ZeroRat()) {
+		return fmt.Errorf("mint parameter GoalBonded should be positive, is %s", params.GoalBonded)
+	}
+	if params.GoalBonded.GT(sdk.OneRat()) {
+		return fmt.Errorf("mint parameter GoalBonded must be <= 1, is %s", params.GoalBonded)
+	}
+	if params.InflationMax.LT(params.InflationMin) {
+		return fmt.Errorf("mint parameter InflationMax (%s) must be greater than or equal to InflationMin (%s)",
+			params.InflationMax, params.InflationMin)
+	}
+	if params.BlocksPerYear <= 0 {
+		return fmt.Errorf("mint parameter BlocksPerYear must be positive, is %d", params.BlocksPerYear)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package mint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+type testInput struct {
+	ctx          sdk.Context
+	coinKeeper   bank.Keeper
+	supplyKeeper bank.SupplyKeeper
+	stakeKeeper  stake.Keeper
+	mintKeeper   Keeper
+}
+
+func createTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
+// create the keepers with a staking token supply of looseTokens
+func createTestInput(t *testing.T, looseTokens int64) testInput {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyMint := sdk.NewKVStoreKey("mint")
+	keyParams := sdk.NewKVStoreKey("params")
+	keySupply := sdk.NewKVStoreKey("supply")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyMint, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	cdc := createTestCodec()
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, auth.ProtoBaseAccount)
+	ck := bank.NewKeeper(accountMapper, params.NewKeeper(cdc, keyParams).Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(cdc, keySupply, ck, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+		ModuleName:            {auth.Minter},
+	})
+	sk := stake.NewKeeper(cdc, keyStake, ck, supplyKeeper, stake.DefaultCodespace)
+
+	stakeGenesis := stake.DefaultGenesisState()
+	stakeGenesis.Pool.LooseTokens = sdk.NewRat(looseTokens)
+	_, err = stake.InitGenesis(ctx, sk, stakeGenesis)
+	require.Nil(t, err)
+
+	keeper := NewKeeper(cdc, keyMint, sk, ck, supplyKeeper)
+	err = InitGenesis(ctx, keeper, DefaultGenesisState())
+	require.Nil(t, err)
+
+	return testInput{ctx, ck, supplyKeeper, sk, keeper}
+}
@@ -12,16 +12,26 @@ import (
 type Keeper struct {
 	cdc *wire.Codec
 	key sdk.StoreKey
+
+	// types of the params which can be set from their JSON encoding
+	types map[string]reflect.Type
 }
 
 // NewKeeper constructs a new Keeper
 func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
 	return Keeper{
-		cdc: cdc,
-		key: key,
+		cdc:   cdc,
+		key:   key,
+		types: make(map[string]reflect.Type),
 	}
 }
 
+// RegisterType registers the type of a parameter, allowing it to be set from
+// its JSON encoding, for instance by a governance proposal
+func (k Keeper) RegisterType(key string, param interface{}) {
+	k.types[key] = reflect.TypeOf(param)
+}
+
 // InitKeeper constructs a new Keeper with initial parameters
 func InitKeeper(ctx sdk.Context, cdc *wire.Codec, key sdk.StoreKey, params ...interface{}) Keeper {
 	if len(params)%2 != 0 {
@@ -72,6 +82,29 @@ func (k Keeper) set(ctx sdk.Context, key string, param interface{}) error {
 	return nil
 }
 
+// unmarshalJSON unmarshalls the JSON encoding of a registered parameter
+func (k Keeper) unmarshalJSON(key string, bz []byte) (interface{}, error) {
+	ty, ok := k.types[key]
+	if !ok {
+		return nil, fmt.Errorf("Param %s has no registered type", key)
+	}
+
+	ptr := reflect.New(ty)
+	if err := k.cdc.UnmarshalJSON(bz, ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("Param %s cannot be decoded: %v", key, err)
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// setJSON unmarshalls the JSON encoding of a registered parameter and sets it
+func (k Keeper) setJSON(ctx sdk.Context, key string, bz []byte) error {
+	param, err := k.unmarshalJSON(key, bz)
+	if err != nil {
+		return err
+	}
+	return k.set(ctx, key, param)
+}
+
 // setRaw sets raw byte slice
 func (k Keeper) setRaw(ctx sdk.Context, key string, param []byte) {
 	store := ctx.KVStore(k.key)
@@ -103,6 +136,19 @@ func (k Getter) GetRaw(ctx sdk.Context, key string) []byte {
 	return k.k.getRaw(ctx, key)
 }
 
+// HasType returns whether the type of a parameter has been registered
+func (k Getter) HasType(key string) bool {
+	_, ok := k.k.types[key]
+	return ok
+}
+
+// ValidateJSON returns an error unless the JSON encoding of a parameter
+// decodes into its registered type
+func (k Getter) ValidateJSON(key string, bz []byte) error {
+	_, err := k.k.unmarshalJSON(key, bz)
+	return err
+}
+
 // GetString is helper function for string params
 func (k Getter) GetString(ctx sdk.Context, key string) (res string, err error) {
 	store := ctx.KVStore(k.k.key)
@@ -403,3 +449,8 @@ func (k Setter) SetRat(ctx sdk.Context, key string, param sdk.Rat) {
 		panic(err)
 	}
 }
+
+// SetJSON sets a parameter registered with RegisterType from its JSON encoding
+func (k Setter) SetJSON(ctx sdk.Context, key string, bz []byte) error {
+	return k.k.setJSON(ctx, key, bz)
+}
@@ -278,3 +278,36 @@ func TestGetter(t *testing.T) {
 	assert.Equal(t, def10, res)
 
 }
+
+func TestSetJSON(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(key)
+	keeper := NewKeeper(wire.NewCodec(), key)
+
+	g := keeper.Getter()
+	s := keeper.Setter()
+
+	// unregistered params cannot be set from JSON
+	assert.False(t, g.HasType("bool"))
+	assert.NotNil(t, s.SetJSON(ctx, "bool", []byte("true")))
+
+	keeper.RegisterType("bool", false)
+	keeper.RegisterType("strings", []string{})
+	assert.True(t, g.HasType("bool"))
+
+	assert.Nil(t, s.SetJSON(ctx, "bool", []byte("true")))
+	assert.True(t, g.GetBoolWithDefault(ctx, "bool", false))
+
+	assert.Nil(t, s.SetJSON(ctx, "strings", []byte(`["foo","bar"]`)))
+	var strs []string
+	assert.Nil(t, g.Get(ctx, "strings", &strs))
+	assert.Equal(t, []string{"foo", "bar"}, strs)
+
+	// values must match the registered type
+	assert.NotNil(t, s.SetJSON(ctx, "bool", []byte(`["foo"]`)))
+	assert.True(t, g.GetBoolWithDefault(ctx, "bool", false))
+
+	assert.Nil(t, g.ValidateJSON("bool", []byte("false")))
+	assert.NotNil(t, g.ValidateJSON("bool", []byte(`["foo"]`)))
+	assert.NotNil(t, g.ValidateJSON("unknown", []byte("false")))
+}
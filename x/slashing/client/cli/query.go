@@ -11,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire" // XXX fix
 	"github.com/cosmos/cosmos-sdk/x/slashing"
+	slashingclient "github.com/cosmos/cosmos-sdk/x/slashing/client"
 )
 
 // get the command to query signing info
@@ -55,3 +56,46 @@ func GetCmdQuerySigningInfo(storeName string, cdc *wire.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// get the command to query the blocks a validator missed within the signing
+// window
+func GetCmdQueryMissedBlocks(storeName, paramsStoreName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "missed-blocks [validator-pubkey]",
+		Short: "Query the blocks a validator signed and missed within the signing window",
+		Long: `Query the blocks a validator signed and missed within the signing window,
+along with the number of blocks it must sign within the window and the height at
+which it is jailed for downtime if it misses every block from now on.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			pk, err := sdk.GetValPubKeyBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			missedBlocks, err := slashingclient.QueryMissedBlocks(ctx, cdc, storeName, paramsStoreName, sdk.ValAddress(pk.Address()))
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+
+			case "text":
+				human := missedBlocks.HumanReadableString()
+				fmt.Println(human)
+
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, missedBlocks)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -10,12 +10,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 )
 
-// create unrevoke command
-func GetCmdUnrevoke(cdc *wire.Codec) *cobra.Command {
+// create unjail command
+func GetCmdUnjail(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unrevoke",
+		Use:   "unjail",
 		Args:  cobra.ExactArgs(0),
-		Short: "unrevoke validator previously revoked for downtime",
+		Short: "unjail validator previously jailed for downtime",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
 
@@ -24,7 +24,7 @@ func GetCmdUnrevoke(cdc *wire.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := slashing.NewMsgUnrevoke(validatorAddr)
+			msg := slashing.NewMsgUnjail(validatorAddr)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+)
+
+// QueryMissedBlocks queries the record of the blocks a validator signed and
+// missed within the signing window, at the height of the context or at the
+// latest height if unset
+func QueryMissedBlocks(ctx context.CoreContext, cdc *wire.Codec, storeName, paramsStoreName string,
+	address sdk.ValAddress) (missed slashing.MissedBlocks, err error) {
+
+	// the projected jailing height is relative to the queried height, so the
+	// latest height is pinned rather than left to the node
+	height := ctx.Height
+	if height == 0 {
+		node, err := ctx.GetNode()
+		if err != nil {
+			return missed, err
+		}
+		status, err := node.Status()
+		if err != nil {
+			return missed, err
+		}
+		height = status.SyncInfo.LatestBlockHeight
+		ctx = ctx.WithHeight(height)
+	}
+
+	res, err := ctx.QueryStore(slashing.GetValidatorSigningInfoKey(address), storeName)
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		return missed, fmt.Errorf("no signing info found for validator %s", address)
+	}
+	var info slashing.ValidatorSigningInfo
+	if err = cdc.UnmarshalBinary(res, &info); err != nil {
+		return
+	}
+
+	windowBz, err := ctx.QueryStore([]byte(slashing.SignedBlocksWindowKey), paramsStoreName)
+	if err != nil {
+		return
+	}
+	minSignedBz, err := ctx.QueryStore([]byte(slashing.MinSignedPerWindowKey), paramsStoreName)
+	if err != nil {
+		return
+	}
+	window, minSigned, err := slashing.SigningWindowParams(cdc, windowBz, minSignedBz)
+	if err != nil {
+		return
+	}
+
+	pairs, err := ctx.QuerySubspace(cdc, slashing.GetValidatorSigningBitArrayPrefixKey(address), storeName)
+	if err != nil {
+		return
+	}
+	bits := make(map[int64]bool, len(pairs))
+	for _, pair := range pairs {
+		var signed bool
+		if err = cdc.UnmarshalBinary(pair.Value, &signed); err != nil {
+			return
+		}
+		bits[slashing.GetValidatorSigningBitArrayIndex(pair.Key)] = signed
+	}
+
+	signed := func(index int64) bool { return bits[index] }
+	return slashing.NewMissedBlocks(info, signed, window, minSigned, height), nil
+}
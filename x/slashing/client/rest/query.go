@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -10,13 +11,22 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
+	slashingclient "github.com/cosmos/cosmos-sdk/x/slashing/client"
 )
 
+// RestHeight is the optional query parameter of the height to query at
+const RestHeight = "height"
+
 func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 	r.HandleFunc(
 		"/slashing/signing_info/{validator}",
 		signingInfoHandlerFn(ctx, "slashing", cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/missed_blocks/{validator}",
+		missedBlocksHandlerFn(ctx, "slashing", "params", cdc),
+	).Methods("GET")
 }
 
 // http request handler to query signing info
@@ -60,3 +70,48 @@ func signingInfoHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.C
 		w.Write(output)
 	}
 }
+
+// http request handler to query the blocks a validator missed within the
+// signing window
+func missedBlocksHandlerFn(ctx context.CoreContext, storeName, paramsStoreName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32validator := vars["validator"]
+
+		validatorAddr, err := sdk.ValAddressFromBech32(bech32validator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		queryCtx := ctx
+		if heightStr := r.URL.Query().Get(RestHeight); heightStr != "" {
+			height, err := strconv.ParseInt(heightStr, 10, 64)
+			if err != nil || height < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(fmt.Sprintf("height must be a non-negative integer, got %s", heightStr)))
+				return
+			}
+			queryCtx = ctx.WithHeight(height)
+		}
+
+		missedBlocks, err := slashingclient.QueryMissedBlocks(queryCtx, cdc, storeName, paramsStoreName, validatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query missed blocks. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(missedBlocks)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -2,6 +2,8 @@
 package slashing
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -14,7 +16,10 @@ const (
 
 	CodeInvalidValidator    CodeType = 101
 	CodeValidatorJailed     CodeType = 102
-	CodeValidatorNotRevoked CodeType = 103
+	CodeValidatorNotJailed  CodeType = 103
+	CodeValidatorTombstoned CodeType = 104
+	CodeEvidenceTooOld      CodeType = 105
+	CodeSelfDelegationLow   CodeType = 106
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -23,9 +28,18 @@ func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
 func ErrBadValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator does not exist for that address")
 }
-func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, CodeValidatorJailed, "validator jailed, cannot yet be unrevoked")
+func ErrValidatorJailed(codespace sdk.CodespaceType, remaining int64) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorJailed, fmt.Sprintf("validator still jailed for %d seconds, cannot yet be unjailed", remaining))
+}
+func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorNotJailed, "validator not jailed, cannot be unjailed")
+}
+func ErrValidatorTombstoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorTombstoned, "validator tombstoned for double signing, it cannot be unjailed nor created again")
+}
+func ErrEvidenceTooOld(codespace sdk.CodespaceType, age, maxAge int64) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceTooOld, fmt.Sprintf("evidence age of %d seconds past max age of %d", age, maxAge))
 }
-func ErrValidatorNotRevoked(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, CodeValidatorNotRevoked, "validator not revoked, cannot be unrevoked")
+func ErrSelfDelegationTooLow(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfDelegationLow, "validator owner's self delegation is below the minimum, cannot be unjailed")
 }
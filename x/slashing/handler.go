@@ -8,17 +8,20 @@ func NewHandler(k Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		// NOTE msg already has validate basic run
 		switch msg := msg.(type) {
-		case MsgUnrevoke:
-			return handleMsgUnrevoke(ctx, msg, k)
+		case MsgUnjail:
+			return handleMsgUnjail(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
 	}
 }
 
-// Validators must submit a transaction to unrevoke itself after
-// having been revoked (and thus unbonded) for downtime
-func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
+// Validators must submit a transaction to unjail itself after having been
+// jailed (and thus unbonded) for downtime, once its jail time is over.
+// Validators jailed for double signing are tombstoned and can never be
+// unjailed, validators jailed for an owner self delegation below the minimum
+// must first delegate enough
+func handleMsgUnjail(ctx sdk.Context, msg MsgUnjail, k Keeper) sdk.Result {
 
 	// Validator must exist
 	validator := k.validatorSet.Validator(ctx, msg.ValidatorAddr)
@@ -26,8 +29,8 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 		return ErrNoValidatorForAddress(k.codespace).Result()
 	}
 
-	if !validator.GetRevoked() {
-		return ErrValidatorNotRevoked(k.codespace).Result()
+	if !validator.GetJailed() {
+		return ErrValidatorNotJailed(k.codespace).Result()
 	}
 
 	addr := sdk.ValAddress(validator.GetPubKey().Address())
@@ -38,21 +41,40 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 		return ErrNoValidatorForAddress(k.codespace).Result()
 	}
 
-	// Cannot be unrevoked until out of jail
-	if ctx.BlockHeader().Time < info.JailedUntil {
-		return ErrValidatorJailed(k.codespace).Result()
+	// Cannot be unjailed once tombstoned for double signing
+	if info.Tombstoned {
+		return ErrValidatorTombstoned(k.codespace).Result()
 	}
 
-	// Update the starting height (so the validator can't be immediately revoked again)
+	// Cannot be unjailed until out of jail
+	if remaining := validator.GetJailedUntil() - ctx.BlockHeader().Time; remaining > 0 {
+		return ErrValidatorJailed(k.codespace, remaining).Result()
+	}
+
+	// Cannot be unjailed while the owner self delegation is below the minimum
+	if selfDelegation(ctx, k.validatorSet, validator).LT(sdk.NewRatFromInt(validator.GetMinSelfDelegation())) {
+		return ErrSelfDelegationTooLow(k.codespace).Result()
+	}
+
+	// Update the starting height (so the validator can't be immediately jailed again)
 	info.StartHeight = ctx.BlockHeight()
 	k.setValidatorSigningInfo(ctx, addr, info)
 
-	// Unrevoke the validator
-	k.validatorSet.Unrevoke(ctx, validator.GetPubKey())
+	// Unjail the validator
+	k.validatorSet.Unjail(ctx, validator.GetPubKey())
 
-	tags := sdk.NewTags("action", []byte("unrevoke"), "validator", []byte(msg.ValidatorAddr.String()))
+	tags := sdk.NewTags("action", []byte("unjail"), "validator", []byte(msg.ValidatorAddr.String()))
 
 	return sdk.Result{
 		Tags: tags,
 	}
 }
+
+// tokens the owner of a validator has delegated to it
+func selfDelegation(ctx sdk.Context, vs sdk.ValidatorSet, validator sdk.Validator) sdk.Rat {
+	delegation := vs.Delegation(ctx, validator.GetOwner(), validator.GetOwner())
+	if delegation == nil || validator.GetDelegatorShares().IsZero() {
+		return sdk.ZeroRat()
+	}
+	return delegation.GetBondShares().Mul(validator.GetTokens()).Quo(validator.GetDelegatorShares())
+}
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
-func TestCannotUnrevokeUnlessRevoked(t *testing.T) {
+func TestCannotUnjailUnlessJailed(t *testing.T) {
 	// initial setup
 	ctx, ck, sk, _, keeper := createTestInput(t)
 	slh := NewHandler(keeper)
@@ -22,8 +23,62 @@ func TestCannotUnrevokeUnlessRevoked(t *testing.T) {
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
 	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 
-	// assert non-revoked validator can't be unrevoked
-	got = slh(ctx, NewMsgUnrevoke(addr))
-	require.False(t, got.IsOK(), "allowed unrevoke of non-revoked validator")
-	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorNotRevoked), got.Code)
+	// assert non-jailed validator can't be unjailed
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.False(t, got.IsOK(), "allowed unjail of non-jailed validator")
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorNotJailed), got.Code)
+}
+
+func TestCannotUnjailTombstoned(t *testing.T) {
+	// initial setup
+	ctx, _, sk, _, keeper, ek := createTestInputWithEvidence(t)
+	slh := NewHandler(keeper)
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// double sign, then wait until the validator is out of jail
+	err := ek.HandleEvidence(ctx, newTestDoubleSign(val, 0, 0, amtInt))
+	require.Nil(t, err)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.DoubleSignJailDuration(ctx)})
+
+	// assert tombstoned validator can't be unjailed
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.False(t, got.IsOK(), "allowed unjail of tombstoned validator")
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+}
+
+func TestCannotUnjailBelowMinSelfDelegation(t *testing.T) {
+	// initial setup
+	ctx, _, sk, _, keeper := createTestInput(t)
+	slh := NewHandler(keeper)
+	sh := stake.NewHandler(sk)
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	msg := newTestMsgCreateValidator(addr, val, amt)
+	msg.MinSelfDelegation = sdk.NewInt(50)
+	got := sh(ctx, msg)
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// unbonding below the minimum self delegation jails the validator
+	got = sh(ctx, stake.NewMsgBeginUnbonding(addr, addr, sdk.NewRat(60)))
+	require.True(t, got.IsOK())
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+
+	// assert the validator can't be unjailed until the owner delegates enough
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.False(t, got.IsOK(), "allowed unjail below the minimum self delegation")
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSelfDelegationLow), got.Code)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+
+	got = sh(ctx, stake.NewMsgDelegate(addr, addr, sdk.Coin{"steak", sdk.NewInt(10)}))
+	require.True(t, got.IsOK())
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.True(t, got.IsOK())
+	require.False(t, sk.Validator(ctx, addr).GetJailed())
 }
@@ -0,0 +1,58 @@
+package slashing
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks of the slashing keeper, to be registered on the staking keeper so
+// that the signing info of a validator exists as soon as it is created
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the slashing keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// reject the creation of a validator with the consensus key of a tombstoned
+// validator, which was removed since, so that it is never bonded again
+func (h Hooks) BeforeValidatorCreated(ctx sdk.Context, pubkey crypto.PubKey) sdk.Error {
+	signInfo, found := h.k.getValidatorSigningInfo(ctx, sdk.ValAddress(pubkey.Address()))
+	if found && signInfo.Tombstoned {
+		return ErrValidatorTombstoned(h.k.codespace)
+	}
+	return nil
+}
+
+// create the signing info of a new validator
+func (h Hooks) OnValidatorCreated(ctx sdk.Context, validator sdk.Validator) {
+	address := sdk.ValAddress(validator.GetPubKey().Address())
+	if _, found := h.k.getValidatorSigningInfo(ctx, address); found {
+		return
+	}
+	signInfo := NewValidatorSigningInfo(ctx.BlockHeight(), 0, 0, 0, 0, false)
+	h.k.setValidatorSigningInfo(ctx, address, signInfo)
+}
+
+// start the signing window of a validator bonded for the first time, so that
+// the blocks passed before it was bonded are not counted as missed
+func (h Hooks) OnValidatorBonded(ctx sdk.Context, validator sdk.Validator) {
+	address := sdk.ValAddress(validator.GetPubKey().Address())
+	signInfo, found := h.k.getValidatorSigningInfo(ctx, address)
+	if !found || signInfo.IndexOffset != 0 {
+		return
+	}
+	signInfo.StartHeight = ctx.BlockHeight()
+	h.k.setValidatorSigningInfo(ctx, address, signInfo)
+}
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorBeginUnbonding(ctx sdk.Context, validator sdk.Validator)            {}
+func (h Hooks) OnValidatorRemoved(ctx sdk.Context, validator sdk.Validator)                   {}
+func (h Hooks) OnValidatorSlashed(ctx sdk.Context, validator sdk.Validator, fraction sdk.Rat) {}
+func (h Hooks) OnDelegationModified(ctx sdk.Context, delegator, validator sdk.AccAddress)     {}
@@ -0,0 +1,42 @@
+package slashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// Test that the signing info is created along with the validator
+// and that its start height follows the first bonding of the validator
+func TestHooksSigningInfo(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	addr, val, amt := addrs[0], pks[0], int64(100)
+	address := sdk.ValAddress(val.Address())
+
+	// signing info is created with the validator
+	ctx = ctx.WithBlockHeight(10)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, sdk.NewInt(amt)))
+	require.True(t, got.IsOK())
+	info, found := keeper.getValidatorSigningInfo(ctx, address)
+	require.True(t, found)
+	require.Equal(t, int64(10), info.StartHeight)
+	require.Equal(t, int64(0), info.IndexOffset)
+
+	// bonding a validator which has never signed restarts its window
+	ctx = ctx.WithBlockHeight(20)
+	keeper.Hooks().OnValidatorBonded(ctx, sk.Validator(ctx, addr))
+	info, _ = keeper.getValidatorSigningInfo(ctx, address)
+	require.Equal(t, int64(20), info.StartHeight)
+
+	// once it has signed the window is kept
+	ctx = ctx.WithBlockHeight(21)
+	keeper.handleValidatorSignature(ctx, val, amt, true)
+	ctx = ctx.WithBlockHeight(30)
+	keeper.Hooks().OnValidatorBonded(ctx, sk.Validator(ctx, addr))
+	info, _ = keeper.getValidatorSigningInfo(ctx, address)
+	require.Equal(t, int64(20), info.StartHeight)
+	require.Equal(t, int64(1), info.IndexOffset)
+}
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -32,36 +33,52 @@ func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, params pa
 	return keeper
 }
 
-// handle a validator signing two blocks at the same height
-func (k Keeper) handleDoubleSign(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight int64, timestamp int64, power int64) {
+// HandleDoubleSign is the evidence handler of a validator signing two blocks
+// at the same height, the evidence keeper slashes and jails the validator as
+// DoubleSignPolicy says unless it returns an error
+func (k Keeper) HandleDoubleSign(ctx sdk.Context, ev evidence.Evidence) sdk.Error {
 	logger := ctx.Logger().With("module", "x/slashing")
-	time := ctx.BlockHeader().Time
-	age := time - timestamp
+	pubkey, infractionHeight := ev.GetConsensusPubKey(), ev.GetHeight()
+	age := ctx.BlockHeader().Time - ev.GetTime()
 	address := sdk.ValAddress(pubkey.Address())
 
 	// Double sign too old
 	maxEvidenceAge := k.MaxEvidenceAge(ctx)
 	if age > maxEvidenceAge {
 		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, age of %d past max age of %d", pubkey.Address(), infractionHeight, age, maxEvidenceAge))
-		return
+		return ErrEvidenceTooOld(k.codespace, age, maxEvidenceAge)
+	}
+
+	// Validators created before the staking hooks were registered may have
+	// no signing info yet
+	signInfo, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		signInfo = NewValidatorSigningInfo(ctx.BlockHeight(), 0, 0, 0, 0, false)
+	}
+
+	// Validator already tombstoned, only its first double sign is slashed
+	if signInfo.Tombstoned {
+		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, validator already tombstoned", pubkey.Address(), infractionHeight))
+		return ErrValidatorTombstoned(k.codespace)
 	}
 
 	// Double sign confirmed
 	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), infractionHeight, age, maxEvidenceAge))
 
-	// Slash validator
-	k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, k.SlashFractionDoubleSign(ctx))
-
-	// Revoke validator
-	k.validatorSet.Revoke(ctx, pubkey)
+	// Tombstone validator, so it can never be unjailed
+	signInfo.DoubleSignCount++
+	signInfo.Tombstoned = true
+	k.setValidatorSigningInfo(ctx, address, signInfo)
+	return nil
+}
 
-	// Jail validator
-	signInfo, found := k.getValidatorSigningInfo(ctx, address)
-	if !found {
-		panic(fmt.Sprintf("Expected signing info for validator %s but not found", address))
+// DoubleSignPolicy is the punishment of a double signing validator, it is
+// slashed by SlashFractionDoubleSign and jailed for DoubleSignJailDuration
+func (k Keeper) DoubleSignPolicy() evidence.Policy {
+	return evidence.Policy{
+		SlashFraction: k.SlashFractionDoubleSign,
+		JailDuration:  k.DoubleSignJailDuration,
 	}
-	signInfo.JailedUntil = time + k.DoubleSignUnbondDuration(ctx)
-	k.setValidatorSigningInfo(ctx, address, signInfo)
 }
 
 // handle a validator signature, must be called once per validator per block
@@ -71,11 +88,12 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 	address := sdk.ValAddress(pubkey.Address())
 
 	// Local index, so counts blocks validator *should* have signed
-	// Will use the 0-value default signing info if not present, except for start height
+	// The signing info is created by the staking hooks along with the validator,
+	// validators created before the hooks were registered, such as the genesis
+	// validators, get theirs the first time they are expected to sign
 	signInfo, found := k.getValidatorSigningInfo(ctx, address)
 	if !found {
-		// If this validator has never been seen before, construct a new SigningInfo with the correct start height
-		signInfo = NewValidatorSigningInfo(height, 0, 0, 0)
+		signInfo = NewValidatorSigningInfo(height, 0, 0, 0, 0, false)
 	}
 	index := signInfo.IndexOffset % k.SignedBlocksWindow(ctx)
 	signInfo.IndexOffset++
@@ -101,11 +119,17 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 	}
 	minHeight := signInfo.StartHeight + k.SignedBlocksWindow(ctx)
 	if height > minHeight && signInfo.SignedBlocksCounter < k.MinSignedPerWindow(ctx) {
-		// Downtime confirmed, slash, revoke, and jail the validator
-		logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, k.MinSignedPerWindow(ctx)))
-		k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx))
-		k.validatorSet.Revoke(ctx, pubkey)
-		signInfo.JailedUntil = ctx.BlockHeader().Time + k.DowntimeUnbondDuration(ctx)
+		validator := k.validatorSet.ValidatorByPubKey(ctx, pubkey)
+		if validator != nil && !validator.GetJailed() {
+			// Downtime confirmed, slash and jail the validator
+			logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, k.MinSignedPerWindow(ctx)))
+			k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx))
+			k.validatorSet.Jail(ctx, pubkey, ctx.BlockHeader().Time+k.DowntimeJailDuration(ctx))
+			signInfo.DowntimeJailCount++
+		} else {
+			// Validator was (a) not found or (b) already jailed, don't slash
+			logger.Info(fmt.Sprintf("Validator %s would have been slashed for downtime, but was either not found in store or already jailed", pubkey.Address()))
+		}
 	}
 
 	// Set the updated signing info
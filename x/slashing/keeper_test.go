@@ -8,6 +8,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -15,8 +16,8 @@ import (
 // lest the tests take forever
 func init() {
 	defaultSignedBlocksWindow = 1000
-	defaultDowntimeUnbondDuration = 60 * 60
-	defaultDoubleSignUnbondDuration = 60 * 60
+	defaultDowntimeJailDuration = 60 * 60
+	defaultDoubleSignJailDuration = 60 * 60
 }
 
 // Test that a validator is slashed correctly
@@ -24,7 +25,7 @@ func init() {
 func TestHandleDoubleSign(t *testing.T) {
 
 	// initial setup
-	ctx, ck, sk, _, keeper := createTestInput(t)
+	ctx, ck, sk, _, keeper, ek := createTestInputWithEvidence(t)
 	amtInt := int64(100)
 	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
 	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
@@ -33,27 +34,84 @@ func TestHandleDoubleSign(t *testing.T) {
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
 	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 
-	// handle a signature to set signing info
-	keeper.handleValidatorSignature(ctx, val, amtInt, true)
-
 	// double sign less than max age
-	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
+	err := ek.HandleEvidence(ctx, newTestDoubleSign(val, 0, 0, amtInt))
+	require.Nil(t, err)
 
-	// should be revoked
-	require.True(t, sk.Validator(ctx, addr).GetRevoked())
-	// unrevoke to measure power
-	sk.Unrevoke(ctx, val)
+	// should be jailed and tombstoned
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+	require.Equal(t, keeper.DoubleSignJailDuration(ctx), sk.Validator(ctx, addr).GetJailedUntil())
+	info, found := keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+	require.Equal(t, int64(1), info.DoubleSignCount)
+	require.Equal(t, int64(0), info.DowntimeJailCount)
+	// unjail to measure power
+	sk.Unjail(ctx, val)
 	// power should be reduced
 	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
+
+	// the same evidence is only handled once
+	err = ek.HandleEvidence(ctx, newTestDoubleSign(val, 0, 0, amtInt))
+	require.Equal(t, sdk.ToABCICode(evidence.DefaultCodespace, evidence.CodeEvidenceExists), err.ABCICode())
+
+	// another double sign within the evidence window isn't slashed again
+	err = ek.HandleEvidence(ctx, newTestDoubleSign(val, 1, 0, amtInt))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), err.ABCICode())
+	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
 	ctx = ctx.WithBlockHeader(abci.Header{Time: 1 + keeper.MaxEvidenceAge(ctx)})
 
 	// double sign past max age
-	keeper.handleDoubleSign(ctx, val, 0, 0, amtInt)
+	err = ek.HandleEvidence(ctx, newTestDoubleSign(val, 2, 0, amtInt))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceTooOld), err.ABCICode())
 	require.Equal(t, sdk.NewRatFromInt(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
 }
 
-// Test a validator through uptime, downtime, revocation,
-// unrevocation, starting height reset, and revocation again
+// Test that a tombstoned validator which unbonded and was removed can't be
+// created again with the same consensus key
+func TestTombstonedValidatorCannotBeRecreated(t *testing.T) {
+	ctx, _, sk, _, keeper, ek := createTestInputWithEvidence(t)
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	// double sign tombstones the validator
+	err := ek.HandleEvidence(ctx, newTestDoubleSign(val, 0, 0, amtInt))
+	require.Nil(t, err)
+
+	// unbonding the whole self-delegation removes the validator
+	delegation, found := sk.GetDelegation(ctx, addr, addr)
+	require.True(t, found)
+	got = stake.NewHandler(sk)(ctx, stake.NewMsgBeginUnbonding(addr, addr, delegation.Shares))
+	require.True(t, got.IsOK(), got.Log)
+	_, found = sk.GetValidatorByPubKey(ctx, val)
+	require.False(t, found)
+
+	// the consensus key can't be used by a new validator, whatever its owner
+	for _, owner := range []sdk.AccAddress{addr, addrs[1]} {
+		got = stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(owner, val, amt))
+		require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code, got.Log)
+		_, found = sk.GetValidatorByPubKey(ctx, val)
+		require.False(t, found)
+	}
+
+	// so a later double sign of the key has no validator to escape slashing
+	info, found := keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+	err = ek.HandleEvidence(ctx, newTestDoubleSign(val, 1, 0, amtInt))
+	require.NotNil(t, err)
+	require.Nil(t, sk.ValidatorByPubKey(ctx, val))
+
+	// other keys are still accepted
+	got = stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addrs[1], pks[1], amt))
+	require.True(t, got.IsOK(), got.Log)
+}
+
+// Test a validator through uptime, downtime, jailing,
+// unjailing, starting height reset, and jailing again
 func TestHandleAbsentValidator(t *testing.T) {
 
 	// initial setup
@@ -68,11 +126,11 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
 	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 	info, found := keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
-	require.False(t, found)
+	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
 	require.Equal(t, int64(0), info.IndexOffset)
 	require.Equal(t, int64(0), info.SignedBlocksCounter)
-	require.Equal(t, int64(0), info.JailedUntil)
+	require.Equal(t, int64(0), info.DowntimeJailCount)
 	height := int64(0)
 
 	// 1000 first blocks OK
@@ -109,17 +167,30 @@ func TestHandleAbsentValidator(t *testing.T) {
 	require.Equal(t, int64(0), info.StartHeight)
 	require.Equal(t, keeper.SignedBlocksWindow(ctx)-keeper.MinSignedPerWindow(ctx)-1, info.SignedBlocksCounter)
 
-	// validator should have been revoked
+	require.Equal(t, int64(1), info.DowntimeJailCount)
+
+	// validator should have been jailed
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
 	require.Equal(t, sdk.Unbonded, validator.GetStatus())
+	require.True(t, validator.GetJailed())
+	require.Equal(t, keeper.DowntimeJailDuration(ctx), validator.GetJailedUntil())
 
-	// unrevocation should fail prior to jail expiration
-	got = slh(ctx, NewMsgUnrevoke(addr))
+	// a block missed while jailed is neither slashed nor jailed again
+	keeper.handleValidatorSignature(ctx, val, amtInt, false)
+	info, found = keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.True(t, found)
+	require.Equal(t, int64(1), info.DowntimeJailCount)
+	require.Equal(t, int64(amtInt-1), sk.Validator(ctx, addr).GetTokens().RoundInt64())
+
+	// unjailing should fail prior to jail expiration
+	got = slh(ctx, NewMsgUnjail(addr))
 	require.False(t, got.IsOK())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorJailed), got.Code)
+	require.Equal(t, ErrValidatorJailed(DefaultCodespace, keeper.DowntimeJailDuration(ctx)).ABCILog(), got.Log)
 
-	// unrevocation should succeed after jail expiration
-	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.DowntimeUnbondDuration(ctx) + 1})
-	got = slh(ctx, NewMsgUnrevoke(addr))
+	// unjailing should succeed after jail expiration
+	ctx = ctx.WithBlockHeader(abci.Header{Time: keeper.DowntimeJailDuration(ctx) + 1})
+	got = slh(ctx, NewMsgUnjail(addr))
 	require.True(t, got.IsOK())
 
 	// validator should be rebonded now
@@ -134,9 +205,9 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
 	require.True(t, found)
 	require.Equal(t, height, info.StartHeight)
-	require.Equal(t, keeper.SignedBlocksWindow(ctx)-keeper.MinSignedPerWindow(ctx)-1, info.SignedBlocksCounter)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx)-keeper.MinSignedPerWindow(ctx)-2, info.SignedBlocksCounter)
 
-	// validator should not be immediately revoked again
+	// validator should not be immediately jailed again
 	height++
 	ctx = ctx.WithBlockHeight(height)
 	keeper.handleValidatorSignature(ctx, val, amtInt, false)
@@ -150,7 +221,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 		keeper.handleValidatorSignature(ctx, val, amtInt, false)
 	}
 
-	// validator should be revoked again after 500 unsigned blocks
+	// validator should be jailed again after 500 unsigned blocks
 	nextHeight = height + keeper.MinSignedPerWindow(ctx) + 1
 	for ; height <= nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
@@ -158,25 +229,29 @@ func TestHandleAbsentValidator(t *testing.T) {
 	}
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
 	require.Equal(t, sdk.Unbonded, validator.GetStatus())
+	info, found = keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.True(t, found)
+	require.Equal(t, int64(2), info.DowntimeJailCount)
 }
 
 // Test a new validator entering the validator set
 // Ensure that SigningInfo.StartHeight is set correctly
-// and that they are not immediately revoked
+// and that they are not immediately jailed
 func TestHandleNewValidator(t *testing.T) {
 	// initial setup
 	ctx, ck, sk, _, keeper := createTestInput(t)
 	addr, val, amt := addrs[0], pks[0], int64(100)
 	sh := stake.NewHandler(sk)
+
+	// 1000 first blocks not a validator
+	ctx = ctx.WithBlockHeight(keeper.SignedBlocksWindow(ctx) + 1)
+
 	got := sh(ctx, newTestMsgCreateValidator(addr, val, sdk.NewInt(amt)))
 	require.True(t, got.IsOK())
 	stake.EndBlocker(ctx, sk)
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.SubRaw(amt)}})
 	require.Equal(t, sdk.NewRat(amt), sk.Validator(ctx, addr).GetPower())
 
-	// 1000 first blocks not a validator
-	ctx = ctx.WithBlockHeight(keeper.SignedBlocksWindow(ctx) + 1)
-
 	// Now a validator, for two blocks
 	keeper.handleValidatorSignature(ctx, val, 100, true)
 	ctx = ctx.WithBlockHeight(keeper.SignedBlocksWindow(ctx) + 2)
@@ -187,11 +262,29 @@ func TestHandleNewValidator(t *testing.T) {
 	require.Equal(t, int64(keeper.SignedBlocksWindow(ctx)+1), info.StartHeight)
 	require.Equal(t, int64(2), info.IndexOffset)
 	require.Equal(t, int64(1), info.SignedBlocksCounter)
-	require.Equal(t, int64(0), info.JailedUntil)
+	require.Equal(t, int64(0), info.DowntimeJailCount)
 
-	// validator should be bonded still, should not have been revoked or slashed
+	// validator should be bonded still, should not have been jailed or slashed
 	validator, _ := sk.GetValidatorByPubKey(ctx, val)
 	require.Equal(t, sdk.Bonded, validator.GetStatus())
 	pool := sk.GetPool(ctx)
 	require.Equal(t, int64(100), pool.BondedTokens.RoundInt64())
 }
+
+// Test a validator without signing info, such as a genesis validator created
+// before the staking hooks were registered
+func TestHandleValidatorWithoutSigningInfo(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t)
+	val := pks[2]
+	ctx = ctx.WithBlockHeight(5)
+
+	_, found := keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.False(t, found)
+	keeper.handleValidatorSignature(ctx, val, 100, true)
+
+	info, found := keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(val.Address()))
+	require.True(t, found)
+	require.Equal(t, int64(5), info.StartHeight)
+	require.Equal(t, int64(1), info.IndexOffset)
+	require.Equal(t, int64(1), info.SignedBlocksCounter)
+}
@@ -0,0 +1,114 @@
+package slashing
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// MissedBlocks is the record of the blocks a validator signed and missed
+// within the signing window, and of how close it is to being jailed for
+// downtime
+type MissedBlocks struct {
+	Height        int64  `json:"height"`         // height of the state the record was built from
+	Window        int64  `json:"window"`         // number of blocks in the signing window
+	MinSigned     int64  `json:"min_signed"`     // blocks to sign within the window to avoid being jailed
+	Bitmap        string `json:"bitmap"`         // signed (1) and missed (0) blocks of the window, oldest first
+	SignedCount   int64  `json:"signed_count"`   // signed blocks within the window
+	MissedCount   int64  `json:"missed_count"`   // missed blocks within the window
+	JailingHeight int64  `json:"jailing_height"` // height the validator is jailed at if it misses every block from now on, 0 if never
+}
+
+// Return human readable missed blocks
+func (m MissedBlocks) HumanReadableString() string {
+	jailing := "never"
+	if m.JailingHeight != 0 {
+		jailing = fmt.Sprintf("%d", m.JailingHeight)
+	}
+	return fmt.Sprintf("Height: %d, window: %d, min signed: %d, signed: %d, missed: %d, projected jailing height: %s\nBitmap (oldest first): %s",
+		m.Height, m.Window, m.MinSigned, m.SignedCount, m.MissedCount, jailing, m.Bitmap)
+}
+
+// NewMissedBlocks builds the missed blocks record of a validator from its
+// signing info and its signed block bit array at a height
+func NewMissedBlocks(info ValidatorSigningInfo, signed func(index int64) bool, window, minSigned,
+	height int64) MissedBlocks {
+
+	// the bit array is circular, only the blocks of the last full window
+	// were recorded
+	recorded := info.IndexOffset
+	if recorded > window {
+		recorded = window
+	}
+	bits := make([]bool, window)
+	var bitmap bytes.Buffer
+	missed := int64(0)
+	for offset := info.IndexOffset - recorded; offset < info.IndexOffset; offset++ {
+		index := offset % window
+		bits[index] = signed(index)
+		if bits[index] {
+			bitmap.WriteByte('1')
+		} else {
+			bitmap.WriteByte('0')
+			missed++
+		}
+	}
+
+	return MissedBlocks{
+		Height:        height,
+		Window:        window,
+		MinSigned:     minSigned,
+		Bitmap:        bitmap.String(),
+		SignedCount:   info.SignedBlocksCounter,
+		MissedCount:   missed,
+		JailingHeight: projectJailingHeight(info, bits, window, minSigned, height),
+	}
+}
+
+// project the height at which a validator missing every block after the
+// height is jailed for downtime, the same way handleValidatorSignature
+// updates its signing info
+func projectJailingHeight(info ValidatorSigningInfo, bits []bool, window, minSigned, height int64) int64 {
+	if minSigned <= 0 {
+		return 0
+	}
+	minHeight := info.StartHeight + window
+	counter := info.SignedBlocksCounter
+	for offset := info.IndexOffset; ; offset++ {
+		height++
+		index := offset % window
+		if bits[index] {
+			bits[index] = false
+			counter--
+		}
+		if height > minHeight && counter < minSigned {
+			return height
+		}
+	}
+}
+
+// SigningWindowParams returns the signing window and the minimum number of
+// blocks to sign within it from the raw values of their params, unset params
+// take their default value
+func SigningWindowParams(cdc *wire.Codec, windowBz, minSignedBz []byte) (window, minSigned int64, err error) {
+	window = defaultSignedBlocksWindow
+	if windowBz != nil {
+		if err = cdc.UnmarshalBinary(windowBz, &window); err != nil {
+			return
+		}
+	}
+	minSignedPerWindow := defaultMinSignedPerWindow
+	if minSignedBz != nil {
+		if err = cdc.UnmarshalBinary(minSignedBz, &minSignedPerWindow); err != nil {
+			return
+		}
+	}
+	return window, minSignedInWindow(window, minSignedPerWindow), nil
+}
+
+// number of blocks to sign within a window given the fraction to sign
+func minSignedInWindow(window int64, minSignedPerWindow sdk.Rat) int64 {
+	return sdk.NewRat(window).Mul(minSignedPerWindow).RoundInt64()
+}
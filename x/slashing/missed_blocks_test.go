@@ -0,0 +1,101 @@
+package slashing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestNewMissedBlocks(t *testing.T) {
+	signed := func(bits string) func(int64) bool {
+		return func(index int64) bool { return bits[index] == '1' }
+	}
+
+	tests := []struct {
+		name          string
+		info          ValidatorSigningInfo
+		bits          string
+		height        int64
+		expBitmap     string
+		expMissed     int64
+		expJailHeight int64
+	}{
+		{"partial window", NewValidatorSigningInfo(0, 3, 2, 0, 0, false), "1010", 3, "101", 1, 5},
+		{"full window wraps around", NewValidatorSigningInfo(0, 6, 3, 0, 0, false), "1011", 6, "1110", 1, 8},
+		{"signing window not started", NewValidatorSigningInfo(10, 2, 2, 0, 0, false), "1100", 12, "11", 0, 15},
+		{"no blocks recorded", NewValidatorSigningInfo(0, 0, 0, 0, 0, false), "0000", 0, "", 0, 5},
+	}
+
+	for _, tc := range tests {
+		missed := NewMissedBlocks(tc.info, signed(tc.bits), 4, 2, tc.height)
+		require.Equal(t, tc.expBitmap, missed.Bitmap, tc.name)
+		require.Equal(t, tc.expMissed, missed.MissedCount, tc.name)
+		require.Equal(t, tc.info.SignedBlocksCounter, missed.SignedCount, tc.name)
+		require.Equal(t, tc.expJailHeight, missed.JailingHeight, tc.name)
+	}
+
+	// a validator is never jailed without a minimum of signed blocks
+	missed := NewMissedBlocks(NewValidatorSigningInfo(0, 3, 2, 0, 0, false), signed("1010"), 4, 0, 3)
+	require.Equal(t, int64(0), missed.JailingHeight)
+}
+
+// Test that the projected jailing height is the height at which a validator
+// missing every block is jailed
+func TestMissedBlocksJailingHeight(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	valAddr := sdk.ValAddress(val.Address())
+	window, minSigned := keeper.SignedBlocksWindow(ctx), keeper.MinSignedPerWindow(ctx)
+
+	// sign the first window, then miss 100 blocks
+	height := int64(0)
+	for ; height < window+100; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val, amtInt, height < window)
+	}
+
+	info, found := keeper.getValidatorSigningInfo(ctx, valAddr)
+	require.True(t, found)
+	signed := func(index int64) bool { return keeper.getValidatorSigningBitArray(ctx, valAddr, index) }
+	missed := NewMissedBlocks(info, signed, window, minSigned, height-1)
+	require.Equal(t, strings.Repeat("1", int(window-100))+strings.Repeat("0", 100), missed.Bitmap)
+	require.Equal(t, int64(100), missed.MissedCount)
+	require.Equal(t, window-100, missed.SignedCount)
+	require.Equal(t, height-1+window-100-minSigned+1, missed.JailingHeight)
+
+	// the validator stays bonded until the projected jailing height
+	for ; height < missed.JailingHeight; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val, amtInt, false)
+		require.False(t, sk.Validator(ctx, addr).GetJailed(), "jailed at height %d", height)
+	}
+	ctx = ctx.WithBlockHeight(height)
+	keeper.handleValidatorSignature(ctx, val, amtInt, false)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+}
+
+func TestSigningWindowParams(t *testing.T) {
+	cdc := createTestCodec()
+
+	// unset params take their default
+	window, minSigned, err := SigningWindowParams(cdc, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, defaultSignedBlocksWindow, window)
+	require.Equal(t, sdk.NewRat(defaultSignedBlocksWindow).Mul(defaultMinSignedPerWindow).RoundInt64(), minSigned)
+
+	window, minSigned, err = SigningWindowParams(cdc, cdc.MustMarshalBinary(int64(200)), cdc.MustMarshalBinary(sdk.NewRat(3, 4)))
+	require.Nil(t, err)
+	require.Equal(t, int64(200), window)
+	require.Equal(t, int64(150), minSigned)
+
+	_, _, err = SigningWindowParams(cdc, []byte("invalid"), nil)
+	require.NotNil(t, err)
+}
@@ -6,13 +6,13 @@ import (
 
 // nolint
 const (
-	MaxEvidenceAgeKey           = "slashing/MaxEvidenceAge"
-	SignedBlocksWindowKey       = "slashing/SignedBlocksWindow"
-	MinSignedPerWindowKey       = "slashing/MinSignedPerWindow"
-	DoubleSignUnbondDurationKey = "slashing/DoubleSignUnbondDuration"
-	DowntimeUnbondDurationKey   = "slashing/DowntimeUnbondDuration"
-	SlashFractionDoubleSignKey  = "slashing/SlashFractionDoubleSign"
-	SlashFractionDowntimeKey    = "slashing/SlashFractionDowntime"
+	MaxEvidenceAgeKey          = "slashing/MaxEvidenceAge"
+	SignedBlocksWindowKey      = "slashing/SignedBlocksWindow"
+	MinSignedPerWindowKey      = "slashing/MinSignedPerWindow"
+	DoubleSignJailDurationKey  = "slashing/DoubleSignJailDuration"
+	DowntimeJailDurationKey    = "slashing/DowntimeJailDuration"
+	SlashFractionDoubleSignKey = "slashing/SlashFractionDoubleSign"
+	SlashFractionDowntimeKey   = "slashing/SlashFractionDowntime"
 )
 
 // MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
@@ -29,18 +29,17 @@ func (k Keeper) SignedBlocksWindow(ctx sdk.Context) int64 {
 // Downtime slashing thershold - default 50%
 func (k Keeper) MinSignedPerWindow(ctx sdk.Context) int64 {
 	minSignedPerWindow := k.params.GetRatWithDefault(ctx, MinSignedPerWindowKey, defaultMinSignedPerWindow)
-	signedBlocksWindow := k.SignedBlocksWindow(ctx)
-	return sdk.NewRat(signedBlocksWindow).Mul(minSignedPerWindow).RoundInt64()
+	return minSignedInWindow(k.SignedBlocksWindow(ctx), minSignedPerWindow)
 }
 
-// Double-sign unbond duration
-func (k Keeper) DoubleSignUnbondDuration(ctx sdk.Context) int64 {
-	return k.params.GetInt64WithDefault(ctx, DoubleSignUnbondDurationKey, defaultDoubleSignUnbondDuration)
+// Double-sign jail duration
+func (k Keeper) DoubleSignJailDuration(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, DoubleSignJailDurationKey, defaultDoubleSignJailDuration)
 }
 
-// Downtime unbond duration
-func (k Keeper) DowntimeUnbondDuration(ctx sdk.Context) int64 {
-	return k.params.GetInt64WithDefault(ctx, DowntimeUnbondDurationKey, defaultDowntimeUnbondDuration)
+// Downtime jail duration
+func (k Keeper) DowntimeJailDuration(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, DowntimeJailDurationKey, defaultDowntimeJailDuration)
 }
 
 // SlashFractionDoubleSign - currently default 5%
@@ -62,13 +61,13 @@ var (
 	defaultMaxEvidenceAge int64 = 60 * 2
 
 	// TODO Temporarily set to five minutes for testnets
-	defaultDoubleSignUnbondDuration int64 = 60 * 5
+	defaultDoubleSignJailDuration int64 = 60 * 5
 
 	// TODO Temporarily set to 100 blocks for testnets
 	defaultSignedBlocksWindow int64 = 100
 
 	// TODO Temporarily set to 10 minutes for testnets
-	defaultDowntimeUnbondDuration int64 = 60 * 10
+	defaultDowntimeJailDuration int64 = 60 * 10
 
 	defaultMinSignedPerWindow = sdk.NewRat(1, 2)
 
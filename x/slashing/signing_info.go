@@ -48,27 +48,32 @@ func (k Keeper) setValidatorSigningBitArray(ctx sdk.Context, address sdk.ValAddr
 }
 
 // Construct a new `ValidatorSigningInfo` struct
-func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64) ValidatorSigningInfo {
+func NewValidatorSigningInfo(startHeight int64, indexOffset int64, signedBlocksCounter int64,
+	downtimeJailCount int64, doubleSignCount int64, tombstoned bool) ValidatorSigningInfo {
 	return ValidatorSigningInfo{
 		StartHeight:         startHeight,
 		IndexOffset:         indexOffset,
-		JailedUntil:         jailedUntil,
 		SignedBlocksCounter: signedBlocksCounter,
+		DowntimeJailCount:   downtimeJailCount,
+		DoubleSignCount:     doubleSignCount,
+		Tombstoned:          tombstoned,
 	}
 }
 
 // Signing info for a validator
 type ValidatorSigningInfo struct {
-	StartHeight         int64 `json:"start_height"`          // height at which validator was first a candidate OR was unrevoked
+	StartHeight         int64 `json:"start_height"`          // height at which validator was first a candidate OR was unjailed
 	IndexOffset         int64 `json:"index_offset"`          // index offset into signed block bit array
-	JailedUntil         int64 `json:"jailed_until"`          // timestamp validator cannot be unrevoked until
 	SignedBlocksCounter int64 `json:"signed_blocks_counter"` // signed blocks counter (to avoid scanning the array every time)
+	DowntimeJailCount   int64 `json:"downtime_jail_count"`   // number of times the validator was jailed for downtime
+	DoubleSignCount     int64 `json:"double_sign_count"`     // number of times the validator was slashed for double signing
+	Tombstoned          bool  `json:"tombstoned"`            // whether the validator double signed, it can then never be unjailed
 }
 
 // Return human readable signing info
 func (i ValidatorSigningInfo) HumanReadableString() string {
-	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %d, signed blocks counter: %d",
-		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter)
+	return fmt.Sprintf("Start height: %d, index offset: %d, signed blocks counter: %d, downtime jail count: %d, double sign count: %d, tombstoned: %t",
+		i.StartHeight, i.IndexOffset, i.SignedBlocksCounter, i.DowntimeJailCount, i.DoubleSignCount, i.Tombstoned)
 }
 
 // Stored by *validator* address (not owner address)
@@ -80,5 +85,15 @@ func GetValidatorSigningInfoKey(v sdk.ValAddress) []byte {
 func GetValidatorSigningBitArrayKey(v sdk.ValAddress, i int64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, uint64(i))
-	return append([]byte{0x02}, append(v.Bytes(), b...)...)
+	return append(GetValidatorSigningBitArrayPrefixKey(v), b...)
+}
+
+// Prefix of the signed block bit array of a validator
+func GetValidatorSigningBitArrayPrefixKey(v sdk.ValAddress) []byte {
+	return append([]byte{0x02}, v.Bytes()...)
+}
+
+// Index of the signed block bit array key of a validator
+func GetValidatorSigningBitArrayIndex(key []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(key[len(key)-8:]))
 }
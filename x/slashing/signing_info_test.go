@@ -15,16 +15,18 @@ func TestGetSetValidatorSigningInfo(t *testing.T) {
 	newInfo := ValidatorSigningInfo{
 		StartHeight:         int64(4),
 		IndexOffset:         int64(3),
-		JailedUntil:         int64(2),
 		SignedBlocksCounter: int64(10),
+		DowntimeJailCount:   int64(2),
+		DoubleSignCount:     int64(1),
 	}
 	keeper.setValidatorSigningInfo(ctx, sdk.ValAddress(addrs[0]), newInfo)
 	info, found = keeper.getValidatorSigningInfo(ctx, sdk.ValAddress(addrs[0]))
 	require.True(t, found)
 	require.Equal(t, info.StartHeight, int64(4))
 	require.Equal(t, info.IndexOffset, int64(3))
-	require.Equal(t, info.JailedUntil, int64(2))
 	require.Equal(t, info.SignedBlocksCounter, int64(10))
+	require.Equal(t, info.DowntimeJailCount, int64(2))
+	require.Equal(t, info.DoubleSignCount, int64(1))
 }
 
 func TestGetSetValidatorSigningBitArray(t *testing.T) {
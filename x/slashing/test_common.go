@@ -11,12 +11,14 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
@@ -43,29 +45,44 @@ func createTestCodec() *wire.Codec {
 	auth.RegisterWire(cdc)
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
+	evidence.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
 	return cdc
 }
 
 func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, params.Setter, Keeper) {
+	ctx, ck, sk, setter, keeper, _ := createTestInputWithEvidence(t)
+	return ctx, ck, sk, setter, keeper
+}
+
+// test input with an evidence keeper routing duplicate votes to the keeper
+func createTestInputWithEvidence(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, params.Setter, Keeper, evidence.Keeper) {
 	keyAcc := sdk.NewKVStoreKey("acc")
 	keyStake := sdk.NewKVStoreKey("stake")
 	keySlashing := sdk.NewKVStoreKey("slashing")
 	keyParams := sdk.NewKVStoreKey("params")
+	keySupply := sdk.NewKVStoreKey("supply")
+	keyEvidence := sdk.NewKVStoreKey("evidence")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySlashing, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyEvidence, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewTMLogger(os.Stdout))
 	cdc := createTestCodec()
 	accountMapper := auth.NewAccountMapper(cdc, keyAcc, auth.ProtoBaseAccount)
-	ck := bank.NewKeeper(accountMapper)
 	params := params.NewKeeper(cdc, keyParams)
-	sk := stake.NewKeeper(cdc, keyStake, ck, stake.DefaultCodespace)
+	ck := bank.NewKeeper(accountMapper, params.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(cdc, keySupply, ck, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+	})
+	sk := stake.NewKeeper(cdc, keyStake, ck, supplyKeeper, stake.DefaultCodespace)
 	genesis := stake.DefaultGenesisState()
 
 	genesis.Pool.LooseTokens = sdk.NewRat(initCoins.MulRaw(int64(len(addrs))).Int64())
@@ -74,13 +91,24 @@ func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, para
 	require.Nil(t, err)
 
 	for _, addr := range addrs {
-		_, _, err = ck.AddCoins(ctx, addr, sdk.Coins{
+		coins := sdk.Coins{
 			{sk.GetParams(ctx).BondDenom, initCoins},
-		})
+		}
+		_, _, err = ck.AddCoins(ctx, addr, coins)
+		supplyKeeper.Inflate(ctx, coins)
 	}
 	require.Nil(t, err)
 	keeper := NewKeeper(cdc, keySlashing, sk, params.Getter(), DefaultCodespace)
-	return ctx, ck, sk, params.Setter(), keeper
+	sk.RegisterHooks(keeper.Hooks())
+	ek := evidence.NewKeeper(cdc, keyEvidence, sk, keeper.MaxEvidenceAge, evidence.DefaultCodespace)
+	ek.RegisterHandler(tmtypes.ABCIEvidenceTypeDuplicateVote, keeper.HandleDoubleSign, keeper.DoubleSignPolicy())
+	return ctx, ck, sk, params.Setter(), keeper, ek
+}
+
+// evidence of a validator signing two blocks at the same height, as reported
+// by tendermint
+func newTestDoubleSign(pubKey crypto.PubKey, height, time, power int64) evidence.ABCIEvidence {
+	return evidence.NewABCIEvidence(tmtypes.ABCIEvidenceTypeDuplicateVote, pubKey, height, time, power)
 }
 
 func newPubKey(pk string) (res crypto.PubKey) {
@@ -100,10 +128,11 @@ func testAddr(addr string) sdk.AccAddress {
 
 func newTestMsgCreateValidator(address sdk.AccAddress, pubKey crypto.PubKey, amt sdk.Int) stake.MsgCreateValidator {
 	return stake.MsgCreateValidator{
-		Description:   stake.Description{},
-		DelegatorAddr: address,
-		ValidatorAddr: address,
-		PubKey:        pubKey,
-		Delegation:    sdk.Coin{"steak", amt},
+		Description:       stake.Description{},
+		DelegatorAddr:     address,
+		ValidatorAddr:     address,
+		PubKey:            pubKey,
+		Delegation:        sdk.Coin{"steak", amt},
+		MinSelfDelegation: sdk.OneInt(),
 	}
 }
@@ -2,7 +2,6 @@ package slashing
 
 import (
 	"encoding/binary"
-	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -28,21 +27,8 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 		sk.handleValidatorSignature(ctx, pubkey, signingValidator.Validator.Power, present)
 	}
 
-	// Iterate through any newly discovered evidence of infraction
-	// Slash any validators (and since-unbonded stake within the unbonding period)
-	// who contributed to valid infractions
-	for _, evidence := range req.ByzantineValidators {
-		pk, err := tmtypes.PB2TM.PubKey(evidence.Validator.PubKey)
-		if err != nil {
-			panic(err)
-		}
-		switch evidence.Type {
-		case tmtypes.ABCIEvidenceTypeDuplicateVote:
-			sk.handleDoubleSign(ctx, pk, evidence.Height, evidence.Time, evidence.Validator.Power)
-		default:
-			ctx.Logger().With("module", "x/slashing").Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
-		}
-	}
+	// The evidence of infraction is handled by the evidence module, which
+	// routes duplicate votes to HandleDoubleSign
 
 	return
 }
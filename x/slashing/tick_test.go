@@ -41,8 +41,8 @@ func TestBeginBlocker(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, ctx.BlockHeight(), info.StartHeight)
 	require.Equal(t, int64(1), info.IndexOffset)
-	require.Equal(t, int64(0), info.JailedUntil)
 	require.Equal(t, int64(1), info.SignedBlocksCounter)
+	require.Equal(t, int64(0), info.DowntimeJailCount)
 
 	height := int64(0)
 
@@ -70,8 +70,9 @@ func TestBeginBlocker(t *testing.T) {
 		BeginBlocker(ctx, req, keeper)
 	}
 
-	// validator should be revoked
+	// validator should be jailed
 	validator, found := sk.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	require.Equal(t, sdk.Unbonded, validator.GetStatus())
+	require.True(t, validator.GetJailed())
 }
@@ -2,6 +2,8 @@ package cli
 
 import (
 	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
 // nolint
@@ -14,6 +16,9 @@ const (
 	FlagAmount              = "amount"
 	FlagSharesAmount        = "shares-amount"
 	FlagSharesPercent       = "shares-percent"
+	FlagMinSelfDelegation   = "min-self-delegation"
+	FlagPage                = "page"
+	FlagLimit               = "limit"
 
 	FlagMoniker  = "moniker"
 	FlagIdentity = "keybase-sig"
@@ -30,6 +35,7 @@ var (
 	fsValidator    = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDelegator    = flag.NewFlagSet("", flag.ContinueOnError)
 	fsRedelegation = flag.NewFlagSet("", flag.ContinueOnError)
+	fsPagination   = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -37,12 +43,14 @@ func init() {
 	fsAmount.String(FlagAmount, "1steak", "Amount of coins to bond")
 	fsShares.String(FlagSharesAmount, "", "Amount of source-shares to either unbond or redelegate as a positive integer or decimal")
 	fsShares.String(FlagSharesPercent, "", "Percent of source-shares to either unbond or redelegate as a positive integer or decimal >0 and <=1")
-	fsDescription.String(FlagMoniker, "[do-not-modify]", "validator name")
-	fsDescription.String(FlagIdentity, "[do-not-modify]", "optional keybase signature")
-	fsDescription.String(FlagWebsite, "[do-not-modify]", "optional website")
-	fsDescription.String(FlagDetails, "[do-not-modify]", "optional details")
+	fsDescription.String(FlagMoniker, stake.DoNotModifyDesc, "validator name")
+	fsDescription.String(FlagIdentity, stake.DoNotModifyDesc, "optional keybase signature")
+	fsDescription.String(FlagWebsite, stake.DoNotModifyDesc, "optional website")
+	fsDescription.String(FlagDetails, stake.DoNotModifyDesc, "optional details")
 	fsValidator.String(FlagAddressValidator, "", "hex address of the validator")
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
 	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
 	fsRedelegation.String(FlagAddressValidatorDst, "", "hex address of the destination validator")
+	fsPagination.Int(FlagPage, 1, "Page of the results to return, starting at 1")
+	fsPagination.Int(FlagLimit, 0, "Number of results per page, 0 returns all the results")
 }
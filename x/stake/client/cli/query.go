@@ -11,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakeclient "github.com/cosmos/cosmos-sdk/x/stake/client"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -18,23 +19,38 @@ import (
 func GetCmdQueryValidator(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validator [owner-addr]",
-		Short: "Query a validator",
-		Args:  cobra.ExactArgs(1),
+		Short: "Query a validator by owner address or by --moniker",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-
-			addr, err := sdk.AccAddressFromBech32(args[0])
-			if err != nil {
-				return err
-			}
-			key := stake.GetValidatorKey(addr)
 			ctx := context.NewCoreContextFromViper()
-			res, err := ctx.QueryStore(key, storeName)
-			if err != nil {
-				return err
-			} else if len(res) == 0 {
-				return fmt.Errorf("No validator found with address %s", args[0])
+
+			var validator stake.Validator
+			if moniker := viper.GetString(FlagMoniker); moniker != "" {
+				if len(args) != 0 {
+					return fmt.Errorf("cannot query by both owner address and moniker")
+				}
+				var err error
+				validator, err = stakeclient.QueryValidatorByMoniker(ctx, cdc, storeName, moniker)
+				if err != nil {
+					return err
+				}
+			} else {
+				if len(args) != 1 {
+					return fmt.Errorf("please provide the owner address of the validator or use --moniker")
+				}
+				addr, err := sdk.AccAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				key := stake.GetValidatorKey(addr)
+				res, err := ctx.QueryStore(key, storeName)
+				if err != nil {
+					return err
+				} else if len(res) == 0 {
+					return fmt.Errorf("No validator found with address %s", args[0])
+				}
+				validator = types.MustUnmarshalValidator(cdc, addr, res)
 			}
-			validator := types.MustUnmarshalValidator(cdc, addr, res)
 
 			switch viper.Get(cli.OutputFlag) {
 			case "text":
@@ -57,6 +73,7 @@ func GetCmdQueryValidator(storeName string, cdc *wire.Codec) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(FlagMoniker, "", "moniker of the validator to query")
 	return cmd
 }
 
@@ -129,6 +146,8 @@ func GetCmdQueryDelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 			res, err := ctx.QueryStore(key, storeName)
 			if err != nil {
 				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("No delegation found from %s to %s", delAddr, valAddr)
 			}
 
 			// parse out the delegation
@@ -219,6 +238,8 @@ func GetCmdQueryUnbondingDelegation(storeName string, cdc *wire.Codec) *cobra.Co
 			res, err := ctx.QueryStore(key, storeName)
 			if err != nil {
 				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("No unbonding delegation found from %s to %s", delAddr, valAddr)
 			}
 
 			// parse out the unbonding delegation
@@ -287,11 +308,11 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.C
 	return cmd
 }
 
-// get the command to query a single unbonding-delegation record
+// get the command to query a single redelegation record
 func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unbonding-delegation",
-		Short: "Query an unbonding-delegation record based on delegator and validator address",
+		Use:   "redelegation",
+		Short: "Query a redelegation record based on delegator and source and destination validator addresses",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			valSrcAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressValidatorSrc))
@@ -312,9 +333,11 @@ func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 			res, err := ctx.QueryStore(key, storeName)
 			if err != nil {
 				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("No redelegation found from %s to %s by %s", valSrcAddr, valDstAddr, delAddr)
 			}
 
-			// parse out the unbonding delegation
+			// parse out the redelegation
 			red := types.MustUnmarshalRED(cdc, key, res)
 
 			switch viper.Get(cli.OutputFlag) {
@@ -341,11 +364,11 @@ func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
-// get the command to query all the unbonding-delegation records for a delegator
+// get the command to query all the redelegation records for a delegator
 func GetCmdQueryRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unbonding-delegations [delegator-addr]",
-		Short: "Query all unbonding-delegations records for one delegator",
+		Use:   "redelegations [delegator-addr]",
+		Short: "Query all redelegations records for one delegator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -379,3 +402,176 @@ func GetCmdQueryRedelegations(storeName string, cdc *wire.Codec) *cobra.Command
 	}
 	return cmd
 }
+
+// get the command to query all the delegations, unbonding delegations and
+// redelegations of a delegator
+func GetCmdQueryDelegatorSummary(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator-summary [delegator-addr]",
+		Short: "Query all delegations, unbonding-delegations and redelegations of one delegator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			summary, err := stakeclient.QueryDelegatorSummary(ctx, cdc, storeName, delegatorAddr,
+				viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, summary)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPagination)
+	return cmd
+}
+
+// get the command to query the validators a delegator is bonded to
+func GetCmdQueryDelegatorValidators(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator-validators [delegator-addr]",
+		Short: "Query all validators one delegator is bonded to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			validators, err := stakeclient.QueryDelegatorValidators(ctx, cdc, storeName, delegatorAddr)
+			if err != nil {
+				return err
+			}
+			start, end := stakeclient.Paginate(len(validators), viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+			validators = validators[start:end]
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, validator := range validators {
+					resp, err := validator.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, validators)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPagination)
+	return cmd
+}
+
+// get the command to query all the delegations to a validator
+func GetCmdQueryValidatorDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-delegations [owner-addr]",
+		Short: "Query all delegations to one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			delegations, err := stakeclient.QueryValidatorDelegations(ctx, cdc, storeName, validatorAddr)
+			if err != nil {
+				return err
+			}
+			start, end := stakeclient.Paginate(len(delegations), viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+
+			output, err := wire.MarshalJSONIndent(cdc, delegations[start:end])
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPagination)
+	return cmd
+}
+
+// get the command to query all the unbonding-delegations from a validator
+func GetCmdQueryValidatorUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-unbonding-delegations [owner-addr]",
+		Short: "Query all unbonding-delegations from one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			ubds, err := stakeclient.QueryValidatorUnbondingDelegations(ctx, cdc, storeName, validatorAddr)
+			if err != nil {
+				return err
+			}
+			start, end := stakeclient.Paginate(len(ubds), viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+
+			output, err := wire.MarshalJSONIndent(cdc, ubds[start:end])
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPagination)
+	return cmd
+}
+
+// get the command to query all the redelegations away from a validator
+func GetCmdQueryValidatorRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-redelegations [owner-addr]",
+		Short: "Query all redelegations away from one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.NewCoreContextFromViper()
+			reds, err := stakeclient.QueryValidatorRedelegations(ctx, cdc, storeName, validatorAddr)
+			if err != nil {
+				return err
+			}
+			start, end := stakeclient.Paginate(len(reds), viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+
+			output, err := wire.MarshalJSONIndent(cdc, reds[start:end])
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPagination)
+	return cmd
+}
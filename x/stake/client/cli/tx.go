@@ -41,7 +41,7 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if viper.GetString(FlagMoniker) == "" {
+			if moniker := viper.GetString(FlagMoniker); moniker == "" || moniker == stake.DoNotModifyDesc {
 				return fmt.Errorf("please enter a moniker for the validator using --moniker")
 			}
 			description := stake.Description{
@@ -50,6 +50,10 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
+			minSelfDelegation, ok := sdk.NewIntFromString(viper.GetString(FlagMinSelfDelegation))
+			if !ok {
+				return fmt.Errorf("minimum self delegation must be a positive integer")
+			}
 
 			var msg sdk.Msg
 			if viper.GetString(FlagAddressDelegator) != "" {
@@ -57,9 +61,9 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 				if err != nil {
 					return err
 				}
-				msg = stake.NewMsgCreateValidatorOnBehalfOf(delegatorAddr, validatorAddr, pk, amount, description)
+				msg = stake.NewMsgCreateValidatorOnBehalfOf(delegatorAddr, validatorAddr, pk, amount, description, minSelfDelegation)
 			} else {
-				msg = stake.NewMsgCreateValidator(validatorAddr, pk, amount, description)
+				msg = stake.NewMsgCreateValidator(validatorAddr, pk, amount, description, minSelfDelegation)
 			}
 
 			// build and sign the transaction, then broadcast to Tendermint
@@ -75,6 +79,7 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescription)
 	cmd.Flags().AddFlagSet(fsDelegator)
+	cmd.Flags().String(FlagMinSelfDelegation, "1", "Minimum self delegation of the validator, it is jailed when its owner's delegation falls below it")
 	return cmd
 }
 
@@ -96,7 +101,17 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 				Website:  viper.GetString(FlagWebsite),
 				Details:  viper.GetString(FlagDetails),
 			}
-			msg := stake.NewMsgEditValidator(validatorAddr, description)
+
+			var newMinSelfDelegation *sdk.Int
+			if minSelfDelegationStr := viper.GetString(FlagMinSelfDelegation); minSelfDelegationStr != "" {
+				minSelfDelegation, ok := sdk.NewIntFromString(minSelfDelegationStr)
+				if !ok {
+					return fmt.Errorf("minimum self delegation must be a positive integer")
+				}
+				newMinSelfDelegation = &minSelfDelegation
+			}
+
+			msg := stake.NewMsgEditValidator(validatorAddr, description, newMinSelfDelegation)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
@@ -109,6 +124,7 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().AddFlagSet(fsDescription)
+	cmd.Flags().String(FlagMinSelfDelegation, "", "New minimum self delegation of the validator, it can only be increased")
 	return cmd
 }
 
@@ -160,7 +176,6 @@ func GetCmdRedelegate(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd.AddCommand(
 		client.PostCommands(
 			GetCmdBeginRedelegate(storeName, cdc),
-			GetCmdCompleteRedelegate(cdc),
 		)...)
 	return cmd
 }
@@ -254,52 +269,15 @@ func getShares(storeName string, cdc *wire.Codec, sharesAmountStr, sharesPercent
 	return
 }
 
-// redelegate command
-func GetCmdCompleteRedelegate(cdc *wire.Codec) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "complete",
-		Short: "complete redelegation",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
-
-			delegatorAddr, err := ctx.GetFromAddress()
-			if err != nil {
-				return err
-			}
-			validatorSrcAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressValidatorSrc))
-			if err != nil {
-				return err
-			}
-			validatorDstAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressValidatorDst))
-			if err != nil {
-				return err
-			}
-
-			msg := stake.NewMsgCompleteRedelegate(delegatorAddr, validatorSrcAddr, validatorDstAddr)
-
-			// build and sign the transaction, then broadcast to Tendermint
-			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
-			if err != nil {
-				return err
-			}
-
-			return nil
-		},
-	}
-	cmd.Flags().AddFlagSet(fsRedelegation)
-	return cmd
-}
-
 // create edit validator command
 func GetCmdUnbond(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "unbond",
-		Short: "begin or complete unbonding shares from a validator",
+		Short: "begin unbonding shares from a validator",
 	}
 	cmd.AddCommand(
 		client.PostCommands(
 			GetCmdBeginUnbonding(storeName, cdc),
-			GetCmdCompleteUnbonding(cdc),
 		)...)
 	return cmd
 }
@@ -346,35 +324,3 @@ func GetCmdBeginUnbonding(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
-
-// create edit validator command
-func GetCmdCompleteUnbonding(cdc *wire.Codec) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "complete",
-		Short: "complete unbonding",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
-
-			delegatorAddr, err := ctx.GetFromAddress()
-			if err != nil {
-				return err
-			}
-			validatorAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressValidator))
-			if err != nil {
-				return err
-			}
-
-			msg := stake.NewMsgCompleteUnbonding(delegatorAddr, validatorAddr)
-
-			// build and sign the transaction, then broadcast to Tendermint
-			err = ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
-			if err != nil {
-				return err
-			}
-
-			return nil
-		},
-	}
-	cmd.Flags().AddFlagSet(fsValidator)
-	return cmd
-}
@@ -0,0 +1,309 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// DelegationSummary is a delegation along with the tokens its shares are
+// currently worth
+type DelegationSummary struct {
+	Delegation stake.Delegation `json:"delegation"`
+	Tokens     sdk.Rat          `json:"tokens"`
+}
+
+// DelegatorSummary holds all the delegations, unbonding delegations and
+// redelegations of a delegator
+type DelegatorSummary struct {
+	Delegations          []DelegationSummary         `json:"delegations"`
+	UnbondingDelegations []stake.UnbondingDelegation `json:"unbonding_delegations"`
+	Redelegations        []stake.Redelegation        `json:"redelegations"`
+}
+
+// Paginate returns the bounds of a page of a list of the given length, pages
+// start at 1 and a limit of 0 returns the whole list
+func Paginate(length, page, limit int) (start, end int) {
+	if limit <= 0 {
+		return 0, length
+	}
+	if page < 1 {
+		page = 1
+	}
+	start = (page - 1) * limit
+	if start > length {
+		start = length
+	}
+	end = start + limit
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+// QueryValidator queries a validator by owner address
+func QueryValidator(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	validatorAddr sdk.AccAddress) (validator stake.Validator, err error) {
+
+	res, err := ctx.QueryStore(stake.GetValidatorKey(validatorAddr), storeName)
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		return validator, fmt.Errorf("no validator found with address %s", validatorAddr)
+	}
+	return types.UnmarshalValidator(cdc, validatorAddr, res)
+}
+
+// QueryValidatorByMoniker queries a validator by moniker, monikers are matched
+// case-insensitively
+func QueryValidatorByMoniker(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	moniker string) (validator stake.Validator, err error) {
+
+	res, err := ctx.QueryStore(stake.GetValidatorByMonikerIndexKey(moniker), storeName)
+	if err != nil {
+		return
+	}
+	if len(res) == 0 {
+		return validator, fmt.Errorf("no validator found with moniker %s", moniker)
+	}
+	return QueryValidator(ctx, cdc, storeName, res)
+}
+
+// QueryDelegatorDelegations queries all the delegations of a delegator along
+// with their current token values
+func QueryDelegatorDelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	delegatorAddr sdk.AccAddress) ([]DelegationSummary, error) {
+
+	ctx, err := ctx.WithLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetDelegationsKey(delegatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeDelegations(ctx, cdc, storeName, kvs)
+}
+
+// QueryDelegatorUnbondingDelegations queries all the unbonding delegations of
+// a delegator
+func QueryDelegatorUnbondingDelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	delegatorAddr sdk.AccAddress) ([]stake.UnbondingDelegation, error) {
+
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetUBDsKey(delegatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	ubds := make([]stake.UnbondingDelegation, 0, len(kvs))
+	for _, kv := range kvs {
+		ubd, err := types.UnmarshalUBD(cdc, kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		ubds = append(ubds, ubd)
+	}
+	return ubds, nil
+}
+
+// QueryDelegatorRedelegations queries all the redelegations of a delegator
+func QueryDelegatorRedelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	delegatorAddr sdk.AccAddress) ([]stake.Redelegation, error) {
+
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetREDsKey(delegatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	reds := make([]stake.Redelegation, 0, len(kvs))
+	for _, kv := range kvs {
+		red, err := types.UnmarshalRED(cdc, kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		reds = append(reds, red)
+	}
+	return reds, nil
+}
+
+// QueryDelegatorSummary queries all the delegations, unbonding delegations and
+// redelegations of a delegator at a single height, each list is paginated
+// separately
+func QueryDelegatorSummary(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	delegatorAddr sdk.AccAddress, page, limit int) (summary DelegatorSummary, err error) {
+
+	ctx, err = ctx.WithLatestHeight()
+	if err != nil {
+		return
+	}
+	delegations, err := QueryDelegatorDelegations(ctx, cdc, storeName, delegatorAddr)
+	if err != nil {
+		return
+	}
+	start, end := Paginate(len(delegations), page, limit)
+	summary.Delegations = delegations[start:end]
+
+	ubds, err := QueryDelegatorUnbondingDelegations(ctx, cdc, storeName, delegatorAddr)
+	if err != nil {
+		return
+	}
+	start, end = Paginate(len(ubds), page, limit)
+	summary.UnbondingDelegations = ubds[start:end]
+
+	reds, err := QueryDelegatorRedelegations(ctx, cdc, storeName, delegatorAddr)
+	if err != nil {
+		return
+	}
+	start, end = Paginate(len(reds), page, limit)
+	summary.Redelegations = reds[start:end]
+	return
+}
+
+// QueryDelegatorValidators queries the validators a delegator is bonded to
+func QueryDelegatorValidators(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	delegatorAddr sdk.AccAddress) ([]stake.Validator, error) {
+
+	ctx, err := ctx.WithLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetDelegationsKey(delegatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]stake.Validator, 0, len(kvs))
+	for _, kv := range kvs {
+		delegation, err := types.UnmarshalDelegation(cdc, kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		validator, err := QueryValidator(ctx, cdc, storeName, delegation.ValidatorAddr)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+// QueryValidatorDelegations queries all the delegations to a validator along
+// with their current token values
+func QueryValidatorDelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	validatorAddr sdk.AccAddress) ([]DelegationSummary, error) {
+
+	ctx, err := ctx.WithLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	validator, err := QueryValidator(ctx, cdc, storeName, validatorAddr)
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetDelegationsByValIndexKey(validatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]DelegationSummary, 0, len(kvs))
+	for _, kv := range kvs {
+		key := stake.GetDelegationKeyFromValIndexKey(kv.Key)
+		res, err := ctx.QueryStore(key, storeName)
+		if err != nil {
+			return nil, err
+		}
+		delegation, err := types.UnmarshalDelegation(cdc, key, res)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summarizeDelegation(validator, delegation))
+	}
+	return summaries, nil
+}
+
+// QueryValidatorUnbondingDelegations queries all the unbonding delegations
+// from a validator
+func QueryValidatorUnbondingDelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	validatorAddr sdk.AccAddress) ([]stake.UnbondingDelegation, error) {
+
+	ctx, err := ctx.WithLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetUBDsByValIndexKey(validatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	ubds := make([]stake.UnbondingDelegation, 0, len(kvs))
+	for _, kv := range kvs {
+		key := stake.GetUBDKeyFromValIndexKey(kv.Key)
+		res, err := ctx.QueryStore(key, storeName)
+		if err != nil {
+			return nil, err
+		}
+		ubd, err := types.UnmarshalUBD(cdc, key, res)
+		if err != nil {
+			return nil, err
+		}
+		ubds = append(ubds, ubd)
+	}
+	return ubds, nil
+}
+
+// QueryValidatorRedelegations queries all the redelegations away from a
+// validator
+func QueryValidatorRedelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	validatorAddr sdk.AccAddress) ([]stake.Redelegation, error) {
+
+	ctx, err := ctx.WithLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := ctx.QuerySubspace(cdc, stake.GetREDsFromValSrcIndexKey(validatorAddr), storeName)
+	if err != nil {
+		return nil, err
+	}
+	reds := make([]stake.Redelegation, 0, len(kvs))
+	for _, kv := range kvs {
+		key := stake.GetREDKeyFromValSrcIndexKey(kv.Key)
+		res, err := ctx.QueryStore(key, storeName)
+		if err != nil {
+			return nil, err
+		}
+		red, err := types.UnmarshalRED(cdc, key, res)
+		if err != nil {
+			return nil, err
+		}
+		reds = append(reds, red)
+	}
+	return reds, nil
+}
+
+// value the delegations of a store subspace at the exchange rate of their
+// validator
+func summarizeDelegations(ctx context.CoreContext, cdc *wire.Codec, storeName string,
+	kvs []sdk.KVPair) ([]DelegationSummary, error) {
+
+	summaries := make([]DelegationSummary, 0, len(kvs))
+	for _, kv := range kvs {
+		delegation, err := types.UnmarshalDelegation(cdc, kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		validator, err := QueryValidator(ctx, cdc, storeName, delegation.ValidatorAddr)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summarizeDelegation(validator, delegation))
+	}
+	return summaries, nil
+}
+
+// value a delegation at the exchange rate of its validator
+func summarizeDelegation(validator stake.Validator, delegation stake.Delegation) DelegationSummary {
+	return DelegationSummary{
+		Delegation: delegation,
+		Tokens:     validator.DelegatorShareExRate().Mul(delegation.Shares),
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name                string
+		length, page, limit int
+		expStart, expEnd    int
+	}{
+		{"no limit", 10, 1, 0, 0, 10},
+		{"no limit ignores page", 10, 3, 0, 0, 10},
+		{"first page", 10, 1, 4, 0, 4},
+		{"middle page", 10, 2, 4, 4, 8},
+		{"last partial page", 10, 3, 4, 8, 10},
+		{"page past the end", 10, 4, 4, 10, 10},
+		{"page below one", 10, 0, 4, 0, 4},
+		{"empty list", 0, 1, 4, 0, 0},
+	}
+
+	for _, tc := range tests {
+		start, end := Paginate(tc.length, tc.page, tc.limit)
+		require.Equal(t, tc.expStart, start, tc.name)
+		require.Equal(t, tc.expEnd, end, tc.name)
+	}
+}
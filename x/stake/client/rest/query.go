@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -11,10 +12,21 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakeclient "github.com/cosmos/cosmos-sdk/x/stake/client"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
-const storeName = "stake"
+const (
+	storeName = "stake"
+
+	// RestHeight is the optional query parameter of the height to query at
+	RestHeight = "height"
+
+	// RestPage and RestLimit are the optional query parameters paginating
+	// lists, pages start at 1 and a limit of 0 returns the whole list
+	RestPage  = "page"
+	RestLimit = "limit"
+)
 
 func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec) {
 
@@ -37,11 +49,42 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 		"/stake/validators",
 		validatorsHandlerFn(ctx, cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/delegators/{delegator}",
+		delegatorSummaryHandlerFn(ctx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/delegators/{delegator}/validators",
+		delegatorValidatorsHandlerFn(ctx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/delegations",
+		validatorDelegationsHandlerFn(ctx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/unbonding_delegations",
+		validatorUBDsHandlerFn(ctx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/stake/validators/{validator}/redelegations",
+		validatorREDsHandlerFn(ctx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query a delegation
 func delegationHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -98,6 +141,12 @@ func delegationHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 // http request handler to query an unbonding-delegation
 func ubdHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -154,6 +203,12 @@ func ubdHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 // http request handler to query an redelegation
 func redHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -219,6 +274,12 @@ func redHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 // http request handler to query list of validators
 func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		kvs, err := ctx.QuerySubspace(cdc, stake.ValidatorsKey, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -263,3 +324,262 @@ func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 		w.Write(output)
 	}
 }
+
+// http request handler to query all the delegations, unbonding delegations
+// and redelegations of a delegator
+func delegatorSummaryHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := parsePagination(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		summary, err := stakeclient.QueryDelegatorSummary(ctx, cdc, storeName, delegatorAddr, page, limit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query delegator. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(summary)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the validators a delegator is bonded to
+func delegatorValidatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := parsePagination(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		validators, err := stakeclient.QueryDelegatorValidators(ctx, cdc, storeName, delegatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query delegator validators. Error: %s", err.Error())))
+			return
+		}
+		start, end := stakeclient.Paginate(len(validators), page, limit)
+		validators = validators[start:end]
+
+		bech32Validators := make([]types.BechValidator, len(validators))
+		for i, validator := range validators {
+			bech32Validators[i], err = validator.Bech32Validator()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(bech32Validators)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query all the delegations to a validator
+func validatorDelegationsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		validatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["validator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := parsePagination(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		delegations, err := stakeclient.QueryValidatorDelegations(ctx, cdc, storeName, validatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query validator delegations. Error: %s", err.Error())))
+			return
+		}
+		start, end := stakeclient.Paginate(len(delegations), page, limit)
+
+		output, err := cdc.MarshalJSON(delegations[start:end])
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query all the unbonding delegations from a validator
+func validatorUBDsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		validatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["validator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := parsePagination(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		ubds, err := stakeclient.QueryValidatorUnbondingDelegations(ctx, cdc, storeName, validatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query validator unbonding-delegations. Error: %s", err.Error())))
+			return
+		}
+		start, end := stakeclient.Paginate(len(ubds), page, limit)
+
+		output, err := cdc.MarshalJSON(ubds[start:end])
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query all the redelegations away from a validator
+func validatorREDsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withQueryHeight(ctx, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		validatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["validator"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := parsePagination(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		reds, err := stakeclient.QueryValidatorRedelegations(ctx, cdc, storeName, validatorAddr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("couldn't query validator redelegations. Error: %s", err.Error())))
+			return
+		}
+		start, end := stakeclient.Paginate(len(reds), page, limit)
+
+		output, err := cdc.MarshalJSON(reds[start:end])
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// set the height of the queries from the optional height parameter, the
+// latest height is queried if it is omitted
+func withQueryHeight(ctx context.CoreContext, r *http.Request) (context.CoreContext, error) {
+	heightStr := r.URL.Query().Get(RestHeight)
+	if heightStr == "" {
+		return ctx, nil
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		return ctx, fmt.Errorf("height must be a non-negative integer, got %s", heightStr)
+	}
+	return ctx.WithHeight(height), nil
+}
+
+// parse the optional page and limit parameters, the whole list is returned if
+// they are omitted
+func parsePagination(r *http.Request) (page, limit int, err error) {
+	page, limit = 1, 0
+	if pageStr := r.URL.Query().Get(RestPage); pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("page must be a positive integer, got %s", pageStr)
+		}
+	}
+	if limitStr := r.URL.Query().Get(RestLimit); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("limit must be a non-negative integer, got %s", limitStr)
+		}
+	}
+	return page, limit, nil
+}
@@ -35,34 +35,23 @@ type msgBeginRedelegateInput struct {
 	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
 	SharesAmount     string `json:"shares"`
 }
-type msgCompleteRedelegateInput struct {
-	DelegatorAddr    string `json:"delegator_addr"`     // in bech32
-	ValidatorSrcAddr string `json:"validator_src_addr"` // in bech32
-	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
-}
 type msgBeginUnbondingInput struct {
 	DelegatorAddr string `json:"delegator_addr"` // in bech32
 	ValidatorAddr string `json:"validator_addr"` // in bech32
 	SharesAmount  string `json:"shares"`
 }
-type msgCompleteUnbondingInput struct {
-	DelegatorAddr string `json:"delegator_addr"` // in bech32
-	ValidatorAddr string `json:"validator_addr"` // in bech32
-}
 
 // request body for edit delegations
 type EditDelegationsBody struct {
-	LocalAccountName    string                       `json:"name"`
-	Password            string                       `json:"password"`
-	ChainID             string                       `json:"chain_id"`
-	AccountNumber       int64                        `json:"account_number"`
-	Sequence            int64                        `json:"sequence"`
-	Gas                 int64                        `json:"gas"`
-	Delegations         []msgDelegationsInput        `json:"delegations"`
-	BeginUnbondings     []msgBeginUnbondingInput     `json:"begin_unbondings"`
-	CompleteUnbondings  []msgCompleteUnbondingInput  `json:"complete_unbondings"`
-	BeginRedelegates    []msgBeginRedelegateInput    `json:"begin_redelegates"`
-	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"`
+	LocalAccountName string                    `json:"name"`
+	Password         string                    `json:"password"`
+	ChainID          string                    `json:"chain_id"`
+	AccountNumber    int64                     `json:"account_number"`
+	Sequence         int64                     `json:"sequence"`
+	Gas              int64                     `json:"gas"`
+	Delegations      []msgDelegationsInput     `json:"delegations"`
+	BeginUnbondings  []msgBeginUnbondingInput  `json:"begin_unbondings"`
+	BeginRedelegates []msgBeginRedelegateInput `json:"begin_redelegates"`
 }
 
 // nolint: gocyclo
@@ -93,9 +82,7 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 		// build messages
 		messages := make([]sdk.Msg, len(m.Delegations)+
 			len(m.BeginRedelegates)+
-			len(m.CompleteRedelegates)+
-			len(m.BeginUnbondings)+
-			len(m.CompleteUnbondings))
+			len(m.BeginUnbondings))
 
 		i := 0
 		for _, msg := range m.Delegations {
@@ -163,38 +150,6 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 			i++
 		}
 
-		for _, msg := range m.CompleteRedelegates {
-			delegatorAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error())))
-				return
-			}
-			validatorSrcAddr, err := sdk.AccAddressFromBech32(msg.ValidatorSrcAddr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error())))
-				return
-			}
-			validatorDstAddr, err := sdk.AccAddressFromBech32(msg.ValidatorDstAddr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error())))
-				return
-			}
-			if !bytes.Equal(info.GetPubKey().Address(), delegatorAddr) {
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte("Must use own delegator address"))
-				return
-			}
-			messages[i] = stake.MsgCompleteRedelegate{
-				DelegatorAddr:    delegatorAddr,
-				ValidatorSrcAddr: validatorSrcAddr,
-				ValidatorDstAddr: validatorDstAddr,
-			}
-			i++
-		}
-
 		for _, msg := range m.BeginUnbondings {
 			delegatorAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
 			if err != nil {
@@ -227,31 +182,6 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 			i++
 		}
 
-		for _, msg := range m.CompleteUnbondings {
-			delegatorAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error())))
-				return
-			}
-			validatorAddr, err := sdk.AccAddressFromBech32(msg.ValidatorAddr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error())))
-				return
-			}
-			if !bytes.Equal(info.GetPubKey().Address(), delegatorAddr) {
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte("Must use own delegator address"))
-				return
-			}
-			messages[i] = stake.MsgCompleteUnbonding{
-				DelegatorAddr: delegatorAddr,
-				ValidatorAddr: validatorAddr,
-			}
-			i++
-		}
-
 		// add gas to context
 		ctx = ctx.WithGas(m.Gas)
 
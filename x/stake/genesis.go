@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"strings"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
@@ -10,18 +12,32 @@ import (
 )
 
 // InitGenesis sets the pool and parameters for the provided keeper and
-// initializes the IntraTxCounter. For each validator in data, it sets that
+// initializes the IntraTxCounter. For each validator in data, it creates that
 // validator in the keeper along with manually setting the indexes. In
-// addition, it also sets any delegations found in data. Finally, it updates
-// the bonded validators.
+// addition, it also sets any delegations found in data and checks that the
+// module account, funded by the genesis accounts, holds the validator tokens.
+// Finally, it updates the bonded validators.
 // Returns final validator set after applying all declaration and delegations
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res []abci.Validator, err error) {
 	keeper.SetPool(ctx, data.Pool)
 	keeper.SetNewParams(ctx, data.Params)
 	keeper.InitIntraTxCounter(ctx)
 
+	tokens := sdk.ZeroRat()
+	monikers := make(map[string]bool)
 	for i, validator := range data.Validators {
-		keeper.SetValidator(ctx, validator)
+		// monikers must be unique among validators, ignoring case
+		if validator.Description.Moniker != "" {
+			moniker := strings.ToLower(validator.Description.Moniker)
+			if monikers[moniker] {
+				return res, errors.Errorf("duplicate genesis validator moniker %s, validator: %v", validator.Description.Moniker, validator)
+			}
+			monikers[moniker] = true
+		}
+
+		validator.BondIntraTxCounter = int16(i) // set the intra-tx counter to the order the validators are presented
+		keeper.CreateValidator(ctx, validator)
+		tokens = tokens.Add(validator.Tokens)
 
 		if validator.Tokens.IsZero() {
 			return res, errors.Errorf("genesis validator cannot have zero pool shares, validator: %v", validator)
@@ -30,11 +46,11 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 			return res, errors.Errorf("genesis validator cannot have zero delegator shares, validator: %v", validator)
 		}
 
-		// Manually set indexes for the first time
-		keeper.SetValidatorByPubKeyIndex(ctx, validator)
-
-		validator.BondIntraTxCounter = int16(i) // set the intra-tx counter to the order the validators are presented
-		keeper.SetValidatorByPowerIndex(ctx, validator, data.Pool)
+		// Manually set indexes for the first time, jailed validators aren't
+		// in the power index
+		if !validator.Jailed {
+			keeper.SetValidatorByPowerIndex(ctx, validator, data.Pool)
+		}
 
 		if validator.Status == sdk.Bonded {
 			keeper.SetValidatorBondedIndex(ctx, validator)
@@ -45,6 +61,14 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		keeper.SetDelegation(ctx, bond)
 	}
 
+	// the tokens of the genesis validators are held by the module account,
+	// which is funded by the genesis accounts so that the supply counts them
+	held := keeper.GetModuleAccount(ctx).GetCoins().AmountOf(data.Params.BondDenom)
+	if held.LT(tokens.TruncateInt()) {
+		return res, errors.Errorf("stake module account holds %v bond tokens, less than the %v tokens of the genesis validators",
+			held, tokens.TruncateInt())
+	}
+
 	keeper.UpdateBondedValidatorsFull(ctx)
 
 	vals := keeper.GetValidatorsBonded(ctx)
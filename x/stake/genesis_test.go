@@ -9,12 +9,21 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	keep "github.com/cosmos/cosmos-sdk/x/stake/keeper"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
+// fund the module account with bond tokens as the genesis accounts do
+func fundModuleAccount(t *testing.T, ctx sdk.Context, am auth.AccountMapper, keeper keep.Keeper, amt int64) {
+	moduleAcc := keeper.GetModuleAccount(ctx)
+	err := moduleAcc.SetCoins(sdk.Coins{{keeper.GetParams(ctx).BondDenom, sdk.NewInt(amt)}})
+	require.Nil(t, err)
+	am.SetAccount(ctx, moduleAcc)
+}
+
 func TestInitGenesis(t *testing.T) {
-	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	ctx, am, keeper := keep.CreateTestInput(t, false, 1000)
 
 	pool := keeper.GetPool(ctx)
 	pool.BondedTokens = sdk.NewRat(2)
@@ -38,7 +47,12 @@ func TestInitGenesis(t *testing.T) {
 	validators[1].Tokens = sdk.OneRat()
 	validators[1].DelegatorShares = sdk.OneRat()
 
+	// the module account must hold the tokens of the validators
 	genesisState = types.NewGenesisState(pool, params, validators, delegations)
+	_, err = InitGenesis(ctx, keeper, genesisState)
+	require.Error(t, err)
+
+	fundModuleAccount(t, ctx, am, keeper, 2)
 	vals, err := InitGenesis(ctx, keeper, genesisState)
 	require.NoError(t, err)
 
@@ -59,11 +73,28 @@ func TestInitGenesis(t *testing.T) {
 	require.Equal(t, abcivals, vals)
 }
 
+func TestInitGenesisDuplicateMoniker(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+
+	validators := []Validator{
+		NewValidator(keep.Addrs[0], keep.PKs[0], Description{Moniker: "hoop"}),
+		NewValidator(keep.Addrs[1], keep.PKs[1], Description{Moniker: "HOOP"}),
+	}
+	for i := range validators {
+		validators[i].Status = sdk.Bonded
+		validators[i].Tokens = sdk.OneRat()
+		validators[i].DelegatorShares = sdk.OneRat()
+	}
+	genesisState := types.NewGenesisState(keeper.GetPool(ctx), keeper.GetParams(ctx), validators, nil)
+	_, err := InitGenesis(ctx, keeper, genesisState)
+	require.Error(t, err)
+}
+
 func TestInitGenesisLargeValidatorSet(t *testing.T) {
 	size := 200
 	require.True(t, size > 100)
 
-	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	ctx, am, keeper := keep.CreateTestInput(t, false, 1000)
 
 	// Assigning 2 to the first 100 vals, 1 to the rest
 	pool := keeper.GetPool(ctx)
@@ -86,6 +117,7 @@ func TestInitGenesisLargeValidatorSet(t *testing.T) {
 		}
 	}
 
+	fundModuleAccount(t, ctx, am, keeper, int64(200+(size-100)))
 	genesisState := types.NewGenesisState(pool, params, validators, delegations)
 	vals, err := InitGenesis(ctx, keeper, genesisState)
 	require.NoError(t, err)
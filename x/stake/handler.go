@@ -1,6 +1,9 @@
 package stake
 
 import (
+	"bytes"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
 	"github.com/cosmos/cosmos-sdk/x/stake/tags"
@@ -20,32 +23,60 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgDelegate(ctx, msg, k)
 		case types.MsgBeginRedelegate:
 			return handleMsgBeginRedelegate(ctx, msg, k)
-		case types.MsgCompleteRedelegate:
-			return handleMsgCompleteRedelegate(ctx, msg, k)
 		case types.MsgBeginUnbonding:
 			return handleMsgBeginUnbonding(ctx, msg, k)
-		case types.MsgCompleteUnbonding:
-			return handleMsgCompleteUnbonding(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
 	}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Validator) {
-	pool := k.GetPool(ctx)
-	params := k.GetParams(ctx)
-
-	// Process types.Validator Provisions
+// Called every block, complete mature unbondings and redelegations, update
+// validator set
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Validator, endBlockerTags sdk.Tags) {
+	endBlockerTags = sdk.EmptyTags()
 	blockTime := ctx.BlockHeader().Time
-	if blockTime-pool.InflationLastTime >= 3600 {
-		pool.InflationLastTime = blockTime
-		pool = pool.ProcessProvisions(params)
+
+	logger := ctx.Logger().With("module", "x/stake")
+
+	// Complete all the unbonding delegations which have matured
+	matureUnbonds := k.DequeueAllMatureUBDQueue(ctx, blockTime)
+	for _, dvPair := range matureUnbonds {
+		// an unbonding which fails to complete doesn't halt the chain, its
+		// entries are left untouched and it is queued again to be retried
+		// in the next block
+		err := k.CompleteUnbonding(ctx, dvPair.DelegatorAddr, dvPair.ValidatorAddr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to complete the mature unbonding of %s from %s, retrying in the next block: %v",
+				dvPair.DelegatorAddr, dvPair.ValidatorAddr, err))
+			if ubd, found := k.GetUnbondingDelegation(ctx, dvPair.DelegatorAddr, dvPair.ValidatorAddr); found {
+				k.InsertUBDQueue(ctx, ubd, blockTime)
+			}
+			continue
+		}
+		endBlockerTags = endBlockerTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteUnbonding,
+			tags.Delegator, []byte(dvPair.DelegatorAddr.String()),
+			tags.SrcValidator, []byte(dvPair.ValidatorAddr.String()),
+		))
 	}
 
-	// save the params
-	k.SetPool(ctx, pool)
+	// Complete all the redelegations which have matured
+	matureRedelegations := k.DequeueAllMatureRedelegationQueue(ctx, blockTime)
+	for _, dvvTriplet := range matureRedelegations {
+		err := k.CompleteRedelegation(ctx, dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to complete the mature redelegation of %s from %s to %s: %v",
+				dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr, err))
+			continue
+		}
+		endBlockerTags = endBlockerTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionCompleteRedelegation,
+			tags.Delegator, []byte(dvvTriplet.DelegatorAddr.String()),
+			tags.SrcValidator, []byte(dvvTriplet.ValidatorSrcAddr.String()),
+			tags.DstValidator, []byte(dvvTriplet.ValidatorDstAddr.String()),
+		))
+	}
 
 	// reset the intra-transaction counter
 	k.SetIntraTxCounter(ctx, 0)
@@ -72,17 +103,34 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	if found {
 		return ErrValidatorPubKeyExists(k.Codespace()).Result()
 	}
+	err := k.BeforeValidatorCreated(ctx, msg.PubKey)
+	if err != nil {
+		return err.Result()
+	}
 	if msg.Delegation.Denom != k.GetParams(ctx).BondDenom {
 		return ErrBadDenom(k.Codespace()).Result()
 	}
 
-	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, msg.Description)
-	k.SetValidator(ctx, validator)
-	k.SetValidatorByPubKeyIndex(ctx, validator)
+	// monikers must be unique among validators, including unbonded ones which
+	// keep their moniker until they are removed
+	description, err := Description{}.UpdateDescription(msg.Description)
+	if err != nil {
+		return err.Result()
+	}
+	if description.Moniker != "" {
+		_, found = k.GetValidatorByMoniker(ctx, description.Moniker)
+		if found {
+			return ErrValidatorMonikerExists(k.Codespace()).Result()
+		}
+	}
+
+	validator := NewValidator(msg.ValidatorAddr, msg.PubKey, description)
+	validator.MinSelfDelegation = msg.MinSelfDelegation
+	k.CreateValidator(ctx, validator)
 
 	// move coins from the msg.Address account to a (self-delegation) delegator account
 	// the validator account and global shares are updated within here
-	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
+	_, err = k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
 	if err != nil {
 		return err.Result()
 	}
@@ -90,8 +138,8 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionCreateValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(msg.Description.Moniker),
-		tags.Identity, []byte(msg.Description.Identity),
+		tags.Moniker, []byte(description.Moniker),
+		tags.Identity, []byte(description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
@@ -106,19 +154,47 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 		return ErrNoValidatorFound(k.Codespace()).Result()
 	}
 
-	// replace all editable fields (clients should autofill existing values)
-	description, err := validator.Description.UpdateDescription(msg.Description)
-	if err != nil {
-		return err.Result()
+	// replace the editable fields not set to DoNotModifyDesc, an empty
+	// description only edits the minimum self delegation
+	if msg.Description != (Description{}) {
+		description, err := validator.Description.UpdateDescription(msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+
+		// a new moniker must not be used by another validator
+		if description.Moniker != validator.Description.Moniker {
+			if description.Moniker != "" {
+				other, found := k.GetValidatorByMoniker(ctx, description.Moniker)
+				if found && !bytes.Equal(other.Owner, validator.Owner) {
+					return ErrValidatorMonikerExists(k.Codespace()).Result()
+				}
+			}
+			k.DeleteValidatorByMonikerIndex(ctx, validator)
+		}
+		validator.Description = description
+		k.SetValidatorByMonikerIndex(ctx, validator)
+	}
+
+	// the minimum self delegation can only be increased, up to the tokens
+	// currently self delegated
+	if msg.MinSelfDelegation != nil {
+		if !msg.MinSelfDelegation.GT(validator.MinSelfDelegation) {
+			return ErrMinSelfDelegationDecreased(k.Codespace()).Result()
+		}
+		delegation, found := k.GetDelegation(ctx, validator.Owner, validator.Owner)
+		if !found || validator.DelegatorShareExRate().Mul(delegation.Shares).LT(sdk.NewRatFromInt(*msg.MinSelfDelegation)) {
+			return ErrSelfDelegationBelowMinimum(k.Codespace()).Result()
+		}
+		validator.MinSelfDelegation = *msg.MinSelfDelegation
 	}
-	validator.Description = description
 
 	k.UpdateValidator(ctx, validator)
 	tags := sdk.NewTags(
 		tags.Action, tags.ActionEditValidator,
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
 	)
 	return sdk.Result{
 		Tags: tags,
@@ -134,8 +210,10 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	if msg.Delegation.Denom != k.GetParams(ctx).BondDenom {
 		return ErrBadDenom(k.Codespace()).Result()
 	}
-	if validator.Revoked == true {
-		return ErrValidatorRevoked(k.Codespace()).Result()
+	// only the owner may delegate to a jailed validator, to restore its
+	// minimum self delegation
+	if validator.Jailed && !bytes.Equal(msg.DelegatorAddr, validator.Owner) {
+		return ErrValidatorJailed(k.Codespace()).Result()
 	}
 	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
 	if err != nil {
@@ -166,22 +244,6 @@ func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k kee
 	return sdk.Result{Tags: tags}
 }
 
-func handleMsgCompleteUnbonding(ctx sdk.Context, msg types.MsgCompleteUnbonding, k keeper.Keeper) sdk.Result {
-
-	err := k.CompleteUnbonding(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
-	if err != nil {
-		return err.Result()
-	}
-
-	tags := sdk.NewTags(
-		tags.Action, ActionCompleteUnbonding,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
-	)
-
-	return sdk.Result{Tags: tags}
-}
-
 func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k keeper.Keeper) sdk.Result {
 	err := k.BeginRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr,
 		msg.ValidatorDstAddr, msg.SharesAmount)
@@ -197,18 +259,3 @@ func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k k
 	)
 	return sdk.Result{Tags: tags}
 }
-
-func handleMsgCompleteRedelegate(ctx sdk.Context, msg types.MsgCompleteRedelegate, k keeper.Keeper) sdk.Result {
-	err := k.CompleteRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.ValidatorDstAddr)
-	if err != nil {
-		return err.Result()
-	}
-
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionCompleteRedelegation,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorSrcAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorDstAddr.String()),
-	)
-	return sdk.Result{Tags: tags}
-}
@@ -16,7 +16,7 @@ import (
 //______________________________________________________________________
 
 func newTestMsgCreateValidator(address sdk.AccAddress, pubKey crypto.PubKey, amt int64) MsgCreateValidator {
-	return types.NewMsgCreateValidator(address, pubKey, sdk.Coin{"steak", sdk.NewInt(amt)}, Description{})
+	return types.NewMsgCreateValidator(address, pubKey, sdk.Coin{"steak", sdk.NewInt(amt)}, Description{}, sdk.OneInt())
 }
 
 func newTestMsgDelegate(delegatorAddr, validatorAddr sdk.AccAddress, amt int64) MsgDelegate {
@@ -29,11 +29,12 @@ func newTestMsgDelegate(delegatorAddr, validatorAddr sdk.AccAddress, amt int64)
 
 func newTestMsgCreateValidatorOnBehalfOf(delegatorAddr, validatorAddr sdk.AccAddress, valPubKey crypto.PubKey, amt int64) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:   Description{},
-		DelegatorAddr: delegatorAddr,
-		ValidatorAddr: validatorAddr,
-		PubKey:        valPubKey,
-		Delegation:    sdk.Coin{"steak", sdk.NewInt(amt)},
+		Description:       Description{},
+		DelegatorAddr:     delegatorAddr,
+		ValidatorAddr:     validatorAddr,
+		PubKey:            valPubKey,
+		Delegation:        sdk.Coin{"steak", sdk.NewInt(amt)},
+		MinSelfDelegation: sdk.OneInt(),
 	}
 }
 
@@ -79,9 +80,9 @@ func TestValidatorByPowerIndex(t *testing.T) {
 	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
 	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
 
-	// slash and revoke the first validator
+	// slash and jail the first validator
 	keeper.Slash(ctx, keep.PKs[0], 0, initBond, sdk.NewRat(1, 2))
-	keeper.Revoke(ctx, keep.PKs[0])
+	keeper.Jail(ctx, keep.PKs[0], 0)
 	validator, found = keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
 	require.Equal(t, sdk.Unbonded, validator.Status)               // ensure is unbonded
@@ -90,19 +91,25 @@ func TestValidatorByPowerIndex(t *testing.T) {
 	// the old power record should have been deleted as the power changed
 	require.False(t, keep.ValidatorByPowerIndexExists(ctx, keeper, power))
 
-	// but the new power record should have been created
+	// and no new power record is created while the validator is jailed
+	pool = keeper.GetPool(ctx)
+	power2 := GetValidatorsByPowerIndexKey(validator, pool)
+	require.False(t, keep.ValidatorByPowerIndexExists(ctx, keeper, power2))
+
+	// but the new power record should be created once it is unjailed
+	keeper.Unjail(ctx, keep.PKs[0])
 	validator, found = keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
+	require.False(t, validator.Jailed)
 	pool = keeper.GetPool(ctx)
-	power2 := GetValidatorsByPowerIndexKey(validator, pool)
+	power2 = GetValidatorsByPowerIndexKey(validator, pool)
 	require.True(t, keep.ValidatorByPowerIndexExists(ctx, keeper, power2))
 
 	// inflate a bunch
-	params := keeper.GetParams(ctx)
 	for i := 0; i < 200; i++ {
-		pool = pool.ProcessProvisions(params)
-		keeper.SetPool(ctx, pool)
+		keeper.InflateSupply(ctx, sdk.NewInt(10000))
 	}
+	pool = keeper.GetPool(ctx)
 
 	// now the new record power index should be the same as the original record
 	power3 := GetValidatorsByPowerIndexKey(validator, pool)
@@ -110,11 +117,9 @@ func TestValidatorByPowerIndex(t *testing.T) {
 
 	// unbond self-delegation
 	msgBeginUnbonding := NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(1000000))
-	msgCompleteUnbonding := NewMsgCompleteUnbonding(validatorAddr, validatorAddr)
 	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
 	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	EndBlocker(ctx, keeper)
 
 	// verify that by power key nolonger exists
 	_, found = keeper.GetValidator(ctx, validatorAddr)
@@ -296,13 +301,11 @@ func TestIncrementsMsgUnbond(t *testing.T) {
 	// TODO use decimals here
 	unbondShares := sdk.NewRat(10)
 	msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, unbondShares)
-	msgCompleteUnbonding := NewMsgCompleteUnbonding(delegatorAddr, validatorAddr)
 	numUnbonds := 5
 	for i := 0; i < numUnbonds; i++ {
 		got := handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
 		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
-		got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
+		EndBlocker(ctx, keeper)
 
 		//Check that the accounts and the bond account have the appropriate values
 		validator, found = keeper.GetValidator(ctx, validatorAddr)
@@ -391,11 +394,9 @@ func TestMultipleMsgCreateValidator(t *testing.T) {
 		_, found := keeper.GetValidator(ctx, validatorAddr)
 		require.True(t, found)
 		msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddrs[i], validatorAddr, sdk.NewRat(10)) // remove delegation
-		msgCompleteUnbonding := NewMsgCompleteUnbonding(delegatorAddrs[i], validatorAddr)
 		got := handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
 		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
-		got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
+		EndBlocker(ctx, keeper)
 
 		//Check that the account is unbonded
 		validators := keeper.GetValidators(ctx, 100)
@@ -436,11 +437,9 @@ func TestMultipleMsgDelegate(t *testing.T) {
 	// unbond them all
 	for i, delegatorAddr := range delegatorAddrs {
 		msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(10))
-		msgCompleteUnbonding := NewMsgCompleteUnbonding(delegatorAddr, validatorAddr)
 		got := handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
 		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
-		got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-		require.True(t, got.IsOK(), "expected msg %d to be ok, got %v", i, got)
+		EndBlocker(ctx, keeper)
 
 		//Check that the account is unbonded
 		_, found := keeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
@@ -448,7 +447,7 @@ func TestMultipleMsgDelegate(t *testing.T) {
 	}
 }
 
-func TestRevokeValidator(t *testing.T) {
+func TestJailValidator(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
 	_ = setInstantUnbondPeriod(keeper, ctx)
@@ -467,33 +466,151 @@ func TestRevokeValidator(t *testing.T) {
 
 	// unbond the validators bond portion
 	msgBeginUnbondingValidator := NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(10))
-	msgCompleteUnbondingValidator := NewMsgCompleteUnbonding(validatorAddr, validatorAddr)
 	got = handleMsgBeginUnbonding(ctx, msgBeginUnbondingValidator, keeper)
 	require.True(t, got.IsOK(), "expected no error")
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbondingValidator, keeper)
-	require.True(t, got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
 
 	validator, found := keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
-	require.True(t, validator.Revoked, "%v", validator)
+	require.True(t, validator.Jailed, "%v", validator)
 
-	// test that this address cannot yet be bonded too because is revoked
+	// test that this address cannot yet be bonded too because is jailed
 	got = handleMsgDelegate(ctx, msgDelegate, keeper)
 	require.False(t, got.IsOK(), "expected error, got %v", got)
 
 	// test that the delegator can still withdraw their bonds
 	msgBeginUnbondingDelegator := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(10))
-	msgCompleteUnbondingDelegator := NewMsgCompleteUnbonding(delegatorAddr, validatorAddr)
 	got = handleMsgBeginUnbonding(ctx, msgBeginUnbondingDelegator, keeper)
 	require.True(t, got.IsOK(), "expected no error")
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbondingDelegator, keeper)
-	require.True(t, got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
 
 	// verify that the pubkey can now be reused
 	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
 	require.True(t, got.IsOK(), "expected ok, got %v", got)
 }
 
+func TestJailValidatorBelowMinSelfDelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := keep.Addrs[0]
+	_ = setInstantUnbondPeriod(keeper, ctx)
+
+	// create the validator with a minimum self delegation of 5
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.MinSelfDelegation = sdk.NewInt(5)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// unbonding down to the minimum keeps the validator
+	msgBeginUnbonding := NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(5))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.False(t, validator.Jailed, "%v", validator)
+
+	// unbonding below the minimum jails it
+	msgBeginUnbonding = NewMsgBeginUnbonding(validatorAddr, validatorAddr, sdk.NewRat(1))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error")
+
+	validator, found = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(t, validator.Jailed, "%v", validator)
+	require.NotEqual(t, sdk.Bonded, validator.Status)
+
+	// only the owner can delegate to the jailed validator
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(keep.Addrs[1], validatorAddr, 1), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(validatorAddr, validatorAddr, 1), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+}
+
+func TestEditValidatorMinSelfDelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := keep.Addrs[0]
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.MinSelfDelegation = sdk.NewInt(5)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// cannot decrease or keep the minimum self delegation
+	for _, amt := range []int64{4, 5} {
+		newMin := sdk.NewInt(amt)
+		got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &newMin), keeper)
+		require.False(t, got.IsOK(), "expected error for min self delegation %d", amt)
+	}
+
+	// cannot increase it above the current self delegation
+	newMin := sdk.NewInt(11)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{}, &newMin), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	// can increase it up to the current self delegation
+	newMin = sdk.NewInt(10)
+	description := NewDescription("moniker", "", "", "")
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, description, &newMin), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(t, validator.MinSelfDelegation.Equal(newMin))
+	require.Equal(t, description, validator.Description)
+
+	// a description only edit keeps the minimum self delegation
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, NewDescription("other", "", "", ""), nil), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	validator, _ = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, validator.MinSelfDelegation.Equal(newMin))
+}
+
+func TestEditValidatorDescription(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2 := keep.Addrs[0], keep.Addrs[1]
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	msgCreateValidator.Description = NewDescription("moniker", "identity", "website", "details")
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// a partial edit keeps the fields set to DoNotModifyDesc
+	description := NewDescription(DoNotModifyDesc, DoNotModifyDesc, "new website", DoNotModifyDesc)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, description, nil), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, NewDescription("moniker", "identity", "new website", "details"), validator.Description)
+
+	// the validator can be found by its moniker, case-insensitively
+	validator, found = keeper.GetValidatorByMoniker(ctx, "MONIKER")
+	require.True(t, found)
+	require.Equal(t, validatorAddr, validator.Owner)
+
+	// another validator can't take the same moniker
+	msgCreateValidator = newTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 10)
+	msgCreateValidator.Description = NewDescription("Moniker", "", "", "")
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	msgCreateValidator.Description = NewDescription("moniker2", "", "", "")
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	description = NewDescription("moniker", DoNotModifyDesc, DoNotModifyDesc, DoNotModifyDesc)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr2, description, nil), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	// renaming frees the old moniker
+	description = NewDescription("moniker3", DoNotModifyDesc, DoNotModifyDesc, DoNotModifyDesc)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, description, nil), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	_, found = keeper.GetValidatorByMoniker(ctx, "moniker")
+	require.False(t, found)
+	validator, found = keeper.GetValidatorByMoniker(ctx, "moniker3")
+	require.True(t, found)
+	require.Equal(t, validatorAddr, validator.Owner)
+}
+
 func TestUnbondingPeriod(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr := keep.Addrs[0]
@@ -514,24 +631,178 @@ func TestUnbondingPeriod(t *testing.T) {
 	require.True(t, got.IsOK(), "expected no error")
 
 	// cannot complete unbonding at same time
-	msgCompleteUnbonding := NewMsgCompleteUnbonding(validatorAddr, validatorAddr)
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-	require.True(t, !got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetUnbondingDelegation(ctx, validatorAddr, validatorAddr)
+	require.True(t, found, "should not have unbonded")
 
 	// cannot complete unbonding at time 6 seconds later
 	origHeader := ctx.BlockHeader()
 	headerTime6 := origHeader
 	headerTime6.Time += 6
 	ctx = ctx.WithBlockHeader(headerTime6)
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-	require.True(t, !got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, validatorAddr, validatorAddr)
+	require.True(t, found, "should not have unbonded")
 
 	// can complete unbonding at time 7 seconds later
 	headerTime7 := origHeader
 	headerTime7.Time += 7
 	ctx = ctx.WithBlockHeader(headerTime7)
-	got = handleMsgCompleteUnbonding(ctx, msgCompleteUnbonding, keeper)
-	require.True(t, got.IsOK(), "expected no error")
+	_, endBlockerTags := EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, validatorAddr, validatorAddr)
+	require.False(t, found, "should have unbonded")
+	require.Equal(t, 3, len(endBlockerTags))
+	require.Equal(t, ActionCompleteUnbonding, endBlockerTags[0].Value)
+}
+
+func TestConcurrentUnbondingEntries(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	// set the unbonding time
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	// create the validator and bond a delegator
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 10)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	amt1 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+
+	// begin unbonding twice, the second unbonding 3 seconds after the first
+	msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(4))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error, got %v", got)
+	origHeader := ctx.BlockHeader()
+	headerTime3 := origHeader
+	headerTime3.Time += 3
+	ctx = ctx.WithBlockHeader(headerTime3)
+	msgBeginUnbonding = NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(6))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error, got %v", got)
+
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 2, len(ubd.Entries))
+
+	// the first entry completes 7 seconds after it began
+	headerTime7 := origHeader
+	headerTime7.Time += 7
+	ctx = ctx.WithBlockHeader(headerTime7)
+	EndBlocker(ctx, keeper)
+	ubd, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 1, len(ubd.Entries))
+	amt2 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+	require.Equal(t, amt1.Add(sdk.NewInt(4)), amt2)
+
+	// the second entry completes 7 seconds after it began
+	headerTime10 := origHeader
+	headerTime10.Time += 10
+	ctx = ctx.WithBlockHeader(headerTime10)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	amt3 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+	require.Equal(t, amt1.Add(sdk.NewInt(10)), amt3)
+}
+
+// tests that the entries of an unbonding delegation which mature in the same
+// block, but were queued at different times, complete together
+func TestConcurrentUnbondingEntriesMatureTogether(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 10)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	amt1 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+
+	origHeader := ctx.BlockHeader()
+	msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(4))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error, got %v", got)
+	headerTime3 := origHeader
+	headerTime3.Time += 3
+	ctx = ctx.WithBlockHeader(headerTime3)
+	msgBeginUnbonding = NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(6))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error, got %v", got)
+
+	// no block is produced until both entries are mature
+	headerTime20 := origHeader
+	headerTime20.Time += 20
+	ctx = ctx.WithBlockHeader(headerTime20)
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	amt2 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+	require.Equal(t, amt1.Add(sdk.NewInt(10)), amt2)
+}
+
+// tests that a mature unbonding which fails to pay out doesn't halt the chain,
+// it stays queued and completes in a later block
+func TestFailedUnbondingPayoutStaysQueued(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := keep.Addrs[0], keep.Addrs[1]
+	denom := keeper.GetParams(ctx).BondDenom
+
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 7
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 10)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	amt1 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+
+	origHeader := ctx.BlockHeader()
+	msgBeginUnbonding := NewMsgBeginUnbonding(delegatorAddr, validatorAddr, sdk.NewRat(4))
+	got = handleMsgBeginUnbonding(ctx, msgBeginUnbonding, keeper)
+	require.True(t, got.IsOK(), "expected no error, got %v", got)
+
+	// the module account can't pay out the mature entry
+	moduleAcc := accMapper.GetAccount(ctx, keeper.GetModuleAccount(ctx).GetAddress())
+	moduleCoins := moduleAcc.GetCoins()
+	require.Nil(t, moduleAcc.SetCoins(nil))
+	accMapper.SetAccount(ctx, moduleAcc)
+
+	headerTime10 := origHeader
+	headerTime10.Time += 10
+	ctx = ctx.WithBlockHeader(headerTime10)
+	require.NotPanics(t, func() { EndBlocker(ctx, keeper) })
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 1, len(ubd.Entries))
+	require.Equal(t, amt1, accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom))
+
+	// the entry is retried and completes once the coins can be paid out
+	require.Nil(t, moduleAcc.SetCoins(moduleCoins))
+	accMapper.SetAccount(ctx, moduleAcc)
+	headerTime11 := origHeader
+	headerTime11.Time += 11
+	ctx = ctx.WithBlockHeader(headerTime11)
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	amt2 := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(denom)
+	require.Equal(t, amt1.Add(sdk.NewInt(4)), amt2)
 }
 
 func TestRedelegationPeriod(t *testing.T) {
@@ -573,24 +844,26 @@ func TestRedelegationPeriod(t *testing.T) {
 	require.Equal(t, bal1, bal2)
 
 	// cannot complete redelegation at same time
-	msgCompleteRedelegate := NewMsgCompleteRedelegate(validatorAddr, validatorAddr, validatorAddr2)
-	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
-	require.True(t, !got.IsOK(), "expected an error")
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found, "should not have completed redelegation")
 
 	// cannot complete redelegation at time 6 seconds later
 	origHeader := ctx.BlockHeader()
 	headerTime6 := origHeader
 	headerTime6.Time += 6
 	ctx = ctx.WithBlockHeader(headerTime6)
-	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
-	require.True(t, !got.IsOK(), "expected an error")
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found, "should not have completed redelegation")
 
 	// can complete redelegation at time 7 seconds later
 	headerTime7 := origHeader
 	headerTime7.Time += 7
 	ctx = ctx.WithBlockHeader(headerTime7)
-	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
-	require.True(t, got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetRedelegation(ctx, validatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found, "should have completed redelegation")
 }
 
 func TestTransitiveRedelegation(t *testing.T) {
@@ -626,9 +899,7 @@ func TestTransitiveRedelegation(t *testing.T) {
 	require.True(t, !got.IsOK(), "expected an error, msg: %v", msgBeginRedelegate)
 
 	// complete first redelegation
-	msgCompleteRedelegate := NewMsgCompleteRedelegate(validatorAddr, validatorAddr, validatorAddr2)
-	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
-	require.True(t, got.IsOK(), "expected no error")
+	EndBlocker(ctx, keeper)
 
 	// now should be able to redelegate from the second validator to the third
 	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
@@ -851,12 +1122,12 @@ func TestBondUnbondRedelegateSlashTwice(t *testing.T) {
 	// unbonding delegation should have been slashed by half
 	unbonding, found := keeper.GetUnbondingDelegation(ctx, del, valA)
 	require.True(t, found)
-	require.Equal(t, int64(2), unbonding.Balance.Amount.Int64())
+	require.Equal(t, int64(2), unbonding.Entries[0].Balance.Amount.Int64())
 
 	// redelegation should have been slashed by half
 	redelegation, found := keeper.GetRedelegation(ctx, del, valA, valB)
 	require.True(t, found)
-	require.Equal(t, int64(3), redelegation.Balance.Amount.Int64())
+	require.Equal(t, int64(3), redelegation.Entries[0].Balance.Amount.Int64())
 
 	// destination delegation should have been slashed by half
 	delegation, found = keeper.GetDelegation(ctx, del, valB)
@@ -875,12 +1146,12 @@ func TestBondUnbondRedelegateSlashTwice(t *testing.T) {
 	// unbonding delegation should be unchanged
 	unbonding, found = keeper.GetUnbondingDelegation(ctx, del, valA)
 	require.True(t, found)
-	require.Equal(t, int64(2), unbonding.Balance.Amount.Int64())
+	require.Equal(t, int64(2), unbonding.Entries[0].Balance.Amount.Int64())
 
 	// redelegation should be unchanged
 	redelegation, found = keeper.GetRedelegation(ctx, del, valA, valB)
 	require.True(t, found)
-	require.Equal(t, int64(3), redelegation.Balance.Amount.Int64())
+	require.Equal(t, int64(3), redelegation.Entries[0].Balance.Amount.Int64())
 
 	// destination delegation should be unchanged
 	delegation, found = keeper.GetDelegation(ctx, del, valB)
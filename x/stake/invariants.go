@@ -0,0 +1,15 @@
+package stake
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+// RegisterInvariants registers all the stake invariants on a crisis registry
+func RegisterInvariants(r *crisis.Registry, k Keeper, am auth.AccountMapper) {
+	r.RegisterRoute(ModuleName, "supply", SupplyInvariants(k, am))
+	r.RegisterRoute(ModuleName, "positive-power", PositivePowerInvariant(k))
+	r.RegisterRoute(ModuleName, "delegator-shares", DelegatorSharesInvariant(k))
+	r.RegisterRoute(ModuleName, "power-index", PowerIndexInvariant(k))
+	r.RegisterRoute(ModuleName, "unbonding-balances", UnbondingBalancesInvariant(k))
+}
@@ -62,17 +62,32 @@ func (k Keeper) GetDelegations(ctx sdk.Context, delegator sdk.AccAddress,
 	return delegations[:i] // trim
 }
 
+// load all delegations to a particular validator
+func (k Keeper) GetDelegationsToValidator(ctx sdk.Context, valAddr sdk.AccAddress) (delegations []types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsByValIndexKey(valAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		key := GetDelegationKeyFromValIndexKey(iterator.Key())
+		delegation := types.MustUnmarshalDelegation(k.cdc, key, store.Get(key))
+		delegations = append(delegations, delegation)
+	}
+	iterator.Close()
+	return delegations
+}
+
 // set the delegation
 func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
 	b := types.MustMarshalDelegation(k.cdc, delegation)
 	store.Set(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr), b)
+	store.Set(GetDelegationByValIndexKey(delegation.DelegatorAddr, delegation.ValidatorAddr), []byte{}) // index, store empty bytes
 }
 
-// remove the delegation
+// remove the delegation and associated index
 func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
+	store.Delete(GetDelegationByValIndexKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
 }
 
 //_____________________________________________________________________________________
@@ -143,6 +158,88 @@ func (k Keeper) RemoveUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDe
 	store.Delete(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr))
 }
 
+// add an entry to the unbonding delegation of a delegator/validator pair,
+// creating the unbonding delegation if it does not exist yet
+func (k Keeper) SetUnbondingDelegationEntry(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddress,
+	creationHeight, minTime int64, balance sdk.Coin) types.UnbondingDelegation {
+
+	ubd, found := k.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	if found {
+		ubd.AddEntry(creationHeight, minTime, balance)
+	} else {
+		ubd = types.NewUnbondingDelegation(delegatorAddr, validatorAddr, creationHeight, minTime, balance)
+	}
+	k.SetUnbondingDelegation(ctx, ubd)
+	return ubd
+}
+
+// gets a specific unbonding queue timeslice. A timeslice is a slice of DVPairs
+// corresponding to unbonding delegations that expire at a certain time.
+func (k Keeper) GetUBDQueueTimeSlice(ctx sdk.Context, timestamp int64) (dvPairs []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetUnbondingDelegationTimeKey(timestamp))
+	if bz == nil {
+		return []types.DVPair{}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &dvPairs)
+	return dvPairs
+}
+
+// sets a specific unbonding queue timeslice
+func (k Keeper) SetUBDQueueTimeSlice(ctx sdk.Context, timestamp int64, keys []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(keys)
+	store.Set(GetUnbondingDelegationTimeKey(timestamp), bz)
+}
+
+// insert an unbonding delegation to the appropriate timeslice in the unbonding queue,
+// an unbonding delegation is inserted once per timeslice however many entries complete then
+func (k Keeper) InsertUBDQueue(ctx sdk.Context, ubd types.UnbondingDelegation, minTime int64) {
+	timeSlice := k.GetUBDQueueTimeSlice(ctx, minTime)
+	dvPair := types.DVPair{ubd.DelegatorAddr, ubd.ValidatorAddr}
+	for _, pair := range timeSlice {
+		if bytes.Equal(pair.DelegatorAddr, dvPair.DelegatorAddr) && bytes.Equal(pair.ValidatorAddr, dvPair.ValidatorAddr) {
+			return
+		}
+	}
+	k.SetUBDQueueTimeSlice(ctx, minTime, append(timeSlice, dvPair))
+}
+
+// returns all the unbonding queue timeslices from time 0 until endTime
+func (k Keeper) UBDQueueIterator(ctx sdk.Context, endTime int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(UnbondingQueueKey, GetUnbondingDelegationTimeKey(endTime+1))
+}
+
+// returns a concatenated list of all the timeslices before currTime, and deletes the timeslices from the queue.
+// A pair queued in several timeslices is only returned once, as all its mature entries complete together.
+func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime int64) (matureUnbonds []types.DVPair) {
+	store := ctx.KVStore(k.storeKey)
+	// gets an iterator for all timeslices from time 0 until the current Blockheader time
+	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, currTime)
+	var keys [][]byte
+	seen := make(map[string]bool)
+	for ; unbondingTimesliceIterator.Valid(); unbondingTimesliceIterator.Next() {
+		timeslice := []types.DVPair{}
+		k.cdc.MustUnmarshalBinary(unbondingTimesliceIterator.Value(), &timeslice)
+		for _, dvPair := range timeslice {
+			key := string(GetUBDKey(dvPair.DelegatorAddr, dvPair.ValidatorAddr))
+			if !seen[key] {
+				seen[key] = true
+				matureUnbonds = append(matureUnbonds, dvPair)
+			}
+		}
+		keys = append(keys, unbondingTimesliceIterator.Key())
+	}
+	unbondingTimesliceIterator.Close()
+
+	// delete the timeslices once the iterator is closed
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return matureUnbonds
+}
+
 //_____________________________________________________________________________________
 
 // load a redelegation
@@ -214,6 +311,92 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store.Delete(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr))
 }
 
+// add an entry to the redelegation of a delegator between two validators,
+// creating the redelegation if it does not exist yet
+func (k Keeper) SetRedelegationEntry(ctx sdk.Context, delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.AccAddress, creationHeight, minTime int64, balance sdk.Coin,
+	sharesSrc, sharesDst sdk.Rat) types.Redelegation {
+
+	red, found := k.GetRedelegation(ctx, delegatorAddr, validatorSrcAddr, validatorDstAddr)
+	if found {
+		red.AddEntry(creationHeight, minTime, balance, sharesSrc, sharesDst)
+	} else {
+		red = types.NewRedelegation(delegatorAddr, validatorSrcAddr, validatorDstAddr,
+			creationHeight, minTime, balance, sharesSrc, sharesDst)
+	}
+	k.SetRedelegation(ctx, red)
+	return red
+}
+
+// gets a specific redelegation queue timeslice. A timeslice is a slice of DVVTriplets
+// corresponding to redelegations that expire at a certain time.
+func (k Keeper) GetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp int64) (dvvTriplets []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetRedelegationTimeKey(timestamp))
+	if bz == nil {
+		return []types.DVVTriplet{}
+	}
+	k.cdc.MustUnmarshalBinary(bz, &dvvTriplets)
+	return dvvTriplets
+}
+
+// sets a specific redelegation queue timeslice
+func (k Keeper) SetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp int64, keys []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(keys)
+	store.Set(GetRedelegationTimeKey(timestamp), bz)
+}
+
+// insert a redelegation to the appropriate timeslice in the redelegation queue,
+// a redelegation is inserted once per timeslice however many entries complete then
+func (k Keeper) InsertRedelegationQueue(ctx sdk.Context, red types.Redelegation, minTime int64) {
+	timeSlice := k.GetRedelegationQueueTimeSlice(ctx, minTime)
+	dvvTriplet := types.DVVTriplet{red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr}
+	for _, triplet := range timeSlice {
+		if bytes.Equal(triplet.DelegatorAddr, dvvTriplet.DelegatorAddr) &&
+			bytes.Equal(triplet.ValidatorSrcAddr, dvvTriplet.ValidatorSrcAddr) &&
+			bytes.Equal(triplet.ValidatorDstAddr, dvvTriplet.ValidatorDstAddr) {
+			return
+		}
+	}
+	k.SetRedelegationQueueTimeSlice(ctx, minTime, append(timeSlice, dvvTriplet))
+}
+
+// returns all the redelegation queue timeslices from time 0 until endTime
+func (k Keeper) RedelegationQueueIterator(ctx sdk.Context, endTime int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(RedelegationQueueKey, GetRedelegationTimeKey(endTime+1))
+}
+
+// returns a concatenated list of all the timeslices before currTime, and deletes the timeslices from the queue.
+// A triplet queued in several timeslices is only returned once, as all its mature entries complete together.
+func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime int64) (matureRedelegations []types.DVVTriplet) {
+	store := ctx.KVStore(k.storeKey)
+	// gets an iterator for all timeslices from time 0 until the current Blockheader time
+	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, currTime)
+	var keys [][]byte
+	seen := make(map[string]bool)
+	for ; redelegationTimesliceIterator.Valid(); redelegationTimesliceIterator.Next() {
+		timeslice := []types.DVVTriplet{}
+		k.cdc.MustUnmarshalBinary(redelegationTimesliceIterator.Value(), &timeslice)
+		for _, dvvTriplet := range timeslice {
+			key := string(GetREDKey(dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr))
+			if !seen[key] {
+				seen[key] = true
+				matureRedelegations = append(matureRedelegations, dvvTriplet)
+			}
+		}
+		keys = append(keys, redelegationTimesliceIterator.Key())
+	}
+	redelegationTimesliceIterator.Close()
+
+	// delete the timeslices once the iterator is closed
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return matureRedelegations
+}
+
 //_____________________________________________________________________________________
 
 // Perform a delegation, set/update everything necessary within the store.
@@ -232,7 +415,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, bondAmt
 
 	if subtractAccount {
 		// Account new shares, save
-		_, _, err = k.coinKeeper.SubtractCoins(ctx, delegation.DelegatorAddr, sdk.Coins{bondAmt})
+		_, err = k.supplyKeeper.DelegateCoins(ctx, delegation.DelegatorAddr, types.ModuleName, sdk.Coins{bondAmt})
 		if err != nil {
 			return
 		}
@@ -249,6 +432,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, bondAmt
 	k.SetDelegation(ctx, delegation)
 	k.UpdateValidator(ctx, validator)
 
+	k.onDelegationModified(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	return
 }
 
@@ -281,12 +465,6 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddr
 
 	// remove the delegation
 	if delegation.Shares.IsZero() {
-
-		// if the delegation is the owner of the validator then
-		// trigger a revoke validator
-		if bytes.Equal(delegation.DelegatorAddr, validator.Owner) && validator.Revoked == false {
-			validator.Revoked = true
-		}
 		k.RemoveDelegation(ctx, delegation)
 	} else {
 		// Update height
@@ -294,6 +472,17 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddr
 		k.SetDelegation(ctx, delegation)
 	}
 
+	// if the delegation is the owner of the validator and its remaining self
+	// delegation falls below the minimum then jail the validator, it can be
+	// unjailed at once after delegating enough
+	selfDelegation := validator.DelegatorShareExRate().Mul(delegation.Shares)
+	if bytes.Equal(delegation.DelegatorAddr, validator.Owner) && !validator.Jailed &&
+		selfDelegation.LT(sdk.NewRatFromInt(validator.MinSelfDelegation)) {
+
+		k.Jail(ctx, validator.PubKey, ctx.BlockHeader().Time)
+		validator, _ = k.GetValidator(ctx, validator.Owner)
+	}
+
 	// remove the coins from the validator
 	pool := k.GetPool(ctx)
 	validator, pool, amount = validator.RemoveDelShares(pool, shares)
@@ -306,42 +495,35 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddr
 		k.RemoveValidator(ctx, validator.Owner)
 	}
 
+	k.onDelegationModified(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	return
 }
 
 //______________________________________________________________________________________________________
 
-// complete unbonding an unbonding record
+// begin unbonding part or all of a delegation, an unbonding delegation entry
+// is added for every unbonding so that several may be ongoing concurrently
 func (k Keeper) BeginUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddress, sharesAmount sdk.Rat) sdk.Error {
 
-	// TODO quick fix, instead we should use an index, see https://github.com/cosmos/cosmos-sdk/issues/1402
-	_, found := k.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
-	if found {
-		return types.ErrExistingUnbondingDelegation(k.Codespace())
-	}
-
 	returnAmount, err := k.unbond(ctx, delegatorAddr, validatorAddr, sharesAmount)
 	if err != nil {
 		return err
 	}
 
-	// create the unbonding delegation
+	// create the unbonding delegation entry, its balance is truncated so that
+	// it never pays out more than the tokens removed from the validator, a
+	// fraction of a token stays loose in the pool
 	params := k.GetParams(ctx)
 	minTime := ctx.BlockHeader().Time + params.UnbondingTime
-	balance := sdk.Coin{params.BondDenom, returnAmount.RoundInt()}
+	balance := sdk.Coin{params.BondDenom, returnAmount.TruncateInt()}
 
-	ubd := types.UnbondingDelegation{
-		DelegatorAddr:  delegatorAddr,
-		ValidatorAddr:  validatorAddr,
-		MinTime:        minTime,
-		Balance:        balance,
-		InitialBalance: balance,
-	}
-	k.SetUnbondingDelegation(ctx, ubd)
+	ubd := k.SetUnbondingDelegationEntry(ctx, delegatorAddr, validatorAddr, ctx.BlockHeight(), minTime, balance)
+	k.InsertUBDQueue(ctx, ubd, minTime)
 	return nil
 }
 
-// complete unbonding an unbonding record
+// complete all the mature entries of an unbonding delegation, the unbonding
+// delegation is removed once it has no entries left
 func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddress) sdk.Error {
 
 	ubd, found := k.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
@@ -349,21 +531,42 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr
 		return types.ErrNoUnbondingDelegation(k.Codespace())
 	}
 
-	// ensure that enough time has passed
 	ctxTime := ctx.BlockHeader().Time
-	if ubd.MinTime > ctxTime {
-		return types.ErrNotMature(k.Codespace(), "unbonding", "unit-time", ubd.MinTime, ctxTime)
+
+	// loop through all the entries and remove the mature ones, summing the
+	// coins they return. Entries slashed down to zero have no coins left.
+	var balance sdk.Coins
+	for i := 0; i < len(ubd.Entries); i++ {
+		entry := ubd.Entries[i]
+		if entry.IsMature(ctxTime) {
+			ubd.RemoveEntry(int64(i))
+			i--
+			if !entry.Balance.IsZero() {
+				balance = balance.Plus(sdk.Coins{entry.Balance})
+			}
+		}
 	}
 
-	_, _, err := k.coinKeeper.AddCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
-	if err != nil {
-		return err
+	// return the coins of all the mature entries at once, so that either all
+	// the entries complete or none does
+	if !balance.IsZero() {
+		_, err := k.supplyKeeper.UndelegateCoins(ctx, types.ModuleName, ubd.DelegatorAddr, balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	// set the unbonding delegation or remove it if there are no more entries
+	if len(ubd.Entries) == 0 {
+		k.RemoveUnbondingDelegation(ctx, ubd)
+	} else {
+		k.SetUnbondingDelegation(ctx, ubd)
 	}
-	k.RemoveUnbondingDelegation(ctx, ubd)
 	return nil
 }
 
-// complete unbonding an unbonding record
+// begin a redelegation, a redelegation entry is added for every redelegation
+// so that several may be ongoing concurrently
 func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAddr,
 	validatorDstAddr sdk.AccAddress, sharesAmount sdk.Rat) sdk.Error {
 
@@ -377,8 +580,10 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAd
 		return err
 	}
 
+	// the whole tokens removed from the source validator are delegated to the
+	// destination validator, a fraction of a token stays loose in the pool
 	params := k.GetParams(ctx)
-	returnCoin := sdk.Coin{params.BondDenom, returnAmount.RoundInt()}
+	returnCoin := sdk.Coin{params.BondDenom, returnAmount.TruncateInt()}
 	dstValidator, found := k.GetValidator(ctx, validatorDstAddr)
 	if !found {
 		return types.ErrBadRedelegationDst(k.Codespace())
@@ -388,24 +593,17 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAd
 		return err
 	}
 
-	// create the unbonding delegation
+	// create the redelegation entry
 	minTime := ctx.BlockHeader().Time + params.UnbondingTime
 
-	red := types.Redelegation{
-		DelegatorAddr:    delegatorAddr,
-		ValidatorSrcAddr: validatorSrcAddr,
-		ValidatorDstAddr: validatorDstAddr,
-		MinTime:          minTime,
-		SharesDst:        sharesCreated,
-		SharesSrc:        sharesAmount,
-		Balance:          returnCoin,
-		InitialBalance:   returnCoin,
-	}
-	k.SetRedelegation(ctx, red)
+	red := k.SetRedelegationEntry(ctx, delegatorAddr, validatorSrcAddr, validatorDstAddr,
+		ctx.BlockHeight(), minTime, returnCoin, sharesAmount, sharesCreated)
+	k.InsertRedelegationQueue(ctx, red, minTime)
 	return nil
 }
 
-// complete unbonding an ongoing redelegation
+// complete all the mature entries of a redelegation, the redelegation is
+// removed once it has no entries left
 func (k Keeper) CompleteRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAddr, validatorDstAddr sdk.AccAddress) sdk.Error {
 
 	red, found := k.GetRedelegation(ctx, delegatorAddr, validatorSrcAddr, validatorDstAddr)
@@ -413,12 +611,21 @@ func (k Keeper) CompleteRedelegation(ctx sdk.Context, delegatorAddr, validatorSr
 		return types.ErrNoRedelegation(k.Codespace())
 	}
 
-	// ensure that enough time has passed
 	ctxTime := ctx.BlockHeader().Time
-	if red.MinTime > ctxTime {
-		return types.ErrNotMature(k.Codespace(), "redelegation", "unit-time", red.MinTime, ctxTime)
+
+	// loop through all the entries and remove the mature ones
+	for i := 0; i < len(red.Entries); i++ {
+		if red.Entries[i].IsMature(ctxTime) {
+			red.RemoveEntry(int64(i))
+			i--
+		}
 	}
 
-	k.RemoveRedelegation(ctx, red)
+	// set the redelegation or remove it if there are no more entries
+	if len(red.Entries) == 0 {
+		k.RemoveRedelegation(ctx, red)
+	} else {
+		k.SetRedelegation(ctx, red)
+	}
 	return nil
 }
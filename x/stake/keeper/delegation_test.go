@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// tests GetDelegation, GetDelegations, SetDelegation, RemoveDelegation, GetDelegations
+// tests GetDelegation, GetDelegations, GetDelegationsToValidator, SetDelegation, RemoveDelegation, GetDelegations
 func TestDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 10)
 	pool := keeper.GetPool(ctx)
@@ -86,11 +86,18 @@ func TestDelegation(t *testing.T) {
 	require.True(t, bond2to1.Equal(allBonds[3]))
 	require.True(t, bond2to2.Equal(allBonds[4]))
 	require.True(t, bond2to3.Equal(allBonds[5]))
+	resBonds = keeper.GetDelegationsToValidator(ctx, addrVals[2])
+	require.Equal(t, 2, len(resBonds))
+	require.True(t, bond1to3.Equal(resBonds[0]))
+	require.True(t, bond2to3.Equal(resBonds[1]))
 
 	// delete a record
 	keeper.RemoveDelegation(ctx, bond2to3)
 	_, found = keeper.GetDelegation(ctx, addrDels[1], addrVals[2])
 	require.False(t, found)
+	resBonds = keeper.GetDelegationsToValidator(ctx, addrVals[2])
+	require.Equal(t, 1, len(resBonds))
+	require.True(t, bond1to3.Equal(resBonds[0]))
 	resBonds = keeper.GetDelegations(ctx, addrDels[1], 5)
 	require.Equal(t, 2, len(resBonds))
 	require.True(t, bond2to1.Equal(resBonds[0]))
@@ -111,13 +118,7 @@ func TestDelegation(t *testing.T) {
 func TestUnbondingDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 
-	ubd := types.UnbondingDelegation{
-		DelegatorAddr:  addrDels[0],
-		ValidatorAddr:  addrVals[0],
-		CreationHeight: 0,
-		MinTime:        0,
-		Balance:        sdk.NewCoin("steak", 5),
-	}
+	ubd := types.NewUnbondingDelegation(addrDels[0], addrVals[0], 0, 0, sdk.NewCoin("steak", 5))
 
 	// set and retrieve a record
 	keeper.SetUnbondingDelegation(ctx, ubd)
@@ -126,7 +127,7 @@ func TestUnbondingDelegation(t *testing.T) {
 	require.True(t, ubd.Equal(resBond))
 
 	// modify a records, save, and retrieve
-	ubd.Balance = sdk.NewCoin("steak", 21)
+	ubd.Entries[0].Balance = sdk.NewCoin("steak", 21)
 	keeper.SetUnbondingDelegation(ctx, ubd)
 	resBond, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
@@ -138,6 +139,119 @@ func TestUnbondingDelegation(t *testing.T) {
 	require.False(t, found)
 }
 
+// tests Insert/DequeueAllMature for the unbonding and redelegation queues
+func TestUnbondingAndRedelegationQueues(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	ubd1 := types.UnbondingDelegation{DelegatorAddr: addrDels[0], ValidatorAddr: addrVals[0]}
+	ubd2 := types.UnbondingDelegation{DelegatorAddr: addrDels[1], ValidatorAddr: addrVals[0]}
+	ubd3 := types.UnbondingDelegation{DelegatorAddr: addrDels[0], ValidatorAddr: addrVals[1]}
+	keeper.InsertUBDQueue(ctx, ubd1, 10)
+	keeper.InsertUBDQueue(ctx, ubd3, 20)
+	keeper.InsertUBDQueue(ctx, ubd2, 10)
+	require.Equal(t, 2, len(keeper.GetUBDQueueTimeSlice(ctx, 10)))
+
+	// an unbonding delegation is inserted once per timeslice
+	keeper.InsertUBDQueue(ctx, ubd1, 10)
+	require.Equal(t, 2, len(keeper.GetUBDQueueTimeSlice(ctx, 10)))
+
+	// nothing is mature before the earliest completion time
+	require.Equal(t, 0, len(keeper.DequeueAllMatureUBDQueue(ctx, 9)))
+
+	// entries are dequeued in order of completion time, then of insertion
+	mature := keeper.DequeueAllMatureUBDQueue(ctx, 20)
+	require.Equal(t, []types.DVPair{
+		{addrDels[0], addrVals[0]},
+		{addrDels[1], addrVals[0]},
+		{addrDels[0], addrVals[1]},
+	}, mature)
+
+	// dequeued entries are removed from the queue
+	require.Equal(t, 0, len(keeper.DequeueAllMatureUBDQueue(ctx, 20)))
+
+	// a pair queued in several mature timeslices is dequeued once
+	keeper.InsertUBDQueue(ctx, ubd1, 30)
+	keeper.InsertUBDQueue(ctx, ubd2, 30)
+	keeper.InsertUBDQueue(ctx, ubd1, 40)
+	mature = keeper.DequeueAllMatureUBDQueue(ctx, 40)
+	require.Equal(t, []types.DVPair{{addrDels[0], addrVals[0]}, {addrDels[1], addrVals[0]}}, mature)
+
+	red1 := types.Redelegation{DelegatorAddr: addrDels[0], ValidatorSrcAddr: addrVals[0], ValidatorDstAddr: addrVals[1]}
+	red2 := types.Redelegation{DelegatorAddr: addrDels[1], ValidatorSrcAddr: addrVals[0], ValidatorDstAddr: addrVals[1]}
+	keeper.InsertRedelegationQueue(ctx, red1, 10)
+	keeper.InsertRedelegationQueue(ctx, red2, 20)
+
+	matureReds := keeper.DequeueAllMatureRedelegationQueue(ctx, 10)
+	require.Equal(t, []types.DVVTriplet{{addrDels[0], addrVals[0], addrVals[1]}}, matureReds)
+	require.Equal(t, 1, len(keeper.GetRedelegationQueueTimeSlice(ctx, 20)))
+
+	keeper.InsertRedelegationQueue(ctx, red1, 20)
+	keeper.InsertRedelegationQueue(ctx, red1, 30)
+	matureReds = keeper.DequeueAllMatureRedelegationQueue(ctx, 30)
+	require.Equal(t, []types.DVVTriplet{
+		{addrDels[1], addrVals[0], addrVals[1]},
+		{addrDels[0], addrVals[0], addrVals[1]},
+	}, matureReds)
+}
+
+// tests that unbonding delegations hold concurrent entries which complete separately
+func TestUnbondingDelegationEntries(t *testing.T) {
+	ctx, accMapper, keeper := CreateTestInput(t, false, 0)
+
+	// the module account holds the unbonding tokens
+	fundModuleAccount(t, ctx, keeper, 12)
+
+	// add two entries to the same delegator/validator pair
+	keeper.SetUnbondingDelegationEntry(ctx, addrDels[0], addrVals[0], 1, 10, sdk.NewCoin("steak", 5))
+	keeper.SetUnbondingDelegationEntry(ctx, addrDels[0], addrVals[0], 2, 20, sdk.NewCoin("steak", 7))
+	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, 2, len(ubd.Entries))
+
+	// completing at time 10 only removes the first entry
+	header := ctx.BlockHeader()
+	header.Time = 10
+	ctx = ctx.WithBlockHeader(header)
+	err := keeper.CompleteUnbonding(ctx, addrDels[0], addrVals[0])
+	require.Nil(t, err)
+	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, []types.UnbondingDelegationEntry{
+		types.NewUnbondingDelegationEntry(2, 20, sdk.NewCoin("steak", 7)),
+	}, ubd.Entries)
+	require.Equal(t, int64(5), accMapper.GetAccount(ctx, addrDels[0]).GetCoins().AmountOf("steak").Int64())
+
+	// the unbonding delegation is removed with its last entry
+	header.Time = 20
+	ctx = ctx.WithBlockHeader(header)
+	err = keeper.CompleteUnbonding(ctx, addrDels[0], addrVals[0])
+	require.Nil(t, err)
+	_, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.False(t, found)
+	require.Equal(t, int64(12), accMapper.GetAccount(ctx, addrDels[0]).GetCoins().AmountOf("steak").Int64())
+}
+
+// tests that the mature entries of an unbonding delegation complete together,
+// without paying any of them out if the coins of all can't be returned
+func TestCompleteUnbondingAllOrNothing(t *testing.T) {
+	ctx, accMapper, keeper := CreateTestInput(t, false, 0)
+
+	// the module account only holds the coins of the first entry
+	fundModuleAccount(t, ctx, keeper, 5)
+	keeper.SetUnbondingDelegationEntry(ctx, addrDels[0], addrVals[0], 1, 10, sdk.NewCoin("steak", 5))
+	keeper.SetUnbondingDelegationEntry(ctx, addrDels[0], addrVals[0], 2, 10, sdk.NewCoin("steak", 7))
+
+	header := ctx.BlockHeader()
+	header.Time = 10
+	ctx = ctx.WithBlockHeader(header)
+	err := keeper.CompleteUnbonding(ctx, addrDels[0], addrVals[0])
+	require.NotNil(t, err)
+	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, 2, len(ubd.Entries))
+	require.True(t, accMapper.GetAccount(ctx, addrDels[0]).GetCoins().AmountOf("steak").IsZero())
+}
+
 func TestUnbondDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
@@ -179,19 +293,59 @@ func TestUnbondDelegation(t *testing.T) {
 	require.Equal(t, int64(4), pool.BondedTokens.RoundInt64())
 }
 
+// tests unbonding and redelegating fractional shares, only the whole tokens
+// are paid out or delegated and the fraction stays loose in the pool
+func TestUnbondFractionalShares(t *testing.T) {
+	ctx, accMapper, keeper := CreateTestInput(t, false, 100)
+	for i := 0; i < 2; i++ {
+		validator := types.NewValidator(addrVals[i], PKs[i], types.Description{})
+		_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin("steak", 10), validator, true)
+		require.Nil(t, err)
+	}
+	oldPool := keeper.GetPool(ctx)
+
+	// unbonding 3.5 shares removes 3.5 tokens from the validator and pays 3
+	err := keeper.BeginUnbonding(ctx, addrDels[0], addrVals[0], sdk.NewRat(7, 2))
+	require.Nil(t, err)
+	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoin("steak", 3), ubd.Entries[0].Balance)
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewRat(13, 2).Equal(validator.Tokens))
+
+	// redelegating 3.5 shares delegates 3 tokens to the destination validator
+	err = keeper.BeginRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1], sdk.NewRat(7, 2))
+	require.Nil(t, err)
+	red, found := keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoin("steak", 3), red.Entries[0].Balance)
+	validator, found = keeper.GetValidator(ctx, addrVals[1])
+	require.True(t, found)
+	require.True(t, sdk.NewRat(13).Equal(validator.Tokens))
+
+	// the fractions removed from the validators stay loose in the pool
+	pool := keeper.GetPool(ctx)
+	require.True(t, sdk.NewRat(4).Equal(pool.LooseTokens.Sub(oldPool.LooseTokens)))
+	require.True(t, oldPool.TokenSupply().Equal(pool.TokenSupply()))
+
+	// only the balance of the entry is paid out, the module account keeps the
+	// fraction of a token
+	header := ctx.BlockHeader()
+	header.Time = keeper.GetParams(ctx).UnbondingTime
+	ctx = ctx.WithBlockHeader(header)
+	err = keeper.CompleteUnbonding(ctx, addrDels[0], addrVals[0])
+	require.Nil(t, err)
+	require.Equal(t, int64(83), accMapper.GetAccount(ctx, addrDels[0]).GetCoins().AmountOf("steak").Int64())
+	require.Equal(t, int64(17), keeper.GetModuleAccount(ctx).GetCoins().AmountOf("steak").Int64())
+}
+
 // Make sure that that the retrieving the delegations doesn't affect the state
 func TestGetRedelegationsFromValidator(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 
-	rd := types.Redelegation{
-		DelegatorAddr:    addrDels[0],
-		ValidatorSrcAddr: addrVals[0],
-		ValidatorDstAddr: addrVals[1],
-		CreationHeight:   0,
-		MinTime:          0,
-		SharesSrc:        sdk.NewRat(5),
-		SharesDst:        sdk.NewRat(5),
-	}
+	rd := types.NewRedelegation(addrDels[0], addrVals[0], addrVals[1], 0, 0,
+		sdk.NewCoin("steak", 5), sdk.NewRat(5), sdk.NewRat(5))
 
 	// set and retrieve a record
 	keeper.SetRedelegation(ctx, rd)
@@ -213,15 +367,8 @@ func TestGetRedelegationsFromValidator(t *testing.T) {
 func TestRedelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 
-	rd := types.Redelegation{
-		DelegatorAddr:    addrDels[0],
-		ValidatorSrcAddr: addrVals[0],
-		ValidatorDstAddr: addrVals[1],
-		CreationHeight:   0,
-		MinTime:          0,
-		SharesSrc:        sdk.NewRat(5),
-		SharesDst:        sdk.NewRat(5),
-	}
+	rd := types.NewRedelegation(addrDels[0], addrVals[0], addrVals[1], 0, 0,
+		sdk.NewCoin("steak", 5), sdk.NewRat(5), sdk.NewRat(5))
 
 	// test shouldn't have and redelegations
 	has := keeper.HasReceivingRedelegation(ctx, addrDels[0], addrVals[1])
@@ -241,8 +388,8 @@ func TestRedelegation(t *testing.T) {
 	require.True(t, has)
 
 	// modify a records, save, and retrieve
-	rd.SharesSrc = sdk.NewRat(21)
-	rd.SharesDst = sdk.NewRat(21)
+	rd.Entries[0].SharesSrc = sdk.NewRat(21)
+	rd.Entries[0].SharesDst = sdk.NewRat(21)
 	keeper.SetRedelegation(ctx, rd)
 
 	resBond, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
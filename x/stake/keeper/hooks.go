@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// RegisterHooks registers hooks which are called on the validator and
+// delegation events of the keeper, hooks are called in the order they were
+// registered
+func (k Keeper) RegisterHooks(hooks sdk.StakingHooks) {
+	*k.hooks = append(*k.hooks, hooks)
+}
+
+// BeforeValidatorCreated asks the hooks whether a validator can be created
+// with the consensus key, the first error rejects its creation
+func (k Keeper) BeforeValidatorCreated(ctx sdk.Context, pubkey crypto.PubKey) sdk.Error {
+	for _, hooks := range *k.hooks {
+		err := hooks.BeforeValidatorCreated(ctx, pubkey)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k Keeper) onValidatorCreated(ctx sdk.Context, validator types.Validator) {
+	for _, hooks := range *k.hooks {
+		hooks.OnValidatorCreated(ctx, validator)
+	}
+}
+
+func (k Keeper) onValidatorBonded(ctx sdk.Context, validator types.Validator) {
+	for _, hooks := range *k.hooks {
+		hooks.OnValidatorBonded(ctx, validator)
+	}
+}
+
+func (k Keeper) onValidatorBeginUnbonding(ctx sdk.Context, validator types.Validator) {
+	for _, hooks := range *k.hooks {
+		hooks.OnValidatorBeginUnbonding(ctx, validator)
+	}
+}
+
+func (k Keeper) onValidatorRemoved(ctx sdk.Context, validator types.Validator) {
+	for _, hooks := range *k.hooks {
+		hooks.OnValidatorRemoved(ctx, validator)
+	}
+}
+
+func (k Keeper) onValidatorSlashed(ctx sdk.Context, validator types.Validator, fraction sdk.Rat) {
+	for _, hooks := range *k.hooks {
+		hooks.OnValidatorSlashed(ctx, validator, fraction)
+	}
+}
+
+func (k Keeper) onDelegationModified(ctx sdk.Context, delegatorAddr, validatorAddr sdk.AccAddress) {
+	for _, hooks := range *k.hooks {
+		hooks.OnDelegationModified(ctx, delegatorAddr, validatorAddr)
+	}
+}
@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// hooks recording the events they are called with
+type recordingHooks struct {
+	events []string
+}
+
+var _ sdk.StakingHooks = &recordingHooks{}
+
+func (h *recordingHooks) BeforeValidatorCreated(_ sdk.Context, _ crypto.PubKey) sdk.Error {
+	h.events = append(h.events, "beforeCreated")
+	return nil
+}
+func (h *recordingHooks) OnValidatorCreated(_ sdk.Context, _ sdk.Validator) {
+	h.events = append(h.events, "created")
+}
+func (h *recordingHooks) OnValidatorBonded(_ sdk.Context, _ sdk.Validator) {
+	h.events = append(h.events, "bonded")
+}
+func (h *recordingHooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.Validator) {
+	h.events = append(h.events, "beginUnbonding")
+}
+func (h *recordingHooks) OnValidatorRemoved(_ sdk.Context, _ sdk.Validator) {
+	h.events = append(h.events, "removed")
+}
+func (h *recordingHooks) OnValidatorSlashed(_ sdk.Context, _ sdk.Validator, _ sdk.Rat) {
+	h.events = append(h.events, "slashed")
+}
+func (h *recordingHooks) OnDelegationModified(_ sdk.Context, _, _ sdk.AccAddress) {
+	h.events = append(h.events, "delegationModified")
+}
+
+// pop the events recorded since the last call
+func (h *recordingHooks) pop() (events []string) {
+	events, h.events = h.events, nil
+	return
+}
+
+// tests that the hooks are called along the lifecycle of a validator
+func TestStakingHooks(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	hooks := &recordingHooks{}
+	keeper.RegisterHooks(hooks)
+
+	// hooks registered on a copy are shared by all copies of the keeper
+	keeperCopy := keeper
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeperCopy.CreateValidator(ctx, validator)
+	require.Equal(t, []string{"created"}, hooks.pop())
+
+	bondDenom := keeper.GetParams(ctx).BondDenom
+	_, err := keeper.Delegate(ctx, addrVals[0], sdk.NewCoin(bondDenom, 100), validator, true)
+	require.Nil(t, err)
+	events := hooks.pop()
+	require.Contains(t, events, "bonded")
+	require.Equal(t, "delegationModified", events[len(events)-1])
+
+	keeper.Slash(ctx, PKs[0], 0, 100, sdk.NewRat(1, 2))
+	require.Equal(t, []string{"slashed"}, hooks.pop())
+
+	// unbonding the whole self-delegation removes the validator
+	delegation, found := keeper.GetDelegation(ctx, addrVals[0], addrVals[0])
+	require.True(t, found)
+	err = keeper.BeginUnbonding(ctx, addrVals[0], addrVals[0], delegation.Shares)
+	require.Nil(t, err)
+	events = hooks.pop()
+	require.Contains(t, events, "beginUnbonding")
+	require.Contains(t, events, "removed")
+	require.Equal(t, "delegationModified", events[len(events)-1])
+}
@@ -0,0 +1,208 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// AllInvariants runs all invariants of the stake module
+func AllInvariants(k Keeper, am auth.AccountMapper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		invariants := []sdk.Invariant{
+			SupplyInvariants(k, am),
+			PositivePowerInvariant(k),
+			DelegatorSharesInvariant(k),
+			PowerIndexInvariant(k),
+			UnbondingBalancesInvariant(k),
+		}
+		for _, invariant := range invariants {
+			if err := invariant(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// SupplyInvariants checks that the loose tokens of the pool equal the bond
+// tokens held by accounts, unbonding delegations and unbonding or unbonded
+// validators, and that the bonded tokens of the pool equal the tokens of
+// bonded validators
+func SupplyInvariants(k Keeper, am auth.AccountMapper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		pool := k.GetPool(ctx)
+		bondDenom := k.GetParams(ctx).BondDenom
+
+		loose := sdk.ZeroInt()
+		bonded := sdk.ZeroRat()
+		moduleAddr := auth.NewModuleAddress(types.ModuleName)
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			// bonded and unbonding tokens are held by the module account
+			if bytes.Equal(acc.GetAddress(), moduleAddr) {
+				return false
+			}
+			loose = loose.Add(acc.GetCoins().AmountOf(bondDenom))
+			return false
+		})
+		k.IterateUnbondingDelegations(ctx, func(_ int64, ubd types.UnbondingDelegation) bool {
+			for _, entry := range ubd.Entries {
+				loose = loose.Add(entry.Balance.Amount)
+			}
+			return false
+		})
+		k.IterateValidators(ctx, func(_ int64, validator sdk.Validator) bool {
+			switch validator.GetStatus() {
+			case sdk.Bonded:
+				bonded = bonded.Add(validator.GetPower())
+			case sdk.Unbonding, sdk.Unbonded:
+				loose = loose.Add(validator.GetTokens().RoundInt())
+			}
+			return false
+		})
+
+		if pool.LooseTokens.RoundInt64() != loose.Int64() {
+			return fmt.Errorf("pool loose tokens %v differ from the tokens held by accounts, "+
+				"unbonding delegations and unbonding or unbonded validators %v", pool.LooseTokens.RoundInt64(), loose)
+		}
+		if !pool.BondedTokens.Equal(bonded) {
+			return fmt.Errorf("pool bonded tokens %v differ from the tokens of bonded validators %v",
+				pool.BondedTokens, bonded)
+		}
+		return nil
+	}
+}
+
+// PositivePowerInvariant checks that all bonded validators have a positive
+// power
+func PositivePowerInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (err error) {
+		k.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) bool {
+			if !validator.GetPower().GT(sdk.ZeroRat()) {
+				err = fmt.Errorf("bonded validator %v has a non-positive power %v",
+					validator.GetOwner(), validator.GetPower())
+				return true
+			}
+			return false
+		})
+		return
+	}
+}
+
+// DelegatorSharesInvariant checks that the delegator shares of each validator
+// equal the sum of the shares of the delegations to it
+func DelegatorSharesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		shares := make(map[string]sdk.Rat)
+		for _, delegation := range k.GetAllDelegations(ctx) {
+			key := delegation.ValidatorAddr.String()
+			if _, ok := shares[key]; !ok {
+				shares[key] = sdk.ZeroRat()
+			}
+			shares[key] = shares[key].Add(delegation.Shares)
+		}
+
+		for _, validator := range k.GetAllValidators(ctx) {
+			key := validator.Owner.String()
+			delegated, ok := shares[key]
+			if !ok {
+				delegated = sdk.ZeroRat()
+			}
+			if !validator.DelegatorShares.Equal(delegated) {
+				return fmt.Errorf("validator %v has delegator shares %v but its delegations hold %v shares",
+					validator.Owner, validator.DelegatorShares, delegated)
+			}
+			delete(shares, key)
+		}
+		for key := range shares {
+			return fmt.Errorf("delegations to validator %v which doesn't exist", key)
+		}
+		return nil
+	}
+}
+
+// PowerIndexInvariant checks that each validator which isn't jailed has a
+// single entry in the power index, at the key computed from its current state,
+// and that jailed validators have none
+func PowerIndexInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		store := ctx.KVStore(k.storeKey)
+		pool := k.GetPool(ctx)
+
+		indexed := make(map[string][]byte)
+		iterator := sdk.KVStorePrefixIterator(store, ValidatorsByPowerIndexKey)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			owner := sdk.AccAddress(iterator.Value())
+			if _, ok := indexed[owner.String()]; ok {
+				return fmt.Errorf("validator %v has several entries in the power index", owner)
+			}
+			indexed[owner.String()] = iterator.Key()
+		}
+
+		for _, validator := range k.GetAllValidators(ctx) {
+			key, ok := indexed[validator.Owner.String()]
+			if validator.Jailed {
+				if ok {
+					return fmt.Errorf("jailed validator %v is in the power index", validator.Owner)
+				}
+				continue
+			}
+			if !ok {
+				return fmt.Errorf("validator %v is missing from the power index", validator.Owner)
+			}
+			if !bytes.Equal(key, GetValidatorsByPowerIndexKey(validator, pool)) {
+				return fmt.Errorf("power index entry of validator %v is out of date", validator.Owner)
+			}
+			delete(indexed, validator.Owner.String())
+		}
+		for owner := range indexed {
+			return fmt.Errorf("power index entry for validator %v which doesn't exist", owner)
+		}
+		return nil
+	}
+}
+
+// UnbondingBalancesInvariant checks that the balance of each unbonding
+// delegation entry is in the bond denom, non-negative and at most its initial
+// balance, and that the stake module account holds at least all the balances
+func UnbondingBalancesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (err error) {
+		bondDenom := k.GetParams(ctx).BondDenom
+
+		total := sdk.ZeroInt()
+		k.IterateUnbondingDelegations(ctx, func(_ int64, ubd types.UnbondingDelegation) bool {
+			for _, entry := range ubd.Entries {
+				switch {
+				case entry.Balance.Denom != bondDenom:
+					err = fmt.Errorf("unbonding delegation from %v to %v has a balance in %v",
+						ubd.DelegatorAddr, ubd.ValidatorAddr, entry.Balance.Denom)
+				case entry.Balance.Amount.Sign() < 0:
+					err = fmt.Errorf("unbonding delegation from %v to %v has a negative balance %v",
+						ubd.DelegatorAddr, ubd.ValidatorAddr, entry.Balance.Amount)
+				case entry.Balance.Amount.GT(entry.InitialBalance.Amount):
+					err = fmt.Errorf("unbonding delegation from %v to %v has a balance %v above its initial balance %v",
+						ubd.DelegatorAddr, ubd.ValidatorAddr, entry.Balance.Amount, entry.InitialBalance.Amount)
+				}
+				if err != nil {
+					return true
+				}
+				total = total.Add(entry.Balance.Amount)
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+
+		held := k.GetModuleAccount(ctx).GetCoins().AmountOf(bondDenom)
+		if held.LT(total) {
+			return fmt.Errorf("stake module account holds %v tokens, less than the unbonding balances %v",
+				held, total)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestInvariants(t *testing.T) {
+	ctx, am, keeper := CreateTestInput(t, false, 100)
+	require.Nil(t, AllInvariants(keeper, am)(ctx))
+
+	// create a validator with a self delegation and an external delegation,
+	// then unbond part of the external delegation
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.CreateValidator(ctx, validator)
+	_, err := keeper.Delegate(ctx, addrVals[0], sdk.NewCoin("steak", 10), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	_, err = keeper.Delegate(ctx, addrDels[0], sdk.NewCoin("steak", 20), validator, true)
+	require.Nil(t, err)
+	err = keeper.BeginUnbonding(ctx, addrDels[0], addrVals[0], sdk.NewRat(5))
+	require.Nil(t, err)
+	require.Nil(t, AllInvariants(keeper, am)(ctx))
+
+	// the tokens of an unbonding validator are loose
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	bondedPool := keeper.GetPool(ctx)
+	unbonding, unbondingPool := validator.UpdateStatus(bondedPool, sdk.Unbonding)
+	keeper.SetPool(ctx, unbondingPool)
+	keeper.SetValidator(ctx, unbonding)
+	require.Nil(t, SupplyInvariants(keeper, am)(ctx))
+	keeper.SetPool(ctx, bondedPool)
+	keeper.SetValidator(ctx, validator)
+
+	// delegator shares differing from the delegations are caught
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	broken := validator
+	broken.DelegatorShares = broken.DelegatorShares.Add(sdk.OneRat())
+	keeper.SetValidator(ctx, broken)
+	require.NotNil(t, DelegatorSharesInvariant(keeper)(ctx))
+	keeper.SetValidator(ctx, validator)
+	require.Nil(t, DelegatorSharesInvariant(keeper)(ctx))
+
+	// a power index entry out of date is caught
+	pool := keeper.GetPool(ctx)
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(GetValidatorsByPowerIndexKey(validator, pool))
+	require.NotNil(t, PowerIndexInvariant(keeper)(ctx))
+	broken.Tokens = broken.Tokens.Add(sdk.OneRat())
+	store.Set(GetValidatorsByPowerIndexKey(broken, pool), validator.Owner)
+	require.NotNil(t, PowerIndexInvariant(keeper)(ctx))
+	store.Delete(GetValidatorsByPowerIndexKey(broken, pool))
+	store.Set(GetValidatorsByPowerIndexKey(validator, pool), validator.Owner)
+	require.Nil(t, PowerIndexInvariant(keeper)(ctx))
+
+	// an unbonding balance above its initial balance is caught
+	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	ubd.Entries[0].Balance.Amount = ubd.Entries[0].InitialBalance.Amount.AddRaw(1)
+	keeper.SetUnbondingDelegation(ctx, ubd)
+	require.NotNil(t, UnbondingBalancesInvariant(keeper)(ctx))
+}
@@ -4,26 +4,31 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
 // keeper of the stake store
 type Keeper struct {
-	storeKey   sdk.StoreKey
-	cdc        *wire.Codec
-	coinKeeper bank.Keeper
+	storeKey     sdk.StoreKey
+	cdc          *wire.Codec
+	coinKeeper   bank.Keeper
+	supplyKeeper bank.SupplyKeeper
+	hooks        *[]sdk.StakingHooks // registered with RegisterHooks
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, sk bank.SupplyKeeper, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
-		storeKey:   key,
-		cdc:        cdc,
-		coinKeeper: ck,
-		codespace:  codespace,
+		storeKey:     key,
+		cdc:          cdc,
+		coinKeeper:   ck,
+		supplyKeeper: sk,
+		hooks:        new([]sdk.StakingHooks),
+		codespace:    codespace,
 	}
 	return keeper
 }
@@ -94,6 +99,53 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.Pool) {
 	store.Set(PoolKey, b)
 }
 
+// the fraction of the staking token supply which is bonded
+func (k Keeper) BondedRatio(ctx sdk.Context) sdk.Rat {
+	return k.GetPool(ctx).BondedRatio()
+}
+
+// the total supply of the staking token
+func (k Keeper) StakingTokenSupply(ctx sdk.Context) sdk.Rat {
+	return k.GetPool(ctx).TokenSupply()
+}
+
+// add newly minted staking tokens to the loose tokens of the pool, the tokens
+// themselves are minted by the caller
+func (k Keeper) InflateSupply(ctx sdk.Context, newTokens sdk.Int) {
+	pool := k.GetPool(ctx)
+	pool.LooseTokens = pool.LooseTokens.Add(sdk.NewRatFromInt(newTokens))
+	k.SetPool(ctx, pool)
+}
+
+//_______________________________________________________________________
+
+// return the coins of the bond denom held by the stake module account
+func (k Keeper) bondCoins(ctx sdk.Context, amt sdk.Int) sdk.Coins {
+	return sdk.Coins{sdk.NewIntCoin(k.GetParams(ctx).BondDenom, amt)}
+}
+
+// burn loose bond tokens held by the stake module account, removing them from
+// the pool and the total supply. The amount is truncated once so that the pool
+// loses exactly the whole tokens burned, a fraction of a token stays loose.
+func (k Keeper) burnTokens(ctx sdk.Context, amt sdk.Rat) {
+	burned := amt.TruncateInt()
+	if burned.IsZero() {
+		return
+	}
+	pool := k.GetPool(ctx)
+	pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewRatFromInt(burned))
+	k.SetPool(ctx, pool)
+	err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, k.bondCoins(ctx, burned))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetModuleAccount returns the account holding all bonded and unbonding tokens
+func (k Keeper) GetModuleAccount(ctx sdk.Context) *auth.ModuleAccount {
+	return k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName)
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"encoding/binary"
+	"strings"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -11,7 +12,7 @@ import (
 
 // TODO remove some of these prefixes once have working multistore
 
-//nolint
+// nolint
 var (
 	// Keys for store prefixes
 	ParamKey                         = []byte{0x00} // key for parameters relating to staking
@@ -30,6 +31,10 @@ var (
 	RedelegationKey                  = []byte{0x0D} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by source validator owner
 	RedelegationByValDstIndexKey     = []byte{0x0F} // prefix for each key for an redelegation, by destination validator owner
+	UnbondingQueueKey                = []byte{0x10} // prefix for the timestamps in unbonding queue
+	RedelegationQueueKey             = []byte{0x11} // prefix for the timestamps in redelegations queue
+	ValidatorsByMonikerIndexKey      = []byte{0x12} // prefix for each key to a validator index, by moniker
+	DelegationByValIndexKey          = []byte{0x13} // prefix for each key for a delegation, by validator owner
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -46,6 +51,13 @@ func GetValidatorByPubKeyIndexKey(pubkey crypto.PubKey) []byte {
 	return append(ValidatorsByPubKeyIndexKey, pubkey.Bytes()...)
 }
 
+// get the key for the validator with moniker, monikers are indexed
+// case-insensitively.
+// VALUE: validator owner address ([]byte)
+func GetValidatorByMonikerIndexKey(moniker string) []byte {
+	return append(ValidatorsByMonikerIndexKey, []byte(strings.ToLower(moniker))...)
+}
+
 // get the key for the current validator group
 // VALUE: none (key rearrangement with GetValKeyFromValBondedIndexKey)
 func GetValidatorsBondedIndexKey(ownerAddr sdk.AccAddress) []byte {
@@ -72,22 +84,14 @@ func getValidatorPowerRank(validator types.Validator, pool types.Pool) []byte {
 	potentialPower := validator.Tokens
 	powerBytes := []byte(potentialPower.ToLeftPadded(maxDigitsForAccount)) // power big-endian (more powerful validators first)
 
-	revokedBytes := make([]byte, 1)
-	if validator.Revoked {
-		revokedBytes[0] = byte(0x00)
-	} else {
-		revokedBytes[0] = byte(0x01)
-	}
-
 	// heightBytes and counterBytes represent strings like powerBytes does
 	heightBytes := make([]byte, binary.MaxVarintLen64)
 	binary.BigEndian.PutUint64(heightBytes, ^uint64(validator.BondHeight)) // invert height (older validators first)
 	counterBytes := make([]byte, 2)
 	binary.BigEndian.PutUint16(counterBytes, ^uint16(validator.BondIntraTxCounter)) // invert counter (first txns have priority)
 
-	return append(append(append(append(
+	return append(append(append(
 		ValidatorsByPowerIndexKey,
-		revokedBytes...),
 		powerBytes...),
 		heightBytes...),
 		counterBytes...)
@@ -113,6 +117,28 @@ func GetDelegationsKey(delegatorAddr sdk.AccAddress) []byte {
 	return append(DelegationKey, delegatorAddr.Bytes()...)
 }
 
+// get the index-key for a delegation, stored by validator-index
+// VALUE: none (key rearrangement used)
+func GetDelegationByValIndexKey(delegatorAddr, validatorAddr sdk.AccAddress) []byte {
+	return append(GetDelegationsByValIndexKey(validatorAddr), delegatorAddr.Bytes()...)
+}
+
+// rearrange the ValIndexKey to get the DelegationKey
+func GetDelegationKeyFromValIndexKey(IndexKey []byte) []byte {
+	addrs := IndexKey[1:] // remove prefix bytes
+	if len(addrs) != 2*sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	valAddr := addrs[:sdk.AddrLen]
+	delAddr := addrs[sdk.AddrLen:]
+	return GetDelegationKey(delAddr, valAddr)
+}
+
+// get the prefix keyspace for the indexes of delegations to a validator
+func GetDelegationsByValIndexKey(validatorAddr sdk.AccAddress) []byte {
+	return append(DelegationByValIndexKey, validatorAddr.Bytes()...)
+}
+
 //________________________________________________________________________________
 
 // get the key for an unbonding delegation by delegator and validator addr.
@@ -140,6 +166,14 @@ func GetUBDKeyFromValIndexKey(IndexKey []byte) []byte {
 	return GetUBDKey(delAddr, valAddr)
 }
 
+// get the prefix of the unbonding queue for a completion time.
+// VALUE: []stake/types.DVPair
+func GetUnbondingDelegationTimeKey(timestamp int64) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(timestamp))
+	return append(UnbondingQueueKey, timeBytes...)
+}
+
 //______________
 
 // get the prefix for all unbonding delegations from a delegator
@@ -206,6 +240,14 @@ func GetREDKeyFromValDstIndexKey(IndexKey []byte) []byte {
 	return GetREDKey(delAddr, valSrcAddr, valDstAddr)
 }
 
+// get the prefix of the redelegation queue for a completion time.
+// VALUE: []stake/types.DVVTriplet
+func GetRedelegationTimeKey(timestamp int64) []byte {
+	timeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timeBytes, uint64(timestamp))
+	return append(RedelegationQueueKey, timeBytes...)
+}
+
 //______________
 
 // get the prefix keyspace for redelegations from a delegator
@@ -95,12 +95,14 @@ func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight in
 	pool := k.GetPool(ctx)
 	// remove tokens from the validator
 	validator, pool = validator.RemoveTokens(pool, tokensToBurn)
-	// burn tokens
-	pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
 	// update the pool
 	k.SetPool(ctx, pool)
+	// burn the tokens from the pool, the module account and the total supply
+	k.burnTokens(ctx, tokensToBurn)
 	// update the validator, possibly kicking it out
 	validator = k.UpdateValidator(ctx, validator)
+	// let the hooks know about the slash before the validator may be removed
+	k.onValidatorSlashed(ctx, validator, slashFactor)
 	// remove validator if it has been reduced to zero shares
 	if validator.Tokens.IsZero() {
 		k.RemoveValidator(ctx, validator.Owner)
@@ -115,32 +117,36 @@ func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight in
 	return
 }
 
-// revoke a validator
-func (k Keeper) Revoke(ctx sdk.Context, pubkey crypto.PubKey) {
-	k.setRevoked(ctx, pubkey, true)
-	logger := ctx.Logger().With("module", "x/stake")
-	logger.Info(fmt.Sprintf("Validator %s revoked", pubkey.Address()))
-	// TODO Return event(s), blocked on https://github.com/tendermint/tendermint/pull/1803
-	return
-}
-
-// unrevoke a validator
-func (k Keeper) Unrevoke(ctx sdk.Context, pubkey crypto.PubKey) {
-	k.setRevoked(ctx, pubkey, false)
+// jail a validator until a time, removing it from the power index and
+// unbonding it at once, a jailed validator cannot be unjailed before the
+// latest of the times it was jailed until
+func (k Keeper) Jail(ctx sdk.Context, pubkey crypto.PubKey, jailedUntil int64) {
+	validator, found := k.GetValidatorByPubKey(ctx, pubkey)
+	if !found {
+		panic(fmt.Errorf("Validator with pubkey %s not found, cannot jail it", pubkey))
+	}
+	validator.Jailed = true
+	if jailedUntil > validator.JailedUntil {
+		validator.JailedUntil = jailedUntil
+	}
+	k.UpdateValidator(ctx, validator) // update validator, unbonding it
 	logger := ctx.Logger().With("module", "x/stake")
-	logger.Info(fmt.Sprintf("Validator %s unrevoked", pubkey.Address()))
+	logger.Info(fmt.Sprintf("Validator %s jailed until %d", pubkey.Address(), validator.JailedUntil))
 	// TODO Return event(s), blocked on https://github.com/tendermint/tendermint/pull/1803
 	return
 }
 
-// set the revoked flag on a validator
-func (k Keeper) setRevoked(ctx sdk.Context, pubkey crypto.PubKey, revoked bool) {
+// unjail a validator
+func (k Keeper) Unjail(ctx sdk.Context, pubkey crypto.PubKey) {
 	validator, found := k.GetValidatorByPubKey(ctx, pubkey)
 	if !found {
-		panic(fmt.Errorf("Validator with pubkey %s not found, cannot set revoked to %v", pubkey, revoked))
+		panic(fmt.Errorf("Validator with pubkey %s not found, cannot unjail it", pubkey))
 	}
-	validator.Revoked = revoked
-	k.UpdateValidator(ctx, validator) // update validator, possibly unbonding or bonding it
+	validator.Jailed = false
+	k.UpdateValidator(ctx, validator) // update validator, possibly bonding it
+	logger := ctx.Logger().With("module", "x/stake")
+	logger.Info(fmt.Sprintf("Validator %s unjailed", pubkey.Address()))
+	// TODO Return event(s), blocked on https://github.com/tendermint/tendermint/pull/1803
 	return
 }
 
@@ -150,42 +156,47 @@ func (k Keeper) setRevoked(ctx sdk.Context, pubkey crypto.PubKey, revoked bool)
 // (the amount actually slashed may be less if there's
 // insufficient stake remaining)
 func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation types.UnbondingDelegation,
-	infractionHeight int64, slashFactor sdk.Rat) (slashAmount sdk.Rat) {
+	infractionHeight int64, slashFactor sdk.Rat) (totalSlashAmount sdk.Rat) {
 
 	now := ctx.BlockHeader().Time
+	totalSlashAmount = sdk.ZeroRat()
 
-	// If unbonding started before this height, stake didn't contribute to infraction
-	if unbondingDelegation.CreationHeight < infractionHeight {
-		return sdk.ZeroRat()
-	}
+	// perform slashing on all entries within the unbonding delegation
+	for i, entry := range unbondingDelegation.Entries {
 
-	if unbondingDelegation.MinTime < now {
-		// Unbonding delegation no longer eligible for slashing, skip it
-		// TODO Settle and delete it automatically?
-		return sdk.ZeroRat()
-	}
+		// If unbonding started before this height, stake didn't contribute to infraction
+		if entry.CreationHeight < infractionHeight {
+			continue
+		}
+
+		if entry.MinTime < now {
+			// Unbonding delegation no longer eligible for slashing, skip it
+			continue
+		}
 
-	// Calculate slash amount proportional to stake contributing to infraction
-	slashAmount = sdk.NewRatFromInt(unbondingDelegation.InitialBalance.Amount, sdk.OneInt()).Mul(slashFactor)
+		// Calculate slash amount proportional to stake contributing to infraction
+		slashAmount := sdk.NewRatFromInt(entry.InitialBalance.Amount, sdk.OneInt()).Mul(slashFactor)
+		totalSlashAmount = totalSlashAmount.Add(slashAmount)
 
-	// Don't slash more tokens than held
-	// Possible since the unbonding delegation may already
-	// have been slashed, and slash amounts are calculated
-	// according to stake held at time of infraction
-	unbondingSlashAmount := sdk.MinInt(slashAmount.RoundInt(), unbondingDelegation.Balance.Amount)
+		// Don't slash more tokens than held
+		// Possible since the unbonding delegation may already
+		// have been slashed, and slash amounts are calculated
+		// according to stake held at time of infraction
+		unbondingSlashAmount := sdk.MinInt(slashAmount.RoundInt(), entry.Balance.Amount)
 
-	// Update unbonding delegation if necessary
-	if !unbondingSlashAmount.IsZero() {
-		unbondingDelegation.Balance.Amount = unbondingDelegation.Balance.Amount.Sub(unbondingSlashAmount)
+		// Update unbonding delegation if necessary
+		if unbondingSlashAmount.IsZero() {
+			continue
+		}
+		entry.Balance.Amount = entry.Balance.Amount.Sub(unbondingSlashAmount)
+		unbondingDelegation.Entries[i] = entry
 		k.SetUnbondingDelegation(ctx, unbondingDelegation)
-		pool := k.GetPool(ctx)
 		// Burn loose tokens
 		// Ref https://github.com/cosmos/cosmos-sdk/pull/1278#discussion_r198657760
-		pool.LooseTokens = pool.LooseTokens.Sub(slashAmount)
-		k.SetPool(ctx, pool)
+		k.burnTokens(ctx, sdk.NewRatFromInt(unbondingSlashAmount))
 	}
 
-	return
+	return totalSlashAmount
 }
 
 // slash a redelegation and update the pool
@@ -194,43 +205,50 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 // (the amount actually slashed may be less if there's
 // insufficient stake remaining)
 func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, redelegation types.Redelegation,
-	infractionHeight int64, slashFactor sdk.Rat) (slashAmount sdk.Rat) {
+	infractionHeight int64, slashFactor sdk.Rat) (totalSlashAmount sdk.Rat) {
 
 	now := ctx.BlockHeader().Time
+	totalSlashAmount = sdk.ZeroRat()
 
-	// If redelegation started before this height, stake didn't contribute to infraction
-	if redelegation.CreationHeight < infractionHeight {
-		return sdk.ZeroRat()
-	}
+	// perform slashing on all entries within the redelegation
+	for i, entry := range redelegation.Entries {
 
-	if redelegation.MinTime < now {
-		// Redelegation no longer eligible for slashing, skip it
-		// TODO Delete it automatically?
-		return sdk.ZeroRat()
-	}
-
-	// Calculate slash amount proportional to stake contributing to infraction
-	slashAmount = sdk.NewRatFromInt(redelegation.InitialBalance.Amount, sdk.OneInt()).Mul(slashFactor)
+		// If redelegation started before this height, stake didn't contribute to infraction
+		if entry.CreationHeight < infractionHeight {
+			continue
+		}
 
-	// Don't slash more tokens than held
-	// Possible since the redelegation may already
-	// have been slashed, and slash amounts are calculated
-	// according to stake held at time of infraction
-	redelegationSlashAmount := sdk.MinInt(slashAmount.RoundInt(), redelegation.Balance.Amount)
+		if entry.MinTime < now {
+			// Redelegation no longer eligible for slashing, skip it
+			continue
+		}
 
-	// Update redelegation if necessary
-	if !redelegationSlashAmount.IsZero() {
-		redelegation.Balance.Amount = redelegation.Balance.Amount.Sub(redelegationSlashAmount)
-		k.SetRedelegation(ctx, redelegation)
-	}
+		// Calculate slash amount proportional to stake contributing to infraction
+		slashAmount := sdk.NewRatFromInt(entry.InitialBalance.Amount, sdk.OneInt()).Mul(slashFactor)
+		totalSlashAmount = totalSlashAmount.Add(slashAmount)
+
+		// Don't slash more tokens than held
+		// Possible since the redelegation may already
+		// have been slashed, and slash amounts are calculated
+		// according to stake held at time of infraction
+		redelegationSlashAmount := sdk.MinInt(slashAmount.RoundInt(), entry.Balance.Amount)
+
+		// Update redelegation if necessary
+		if !redelegationSlashAmount.IsZero() {
+			entry.Balance.Amount = entry.Balance.Amount.Sub(redelegationSlashAmount)
+			redelegation.Entries[i] = entry
+			k.SetRedelegation(ctx, redelegation)
+		}
 
-	// Unbond from target validator
-	sharesToUnbond := slashFactor.Mul(redelegation.SharesDst)
-	if !sharesToUnbond.IsZero() {
+		// Unbond from target validator
+		sharesToUnbond := slashFactor.Mul(entry.SharesDst)
+		if sharesToUnbond.IsZero() {
+			continue
+		}
 		delegation, found := k.GetDelegation(ctx, redelegation.DelegatorAddr, redelegation.ValidatorDstAddr)
 		if !found {
 			// If deleted, delegation has zero shares, and we can't unbond any more
-			return slashAmount
+			continue
 		}
 		if sharesToUnbond.GT(delegation.Shares) {
 			sharesToUnbond = delegation.Shares
@@ -240,10 +258,8 @@ func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, re
 			panic(fmt.Errorf("error unbonding delegator: %v", err))
 		}
 		// Burn loose tokens
-		pool := k.GetPool(ctx)
-		pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
-		k.SetPool(ctx, pool)
+		k.burnTokens(ctx, tokensToBurn)
 	}
 
-	return slashAmount
+	return totalSlashAmount
 }
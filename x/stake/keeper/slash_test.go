@@ -30,11 +30,14 @@ func setupHelper(t *testing.T, amt int64) (sdk.Context, Keeper, types.Params) {
 	}
 	pool = keeper.GetPool(ctx)
 
+	// the module account holds the tokens of all validators
+	fundModuleAccount(t, ctx, keeper, amt*int64(numVals))
+
 	return ctx, keeper, params
 }
 
-// tests Revoke, Unrevoke
-func TestRevocation(t *testing.T) {
+// tests Jail, Unjail
+func TestJail(t *testing.T) {
 	// setup
 	ctx, keeper, _ := setupHelper(t, 10)
 	addr := addrVals[0]
@@ -43,19 +46,33 @@ func TestRevocation(t *testing.T) {
 	// initial state
 	val, found := keeper.GetValidator(ctx, addr)
 	require.True(t, found)
-	require.False(t, val.GetRevoked())
+	require.False(t, val.GetJailed())
+	require.Equal(t, sdk.Bonded, val.GetStatus())
+	power := GetValidatorsByPowerIndexKey(val, keeper.GetPool(ctx))
+	require.True(t, ValidatorByPowerIndexExists(ctx, keeper, power))
 
-	// test revoke
-	keeper.Revoke(ctx, pk)
+	// test jail, the validator leaves the power index and unbonds at once
+	keeper.Jail(ctx, pk, 100)
 	val, found = keeper.GetValidator(ctx, addr)
 	require.True(t, found)
-	require.True(t, val.GetRevoked())
+	require.True(t, val.GetJailed())
+	require.Equal(t, int64(100), val.GetJailedUntil())
+	require.Equal(t, sdk.Unbonded, val.GetStatus())
+	require.False(t, ValidatorByPowerIndexExists(ctx, keeper, power))
 
-	// test unrevoke
-	keeper.Unrevoke(ctx, pk)
+	// jailing again never shortens the jail time
+	keeper.Jail(ctx, pk, 50)
 	val, found = keeper.GetValidator(ctx, addr)
 	require.True(t, found)
-	require.False(t, val.GetRevoked())
+	require.Equal(t, int64(100), val.GetJailedUntil())
+
+	// test unjail
+	keeper.Unjail(ctx, pk)
+	val, found = keeper.GetValidator(ctx, addr)
+	require.True(t, found)
+	require.False(t, val.GetJailed())
+	power = GetValidatorsByPowerIndexKey(val, keeper.GetPool(ctx))
+	require.True(t, ValidatorByPowerIndexExists(ctx, keeper, power))
 
 }
 
@@ -64,16 +81,10 @@ func TestSlashUnbondingDelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	fraction := sdk.NewRat(1, 2)
 
-	// set an unbonding delegation
-	ubd := types.UnbondingDelegation{
-		DelegatorAddr:  addrDels[0],
-		ValidatorAddr:  addrVals[0],
-		CreationHeight: 0,
-		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
-		InitialBalance: sdk.NewCoin(params.BondDenom, 10),
-		Balance:        sdk.NewCoin(params.BondDenom, 10),
-	}
+	// set an unbonding delegation, with an expiration timestamp (beyond which the
+	// unbonding delegation shouldn't be slashed) of 0
+	ubd := types.NewUnbondingDelegation(addrDels[0], addrVals[0], 0, 0,
+		sdk.NewCoin(params.BondDenom, 10))
 	keeper.SetUnbondingDelegation(ctx, ubd)
 
 	// unbonding started prior to the infraction height, stake didn't contribute
@@ -95,31 +106,44 @@ func TestSlashUnbondingDelegation(t *testing.T) {
 	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	// initialbalance unchanged
-	require.Equal(t, sdk.NewCoin(params.BondDenom, 10), ubd.InitialBalance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 10), ubd.Entries[0].InitialBalance)
 	// balance decreased
-	require.Equal(t, sdk.NewCoin(params.BondDenom, 5), ubd.Balance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 5), ubd.Entries[0].Balance)
 	newPool := keeper.GetPool(ctx)
 	require.Equal(t, int64(5), oldPool.LooseTokens.Sub(newPool.LooseTokens).RoundInt64())
 }
 
+// tests slashUnbondingDelegation with several entries
+func TestSlashUnbondingDelegationEntries(t *testing.T) {
+	ctx, keeper, params := setupHelper(t, 10)
+	fraction := sdk.NewRat(1, 2)
+
+	// set an unbonding delegation with an entry created before the infraction
+	// and an entry created after it
+	ubd := types.NewUnbondingDelegation(addrDels[0], addrVals[0], 0, 10,
+		sdk.NewCoin(params.BondDenom, 10))
+	ubd.AddEntry(2, 10, sdk.NewCoin(params.BondDenom, 6))
+	keeper.SetUnbondingDelegation(ctx, ubd)
+
+	// only the entry to which stake contributed is slashed
+	slashAmount := keeper.slashUnbondingDelegation(ctx, ubd, 1, fraction)
+	require.Equal(t, int64(3), slashAmount.RoundInt64())
+	ubd, found := keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 10), ubd.Entries[0].Balance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 3), ubd.Entries[1].Balance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 6), ubd.Entries[1].InitialBalance)
+}
+
 // tests slashRedelegation
 func TestSlashRedelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	fraction := sdk.NewRat(1, 2)
 
-	// set a redelegation
-	rd := types.Redelegation{
-		DelegatorAddr:    addrDels[0],
-		ValidatorSrcAddr: addrVals[0],
-		ValidatorDstAddr: addrVals[1],
-		CreationHeight:   0,
-		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
-		MinTime:        0,
-		SharesSrc:      sdk.NewRat(10),
-		SharesDst:      sdk.NewRat(10),
-		InitialBalance: sdk.NewCoin(params.BondDenom, 10),
-		Balance:        sdk.NewCoin(params.BondDenom, 10),
-	}
+	// set a redelegation, with an expiration timestamp (beyond which the
+	// redelegation shouldn't be slashed) of 0
+	rd := types.NewRedelegation(addrDels[0], addrVals[0], addrVals[1], 0, 0,
+		sdk.NewCoin(params.BondDenom, 10), sdk.NewRat(10), sdk.NewRat(10))
 	keeper.SetRedelegation(ctx, rd)
 
 	// set the associated delegation
@@ -155,9 +179,9 @@ func TestSlashRedelegation(t *testing.T) {
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// initialbalance unchanged
-	require.Equal(t, sdk.NewCoin(params.BondDenom, 10), rd.InitialBalance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 10), rd.Entries[0].InitialBalance)
 	// balance decreased
-	require.Equal(t, sdk.NewCoin(params.BondDenom, 5), rd.Balance)
+	require.Equal(t, sdk.NewCoin(params.BondDenom, 5), rd.Entries[0].Balance)
 	// shares decreased
 	del, found = keeper.GetDelegation(ctx, addrDels[0], addrVals[1])
 	require.True(t, found)
@@ -197,22 +221,39 @@ func TestSlashAtCurrentHeight(t *testing.T) {
 	require.Equal(t, sdk.NewRat(5).RoundInt64(), oldPool.BondedTokens.Sub(newPool.BondedTokens).RoundInt64())
 }
 
+// tests Slash of a fraction of a token, only whole tokens are burned
+func TestSlashFractionalTokens(t *testing.T) {
+	ctx, keeper, params := setupHelper(t, 10)
+	pk := PKs[0]
+	fraction := sdk.NewRat(1, 4)
+
+	oldPool := keeper.GetPool(ctx)
+	keeper.Slash(ctx, pk, ctx.BlockHeight(), 10, fraction)
+
+	// the validator loses 2.5 tokens, of which 2 are burned
+	validator, found := keeper.GetValidatorByPubKey(ctx, pk)
+	require.True(t, found)
+	require.True(t, sdk.NewRat(15, 2).Equal(validator.GetPower()))
+	newPool := keeper.GetPool(ctx)
+	require.True(t, sdk.NewRat(5, 2).Equal(oldPool.BondedTokens.Sub(newPool.BondedTokens)))
+	require.True(t, sdk.NewRat(1, 2).Equal(newPool.LooseTokens.Sub(oldPool.LooseTokens)))
+
+	// the pool loses exactly the tokens burned from the module account
+	moduleTokens := keeper.GetModuleAccount(ctx).GetCoins().AmountOf(params.BondDenom)
+	require.Equal(t, sdk.NewInt(28), moduleTokens)
+	require.True(t, sdk.NewRat(2).Equal(oldPool.TokenSupply().Sub(newPool.TokenSupply())))
+}
+
 // tests Slash at a previous height with an unbonding delegation
 func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	pk := PKs[0]
 	fraction := sdk.NewRat(1, 2)
 
-	// set an unbonding delegation
-	ubd := types.UnbondingDelegation{
-		DelegatorAddr:  addrDels[0],
-		ValidatorAddr:  addrVals[0],
-		CreationHeight: 11,
-		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
-		InitialBalance: sdk.NewCoin(params.BondDenom, 4),
-		Balance:        sdk.NewCoin(params.BondDenom, 4),
-	}
+	// set an unbonding delegation, with an expiration timestamp (beyond which the
+	// unbonding delegation shouldn't be slashed) of 0
+	ubd := types.NewUnbondingDelegation(addrDels[0], addrVals[0], 11, 0,
+		sdk.NewCoin(params.BondDenom, 4))
 	keeper.SetUnbondingDelegation(ctx, ubd)
 
 	// slash validator for the first time
@@ -226,7 +267,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	// balance decreased
-	require.Equal(t, sdk.NewInt(2), ubd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(2), ubd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool := keeper.GetPool(ctx)
 	// bonded tokens burned
@@ -246,7 +287,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	// balance decreased again
-	require.Equal(t, sdk.NewInt(0), ubd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), ubd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// bonded tokens burned again
@@ -266,7 +307,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	// balance unchanged
-	require.Equal(t, sdk.NewInt(0), ubd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), ubd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// bonded tokens burned again
@@ -286,7 +327,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ubd, found = keeper.GetUnbondingDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	// balance unchanged
-	require.Equal(t, sdk.NewInt(0), ubd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), ubd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// just 1 bonded token burned again since that's all the validator now has
@@ -305,17 +346,8 @@ func TestSlashWithRedelegation(t *testing.T) {
 	fraction := sdk.NewRat(1, 2)
 
 	// set a redelegation
-	rd := types.Redelegation{
-		DelegatorAddr:    addrDels[0],
-		ValidatorSrcAddr: addrVals[0],
-		ValidatorDstAddr: addrVals[1],
-		CreationHeight:   11,
-		MinTime:          0,
-		SharesSrc:        sdk.NewRat(6),
-		SharesDst:        sdk.NewRat(6),
-		InitialBalance:   sdk.NewCoin(params.BondDenom, 6),
-		Balance:          sdk.NewCoin(params.BondDenom, 6),
-	}
+	rd := types.NewRedelegation(addrDels[0], addrVals[0], addrVals[1], 11, 0,
+		sdk.NewCoin(params.BondDenom, 6), sdk.NewRat(6), sdk.NewRat(6))
 	keeper.SetRedelegation(ctx, rd)
 
 	// set the associated delegation
@@ -342,7 +374,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// balance decreased
-	require.Equal(t, sdk.NewInt(3), rd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(3), rd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool := keeper.GetPool(ctx)
 	// bonded tokens burned
@@ -366,7 +398,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// balance decreased, now zero
-	require.Equal(t, sdk.NewInt(0), rd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), rd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// seven bonded tokens burned
@@ -387,7 +419,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// balance still zero
-	require.Equal(t, sdk.NewInt(0), rd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), rd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// four more bonded tokens burned
@@ -409,7 +441,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// balance still zero
-	require.Equal(t, sdk.NewInt(0), rd.Balance.Amount)
+	require.Equal(t, sdk.NewInt(0), rd.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool = keeper.GetPool(ctx)
 	// no more bonded tokens burned
@@ -425,19 +457,10 @@ func TestSlashBoth(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	fraction := sdk.NewRat(1, 2)
 
-	// set a redelegation
-	rdA := types.Redelegation{
-		DelegatorAddr:    addrDels[0],
-		ValidatorSrcAddr: addrVals[0],
-		ValidatorDstAddr: addrVals[1],
-		CreationHeight:   11,
-		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
-		MinTime:        0,
-		SharesSrc:      sdk.NewRat(6),
-		SharesDst:      sdk.NewRat(6),
-		InitialBalance: sdk.NewCoin(params.BondDenom, 6),
-		Balance:        sdk.NewCoin(params.BondDenom, 6),
-	}
+	// set a redelegation, with an expiration timestamp (beyond which the
+	// redelegation shouldn't be slashed) of 0
+	rdA := types.NewRedelegation(addrDels[0], addrVals[0], addrVals[1], 11, 0,
+		sdk.NewCoin(params.BondDenom, 6), sdk.NewRat(6), sdk.NewRat(6))
 	keeper.SetRedelegation(ctx, rdA)
 
 	// set the associated delegation
@@ -448,16 +471,10 @@ func TestSlashBoth(t *testing.T) {
 	}
 	keeper.SetDelegation(ctx, delA)
 
-	// set an unbonding delegation
-	ubdA := types.UnbondingDelegation{
-		DelegatorAddr:  addrDels[0],
-		ValidatorAddr:  addrVals[0],
-		CreationHeight: 11,
-		// expiration timestamp (beyond which the unbonding delegation shouldn't be slashed)
-		MinTime:        0,
-		InitialBalance: sdk.NewCoin(params.BondDenom, 4),
-		Balance:        sdk.NewCoin(params.BondDenom, 4),
-	}
+	// set an unbonding delegation, with an expiration timestamp (beyond which the
+	// unbonding delegation shouldn't be slashed) of 0
+	ubdA := types.NewUnbondingDelegation(addrDels[0], addrVals[0], 11, 0,
+		sdk.NewCoin(params.BondDenom, 4))
 	keeper.SetUnbondingDelegation(ctx, ubdA)
 
 	// slash validator
@@ -471,7 +488,7 @@ func TestSlashBoth(t *testing.T) {
 	rdA, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
 	require.True(t, found)
 	// balance decreased
-	require.Equal(t, sdk.NewInt(3), rdA.Balance.Amount)
+	require.Equal(t, sdk.NewInt(3), rdA.Entries[0].Balance.Amount)
 	// read updated pool
 	newPool := keeper.GetPool(ctx)
 	// loose tokens burned
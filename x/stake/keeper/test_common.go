@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -57,44 +58,34 @@ func MakeTestCodec() *wire.Codec {
 	// Register Msgs
 	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
 	cdc.RegisterConcrete(bank.MsgSend{}, "test/stake/Send", nil)
-	cdc.RegisterConcrete(bank.MsgIssue{}, "test/stake/Issue", nil)
 	cdc.RegisterConcrete(types.MsgCreateValidator{}, "test/stake/CreateValidator", nil)
 	cdc.RegisterConcrete(types.MsgEditValidator{}, "test/stake/EditValidator", nil)
 	cdc.RegisterConcrete(types.MsgBeginUnbonding{}, "test/stake/BeginUnbonding", nil)
-	cdc.RegisterConcrete(types.MsgCompleteUnbonding{}, "test/stake/CompleteUnbonding", nil)
 	cdc.RegisterConcrete(types.MsgBeginRedelegate{}, "test/stake/BeginRedelegate", nil)
-	cdc.RegisterConcrete(types.MsgCompleteRedelegate{}, "test/stake/CompleteRedelegate", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/stake/Account", nil)
+	cdc.RegisterConcrete(&auth.ModuleAccount{}, "test/stake/ModuleAccount", nil)
 	wire.RegisterCrypto(cdc)
 
 	return cdc
 }
 
-// default params without inflation
-func ParamsNoInflation() types.Params {
-	return types.Params{
-		InflationRateChange: sdk.ZeroRat(),
-		InflationMax:        sdk.ZeroRat(),
-		InflationMin:        sdk.ZeroRat(),
-		GoalBonded:          sdk.NewRat(67, 100),
-		MaxValidators:       100,
-		BondDenom:           "steak",
-	}
-}
-
 // hogpodge of all sorts of input required for testing
 func CreateTestInput(t *testing.T, isCheckTx bool, initCoins int64) (sdk.Context, auth.AccountMapper, Keeper) {
 
 	keyStake := sdk.NewKVStoreKey("stake")
 	keyAcc := sdk.NewKVStoreKey("acc")
+	keySupply := sdk.NewKVStoreKey("supply")
+	keyParams := sdk.NewKVStoreKey("params")
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -105,19 +96,25 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initCoins int64) (sdk.Context
 		keyAcc,                // target store
 		auth.ProtoBaseAccount, // prototype
 	)
-	ck := bank.NewKeeper(accountMapper)
-	keeper := NewKeeper(cdc, keyStake, ck, types.DefaultCodespace)
+	ck := bank.NewKeeper(accountMapper, params.NewKeeper(cdc, keyParams).Getter(), nil)
+	sk := bank.NewSupplyKeeper(cdc, keySupply, ck, map[string][]string{
+		auth.FeeCollectorName: nil,
+		types.ModuleName:      {auth.Burner, auth.Staking},
+	})
+	keeper := NewKeeper(cdc, keyStake, ck, sk, types.DefaultCodespace)
 	keeper.SetPool(ctx, types.InitialPool())
 	keeper.SetNewParams(ctx, types.DefaultParams())
 	keeper.InitIntraTxCounter(ctx)
 
-	// fill all the addresses with some coins, set the loose pool tokens simultaneously
+	// fill all the addresses with some coins, set the loose pool tokens and supply simultaneously
 	for _, addr := range Addrs {
 		pool := keeper.GetPool(ctx)
-		_, _, err := ck.AddCoins(ctx, addr, sdk.Coins{
+		coins := sdk.Coins{
 			{keeper.GetParams(ctx).BondDenom, sdk.NewInt(initCoins)},
-		})
+		}
+		_, _, err := ck.AddCoins(ctx, addr, coins)
 		require.Nil(t, err)
+		sk.Inflate(ctx, coins)
 		pool.LooseTokens = pool.LooseTokens.Add(sdk.NewRat(initCoins))
 		keeper.SetPool(ctx, pool)
 	}
@@ -125,6 +122,15 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initCoins int64) (sdk.Context
 	return ctx, accountMapper, keeper
 }
 
+// fund the module account with bond tokens, as the genesis accounts do, and
+// record them in the supply
+func fundModuleAccount(t *testing.T, ctx sdk.Context, keeper Keeper, amt int64) {
+	coins := keeper.bondCoins(ctx, sdk.NewInt(amt))
+	_, _, err := keeper.coinKeeper.AddCoins(ctx, keeper.GetModuleAccount(ctx).GetAddress(), coins)
+	require.Nil(t, err)
+	keeper.supplyKeeper.Inflate(ctx, coins)
+}
+
 func NewPubKey(pk string) (res crypto.PubKey) {
 	pkBytes, err := hex.DecodeString(pk)
 	if err != nil {
@@ -32,6 +32,16 @@ func (k Keeper) GetValidatorByPubKey(ctx sdk.Context, pubkey crypto.PubKey) (val
 	return k.GetValidator(ctx, addr)
 }
 
+// get a single validator by moniker, monikers are matched case-insensitively
+func (k Keeper) GetValidatorByMoniker(ctx sdk.Context, moniker string) (validator types.Validator, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	addr := store.Get(GetValidatorByMonikerIndexKey(moniker))
+	if addr == nil {
+		return validator, false
+	}
+	return k.GetValidator(ctx, addr)
+}
+
 // set the main record holding validator details
 func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
@@ -39,12 +49,39 @@ func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store.Set(GetValidatorKey(validator.Owner), bz)
 }
 
+// set a new validator along with its pubkey and moniker indexes, and call the validator
+// created hooks
+func (k Keeper) CreateValidator(ctx sdk.Context, validator types.Validator) {
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByPubKeyIndex(ctx, validator)
+	k.SetValidatorByMonikerIndex(ctx, validator)
+	k.onValidatorCreated(ctx, validator)
+}
+
 // validator index
 func (k Keeper) SetValidatorByPubKeyIndex(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(GetValidatorByPubKeyIndexKey(validator.PubKey), validator.Owner)
 }
 
+// validator index, validators without a moniker aren't indexed
+func (k Keeper) SetValidatorByMonikerIndex(ctx sdk.Context, validator types.Validator) {
+	if validator.Description.Moniker == "" {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorByMonikerIndexKey(validator.Description.Moniker), validator.Owner)
+}
+
+// validator index
+func (k Keeper) DeleteValidatorByMonikerIndex(ctx sdk.Context, validator types.Validator) {
+	if validator.Description.Moniker == "" {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorByMonikerIndexKey(validator.Description.Moniker))
+}
+
 // validator index
 func (k Keeper) SetValidatorByPowerIndex(ctx sdk.Context, validator types.Validator, pool types.Pool) {
 	store := ctx.KVStore(k.storeKey)
@@ -203,7 +240,10 @@ func (k Keeper) UpdateValidator(ctx sdk.Context, validator types.Validator) type
 	pool := k.GetPool(ctx)
 	oldValidator, oldFound := k.GetValidator(ctx, validator.Owner)
 
-	validator = k.updateForRevoking(ctx, oldFound, oldValidator, validator)
+	if validator.Jailed {
+		return k.updateForJailing(ctx, oldFound, oldValidator, validator, pool)
+	}
+
 	powerIncreasing := k.getPowerIncreasing(ctx, oldFound, oldValidator, validator)
 	validator.BondHeight, validator.BondIntraTxCounter = k.bondIncrement(ctx, oldFound, oldValidator, validator)
 	valPower := k.updateValidatorPower(ctx, oldFound, oldValidator, validator, pool)
@@ -211,7 +251,7 @@ func (k Keeper) UpdateValidator(ctx sdk.Context, validator types.Validator) type
 
 	switch {
 	// if already bonded and power increasing only need to update tendermint
-	case powerIncreasing && !validator.Revoked &&
+	case powerIncreasing &&
 		(oldFound && oldValidator.Status == sdk.Bonded):
 
 		bz := k.cdc.MustMarshalBinary(validator.ABCIValidator())
@@ -253,15 +293,26 @@ func (k Keeper) UpdateValidator(ctx sdk.Context, validator types.Validator) type
 	return validator
 }
 
-func (k Keeper) updateForRevoking(ctx sdk.Context, oldFound bool, oldValidator, newValidator types.Validator) types.Validator {
-	if newValidator.Revoked && oldFound && oldValidator.Status == sdk.Bonded {
+// jailed validators are removed from the power index and unbonded at once,
+// the next validator by power takes their spot in the bonded validator set
+func (k Keeper) updateForJailing(ctx sdk.Context, oldFound bool, oldValidator, newValidator types.Validator,
+	pool types.Pool) types.Validator {
+
+	if oldFound {
+		store := ctx.KVStore(k.storeKey)
+		store.Delete(GetValidatorsByPowerIndexKey(oldValidator, pool))
+	}
+
+	if oldFound && oldValidator.Status == sdk.Bonded {
 		newValidator = k.unbondValidator(ctx, newValidator)
 
-		// need to also clear the cliff validator spot because the revoke has
-		// opened up a new spot which will be filled when
-		// updateValidatorsBonded is called
+		// need to also clear the cliff validator spot because the jailing has
+		// opened up a new spot which is filled by UpdateBondedValidators
 		k.clearCliffValidator(ctx)
+		k.UpdateBondedValidators(ctx, newValidator)
 	}
+
+	k.SetValidator(ctx, newValidator)
 	return newValidator
 }
 
@@ -349,7 +400,7 @@ func (k Keeper) UpdateBondedValidators(ctx sdk.Context,
 		}
 
 		// increment bondedValidatorsCount / get the validator to bond
-		if !validator.Revoked {
+		if !validator.Jailed {
 			if validator.Status != sdk.Bonded {
 				validatorToBond = validator
 				newValidatorBonded = true
@@ -358,7 +409,7 @@ func (k Keeper) UpdateBondedValidators(ctx sdk.Context,
 
 			// sanity check
 		} else if validator.Status == sdk.Bonded {
-			panic(fmt.Sprintf("revoked validator cannot be bonded, address: %v\n", ownerAddr))
+			panic(fmt.Sprintf("jailed validator cannot be bonded, address: %v\n", ownerAddr))
 		}
 
 		iterator.Next()
@@ -441,11 +492,11 @@ func (k Keeper) UpdateBondedValidatorsFull(ctx sdk.Context) {
 			validator = k.bondValidator(ctx, validator)
 		}
 
-		if !validator.Revoked {
+		if !validator.Jailed {
 			bondedValidatorsCount++
 		} else {
 			if validator.Status == sdk.Bonded {
-				panic(fmt.Sprintf("revoked validator cannot be bonded, address: %v\n", ownerAddr))
+				panic(fmt.Sprintf("jailed validator cannot be bonded, address: %v\n", ownerAddr))
 			}
 		}
 
@@ -500,6 +551,8 @@ func (k Keeper) unbondValidator(ctx sdk.Context, validator types.Validator) type
 
 	// also remove from the Bonded types.Validators Store
 	store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
+
+	k.onValidatorBeginUnbonding(ctx, validator)
 	return validator
 }
 
@@ -526,6 +579,7 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 	bzABCI := k.cdc.MustMarshalBinary(validator.ABCIValidator())
 	store.Set(GetTendermintUpdatesKey(validator.Owner), bzABCI)
 
+	k.onValidatorBonded(ctx, validator)
 	return validator
 }
 
@@ -544,16 +598,18 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.AccAddress) {
 	store.Delete(GetValidatorKey(address))
 	store.Delete(GetValidatorByPubKeyIndexKey(validator.PubKey))
 	store.Delete(GetValidatorsByPowerIndexKey(validator, pool))
+	k.DeleteValidatorByMonikerIndex(ctx, validator)
 
 	// delete from the current and power weighted validator groups if the validator
 	// is bonded - and add validator with zero power to the validator updates
-	if store.Get(GetValidatorsBondedIndexKey(validator.Owner)) == nil {
-		return
+	if store.Get(GetValidatorsBondedIndexKey(validator.Owner)) != nil {
+		store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
+
+		bz := k.cdc.MustMarshalBinary(validator.ABCIValidatorZero())
+		store.Set(GetTendermintUpdatesKey(address), bz)
 	}
-	store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
 
-	bz := k.cdc.MustMarshalBinary(validator.ABCIValidatorZero())
-	store.Set(GetTendermintUpdatesKey(address), bz)
+	k.onValidatorRemoved(ctx, validator)
 }
 
 //__________________________________________________________________________
@@ -102,6 +102,7 @@ func TestSlashToZeroPowerRemoved(t *testing.T) {
 	keeper.SetValidatorByPubKeyIndex(ctx, validator)
 	validator = keeper.UpdateValidator(ctx, validator)
 	require.Equal(t, int64(100), validator.Tokens.RoundInt64(), "\nvalidator %v\npool %v", validator, pool)
+	fundModuleAccount(t, ctx, keeper, 100)
 
 	// slash the validator by 100%
 	keeper.Slash(ctx, PKs[0], 0, 100, sdk.OneRat())
@@ -15,62 +15,27 @@ import (
 )
 
 // AllInvariants runs all invariants of the stake module.
-// Currently: total supply, positive power
+// Currently: total supply, positive power, delegator shares, power index and
+// unbonding balances
 func AllInvariants(ck bank.Keeper, k stake.Keeper, am auth.AccountMapper) simulation.Invariant {
 	return func(t *testing.T, app *baseapp.BaseApp, log string) {
 		SupplyInvariants(ck, k, am)(t, app, log)
 		PositivePowerInvariant(k)(t, app, log)
 		ValidatorSetInvariant(k)(t, app, log)
+		assertInvariant(stake.DelegatorSharesInvariant(k))(t, app, log)
+		assertInvariant(stake.PowerIndexInvariant(k))(t, app, log)
+		assertInvariant(stake.UnbondingBalancesInvariant(k))(t, app, log)
 	}
 }
 
 // SupplyInvariants checks that the total supply reflects all held loose tokens, bonded tokens, and unbonding delegations
 func SupplyInvariants(ck bank.Keeper, k stake.Keeper, am auth.AccountMapper) simulation.Invariant {
-	return func(t *testing.T, app *baseapp.BaseApp, log string) {
-		ctx := app.NewContext(false, abci.Header{})
-		pool := k.GetPool(ctx)
-
-		loose := sdk.ZeroInt()
-		bonded := sdk.ZeroRat()
-		am.IterateAccounts(ctx, func(acc auth.Account) bool {
-			loose = loose.Add(acc.GetCoins().AmountOf("steak"))
-			return false
-		})
-		k.IterateUnbondingDelegations(ctx, func(_ int64, ubd stake.UnbondingDelegation) bool {
-			loose = loose.Add(ubd.Balance.Amount)
-			return false
-		})
-		k.IterateValidators(ctx, func(_ int64, validator sdk.Validator) bool {
-			switch validator.GetStatus() {
-			case sdk.Bonded:
-				bonded = bonded.Add(validator.GetPower())
-			case sdk.Unbonding:
-			case sdk.Unbonded:
-				loose = loose.Add(validator.GetTokens().RoundInt())
-			}
-			return false
-		})
-
-		// Loose tokens should equal coin supply plus unbonding delegations plus tokens on unbonded validators
-		require.True(t, pool.LooseTokens.RoundInt64() == loose.Int64(), "expected loose tokens to equal total steak held by accounts - pool.LooseTokens: %v, sum of account tokens: %v\nlog: %s",
-			pool.LooseTokens.RoundInt64(), loose.Int64(), log)
-
-		// Bonded tokens should equal sum of tokens with bonded validators
-		require.True(t, pool.BondedTokens.Equal(bonded), "expected bonded tokens to equal total steak held by bonded validators\nlog: %s", log)
-
-		// TODO Inflation check on total supply
-	}
+	return assertInvariant(stake.SupplyInvariants(k, am))
 }
 
 // PositivePowerInvariant checks that all stored validators have > 0 power
 func PositivePowerInvariant(k stake.Keeper) simulation.Invariant {
-	return func(t *testing.T, app *baseapp.BaseApp, log string) {
-		ctx := app.NewContext(false, abci.Header{})
-		k.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) bool {
-			require.True(t, validator.GetPower().GT(sdk.ZeroRat()), "validator with non-positive power stored")
-			return false
-		})
-	}
+	return assertInvariant(stake.PositivePowerInvariant(k))
 }
 
 // ValidatorSetInvariant checks equivalence of Tendermint validator set and SDK validator set
@@ -79,3 +44,12 @@ func ValidatorSetInvariant(k stake.Keeper) simulation.Invariant {
 		// TODO
 	}
 }
+
+// assert an invariant against the latest state of the application
+func assertInvariant(invariant sdk.Invariant) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+		err := invariant(ctx)
+		require.Nil(t, err, "%v\nlog: %s", err, log)
+	}
+}
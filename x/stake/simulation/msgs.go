@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	"github.com/cosmos/cosmos-sdk/x/stake"
@@ -35,11 +36,12 @@ func SimulateMsgCreateValidator(m auth.AccountMapper, k stake.Keeper) simulation
 			return "no-operation", nil
 		}
 		msg := stake.MsgCreateValidator{
-			Description:   description,
-			ValidatorAddr: address,
-			DelegatorAddr: address,
-			PubKey:        pubkey,
-			Delegation:    sdk.NewIntCoin(denom, amount),
+			Description:       description,
+			ValidatorAddr:     address,
+			DelegatorAddr:     address,
+			PubKey:            pubkey,
+			Delegation:        sdk.NewIntCoin(denom, amount),
+			MinSelfDelegation: sdk.OneInt(),
 		}
 		require.Nil(t, msg.ValidateBasic(), "expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		ctx, write := ctx.CacheContext()
@@ -146,29 +148,6 @@ func SimulateMsgBeginUnbonding(m auth.AccountMapper, k stake.Keeper) simulation.
 	}
 }
 
-// SimulateMsgCompleteUnbonding
-func SimulateMsgCompleteUnbonding(k stake.Keeper) simulation.TestAndRunTx {
-	return func(t *testing.T, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, keys []crypto.PrivKey, log string, event func(string)) (action string, err sdk.Error) {
-		validatorKey := simulation.RandomKey(r, keys)
-		validatorAddress := sdk.AccAddress(validatorKey.PubKey().Address())
-		delegatorKey := simulation.RandomKey(r, keys)
-		delegatorAddress := sdk.AccAddress(delegatorKey.PubKey().Address())
-		msg := stake.MsgCompleteUnbonding{
-			DelegatorAddr: delegatorAddress,
-			ValidatorAddr: validatorAddress,
-		}
-		require.Nil(t, msg.ValidateBasic(), "expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
-		ctx, write := ctx.CacheContext()
-		result := stake.NewHandler(k)(ctx, msg)
-		if result.IsOK() {
-			write()
-		}
-		event(fmt.Sprintf("stake/MsgCompleteUnbonding/%v", result.IsOK()))
-		action = fmt.Sprintf("TestMsgCompleteUnbonding: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
-		return action, nil
-	}
-}
-
 // SimulateMsgBeginRedelegate
 func SimulateMsgBeginRedelegate(m auth.AccountMapper, k stake.Keeper) simulation.TestAndRunTx {
 	return func(t *testing.T, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, keys []crypto.PrivKey, log string, event func(string)) (action string, err sdk.Error) {
@@ -205,47 +184,24 @@ func SimulateMsgBeginRedelegate(m auth.AccountMapper, k stake.Keeper) simulation
 	}
 }
 
-// SimulateMsgCompleteRedelegate
-func SimulateMsgCompleteRedelegate(k stake.Keeper) simulation.TestAndRunTx {
-	return func(t *testing.T, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, keys []crypto.PrivKey, log string, event func(string)) (action string, err sdk.Error) {
-		validatorSrcKey := simulation.RandomKey(r, keys)
-		validatorSrcAddress := sdk.AccAddress(validatorSrcKey.PubKey().Address())
-		validatorDstKey := simulation.RandomKey(r, keys)
-		validatorDstAddress := sdk.AccAddress(validatorDstKey.PubKey().Address())
-		delegatorKey := simulation.RandomKey(r, keys)
-		delegatorAddress := sdk.AccAddress(delegatorKey.PubKey().Address())
-		msg := stake.MsgCompleteRedelegate{
-			DelegatorAddr:    delegatorAddress,
-			ValidatorSrcAddr: validatorSrcAddress,
-			ValidatorDstAddr: validatorDstAddress,
-		}
-		require.Nil(t, msg.ValidateBasic(), "expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
-		ctx, write := ctx.CacheContext()
-		result := stake.NewHandler(k)(ctx, msg)
-		if result.IsOK() {
-			write()
-		}
-		event(fmt.Sprintf("stake/MsgCompleteRedelegate/%v", result.IsOK()))
-		action = fmt.Sprintf("TestMsgCompleteRedelegate: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
-		return action, nil
-	}
-}
-
 // Setup
-func Setup(mapp *mock.App, k stake.Keeper) simulation.RandSetup {
+func Setup(mapp *mock.App, k stake.Keeper, sk bank.SupplyKeeper) simulation.RandSetup {
 	return func(r *rand.Rand, privKeys []crypto.PrivKey) {
 		ctx := mapp.NewContext(false, abci.Header{})
 		stake.InitGenesis(ctx, k, stake.DefaultGenesisState())
 		params := k.GetParams(ctx)
 		denom := params.BondDenom
 		loose := sdk.ZeroInt()
+		supply := sdk.Coins{}
 		mapp.AccountMapper.IterateAccounts(ctx, func(acc auth.Account) bool {
 			balance := simulation.RandomAmount(r, sdk.NewInt(1000000))
 			acc.SetCoins(acc.GetCoins().Plus(sdk.Coins{sdk.NewIntCoin(denom, balance)}))
 			mapp.AccountMapper.SetAccount(ctx, acc)
 			loose = loose.Add(balance)
+			supply = supply.Plus(acc.GetCoins())
 			return false
 		})
+		sk.SetSupply(ctx, supply)
 		pool := k.GetPool(ctx)
 		pool.LooseTokens = pool.LooseTokens.Add(sdk.NewRat(loose.Int64(), 1))
 		k.SetPool(ctx, pool)
@@ -6,9 +6,12 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -19,18 +22,26 @@ func TestStakeWithRandomMessages(t *testing.T) {
 
 	bank.RegisterWire(mapp.Cdc)
 	mapper := mapp.AccountMapper
-	coinKeeper := bank.NewKeeper(mapper)
 	stakeKey := sdk.NewKVStoreKey("stake")
-	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, coinKeeper, stake.DefaultCodespace)
+	supplyKey := sdk.NewKVStoreKey("supply")
+	paramsKey := sdk.NewKVStoreKey("params")
+	paramsKeeper := params.NewKeeper(mapp.Cdc, paramsKey)
+	coinKeeper := bank.NewKeeper(mapper, paramsKeeper.Getter(), nil)
+	supplyKeeper := bank.NewSupplyKeeper(mapp.Cdc, supplyKey, coinKeeper, map[string][]string{
+		auth.FeeCollectorName: nil,
+		stake.ModuleName:      {auth.Burner, auth.Staking},
+	})
+	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, coinKeeper, supplyKeeper, stake.DefaultCodespace)
 	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper))
 	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-		validatorUpdates := stake.EndBlocker(ctx, stakeKeeper)
+		validatorUpdates, tags := stake.EndBlocker(ctx, stakeKeeper)
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: validatorUpdates,
+			Tags:             tags,
 		}
 	})
 
-	err := mapp.CompleteSetup([]*sdk.KVStoreKey{stakeKey})
+	err := mapp.CompleteSetup([]*sdk.KVStoreKey{stakeKey, supplyKey, paramsKey})
 	if err != nil {
 		panic(err)
 	}
@@ -47,13 +58,12 @@ func TestStakeWithRandomMessages(t *testing.T) {
 			SimulateMsgEditValidator(stakeKeeper),
 			SimulateMsgDelegate(mapper, stakeKeeper),
 			SimulateMsgBeginUnbonding(mapper, stakeKeeper),
-			SimulateMsgCompleteUnbonding(stakeKeeper),
 			SimulateMsgBeginRedelegate(mapper, stakeKeeper),
-			SimulateMsgCompleteRedelegate(stakeKeeper),
 		}, []simulation.RandSetup{
-			Setup(mapp, stakeKeeper),
+			Setup(mapp, stakeKeeper, supplyKeeper),
 		}, []simulation.Invariant{
 			AllInvariants(coinKeeper, stakeKeeper, mapp.AccountMapper),
+			banksim.SupplyInvariant(mapper, supplyKeeper),
 		}, 10, 100, 100,
 	)
 }
@@ -8,57 +8,72 @@ import (
 )
 
 type (
-	Keeper                = keeper.Keeper
-	Validator             = types.Validator
-	BechValidator         = types.BechValidator
-	Description           = types.Description
-	Delegation            = types.Delegation
-	UnbondingDelegation   = types.UnbondingDelegation
-	Redelegation          = types.Redelegation
-	Params                = types.Params
-	Pool                  = types.Pool
-	MsgCreateValidator    = types.MsgCreateValidator
-	MsgEditValidator      = types.MsgEditValidator
-	MsgDelegate           = types.MsgDelegate
-	MsgBeginUnbonding     = types.MsgBeginUnbonding
-	MsgCompleteUnbonding  = types.MsgCompleteUnbonding
-	MsgBeginRedelegate    = types.MsgBeginRedelegate
-	MsgCompleteRedelegate = types.MsgCompleteRedelegate
-	GenesisState          = types.GenesisState
+	Keeper              = keeper.Keeper
+	Validator           = types.Validator
+	BechValidator       = types.BechValidator
+	Description         = types.Description
+	Delegation          = types.Delegation
+	UnbondingDelegation = types.UnbondingDelegation
+	Redelegation        = types.Redelegation
+	DVPair              = types.DVPair
+	DVVTriplet          = types.DVVTriplet
+	Params              = types.Params
+	Pool                = types.Pool
+	MsgCreateValidator  = types.MsgCreateValidator
+	MsgEditValidator    = types.MsgEditValidator
+	MsgDelegate         = types.MsgDelegate
+	MsgBeginUnbonding   = types.MsgBeginUnbonding
+	MsgBeginRedelegate  = types.MsgBeginRedelegate
+	GenesisState        = types.GenesisState
 )
 
 var (
 	NewKeeper = keeper.NewKeeper
 
-	GetValidatorKey              = keeper.GetValidatorKey
-	GetValidatorByPubKeyIndexKey = keeper.GetValidatorByPubKeyIndexKey
-	GetValidatorsBondedIndexKey  = keeper.GetValidatorsBondedIndexKey
-	GetValidatorsByPowerIndexKey = keeper.GetValidatorsByPowerIndexKey
-	GetTendermintUpdatesKey      = keeper.GetTendermintUpdatesKey
-	GetDelegationKey             = keeper.GetDelegationKey
-	GetDelegationsKey            = keeper.GetDelegationsKey
-	ParamKey                     = keeper.ParamKey
-	PoolKey                      = keeper.PoolKey
-	ValidatorsKey                = keeper.ValidatorsKey
-	ValidatorsByPubKeyIndexKey   = keeper.ValidatorsByPubKeyIndexKey
-	ValidatorsBondedIndexKey     = keeper.ValidatorsBondedIndexKey
-	ValidatorsByPowerIndexKey    = keeper.ValidatorsByPowerIndexKey
-	ValidatorCliffIndexKey       = keeper.ValidatorCliffIndexKey
-	ValidatorPowerCliffKey       = keeper.ValidatorPowerCliffKey
-	TendermintUpdatesKey         = keeper.TendermintUpdatesKey
-	DelegationKey                = keeper.DelegationKey
-	IntraTxCounterKey            = keeper.IntraTxCounterKey
-	GetUBDKey                    = keeper.GetUBDKey
-	GetUBDByValIndexKey          = keeper.GetUBDByValIndexKey
-	GetUBDsKey                   = keeper.GetUBDsKey
-	GetUBDsByValIndexKey         = keeper.GetUBDsByValIndexKey
-	GetREDKey                    = keeper.GetREDKey
-	GetREDByValSrcIndexKey       = keeper.GetREDByValSrcIndexKey
-	GetREDByValDstIndexKey       = keeper.GetREDByValDstIndexKey
-	GetREDsKey                   = keeper.GetREDsKey
-	GetREDsFromValSrcIndexKey    = keeper.GetREDsFromValSrcIndexKey
-	GetREDsToValDstIndexKey      = keeper.GetREDsToValDstIndexKey
-	GetREDsByDelToValDstIndexKey = keeper.GetREDsByDelToValDstIndexKey
+	AllInvariants              = keeper.AllInvariants
+	SupplyInvariants           = keeper.SupplyInvariants
+	PositivePowerInvariant     = keeper.PositivePowerInvariant
+	DelegatorSharesInvariant   = keeper.DelegatorSharesInvariant
+	PowerIndexInvariant        = keeper.PowerIndexInvariant
+	UnbondingBalancesInvariant = keeper.UnbondingBalancesInvariant
+
+	GetValidatorKey                 = keeper.GetValidatorKey
+	GetValidatorByPubKeyIndexKey    = keeper.GetValidatorByPubKeyIndexKey
+	GetValidatorByMonikerIndexKey   = keeper.GetValidatorByMonikerIndexKey
+	GetValidatorsBondedIndexKey     = keeper.GetValidatorsBondedIndexKey
+	GetValidatorsByPowerIndexKey    = keeper.GetValidatorsByPowerIndexKey
+	GetTendermintUpdatesKey         = keeper.GetTendermintUpdatesKey
+	GetDelegationKey                = keeper.GetDelegationKey
+	GetDelegationsKey               = keeper.GetDelegationsKey
+	GetDelegationByValIndexKey      = keeper.GetDelegationByValIndexKey
+	GetDelegationKeyFromValIndexKey = keeper.GetDelegationKeyFromValIndexKey
+	GetDelegationsByValIndexKey     = keeper.GetDelegationsByValIndexKey
+	ParamKey                        = keeper.ParamKey
+	PoolKey                         = keeper.PoolKey
+	ValidatorsKey                   = keeper.ValidatorsKey
+	ValidatorsByPubKeyIndexKey      = keeper.ValidatorsByPubKeyIndexKey
+	ValidatorsBondedIndexKey        = keeper.ValidatorsBondedIndexKey
+	ValidatorsByPowerIndexKey       = keeper.ValidatorsByPowerIndexKey
+	ValidatorsByMonikerIndexKey     = keeper.ValidatorsByMonikerIndexKey
+	ValidatorCliffIndexKey          = keeper.ValidatorCliffIndexKey
+	ValidatorPowerCliffKey          = keeper.ValidatorPowerCliffKey
+	TendermintUpdatesKey            = keeper.TendermintUpdatesKey
+	DelegationKey                   = keeper.DelegationKey
+	DelegationByValIndexKey         = keeper.DelegationByValIndexKey
+	IntraTxCounterKey               = keeper.IntraTxCounterKey
+	GetUBDKey                       = keeper.GetUBDKey
+	GetUBDByValIndexKey             = keeper.GetUBDByValIndexKey
+	GetUBDKeyFromValIndexKey        = keeper.GetUBDKeyFromValIndexKey
+	GetUBDsKey                      = keeper.GetUBDsKey
+	GetUBDsByValIndexKey            = keeper.GetUBDsByValIndexKey
+	GetREDKey                       = keeper.GetREDKey
+	GetREDByValSrcIndexKey          = keeper.GetREDByValSrcIndexKey
+	GetREDByValDstIndexKey          = keeper.GetREDByValDstIndexKey
+	GetREDKeyFromValSrcIndexKey     = keeper.GetREDKeyFromValSrcIndexKey
+	GetREDsKey                      = keeper.GetREDsKey
+	GetREDsFromValSrcIndexKey       = keeper.GetREDsFromValSrcIndexKey
+	GetREDsToValDstIndexKey         = keeper.GetREDsToValDstIndexKey
+	GetREDsByDelToValDstIndexKey    = keeper.GetREDsByDelToValDstIndexKey
 
 	DefaultParams       = types.DefaultParams
 	InitialPool         = types.InitialPool
@@ -73,12 +88,12 @@ var (
 	NewMsgEditValidator             = types.NewMsgEditValidator
 	NewMsgDelegate                  = types.NewMsgDelegate
 	NewMsgBeginUnbonding            = types.NewMsgBeginUnbonding
-	NewMsgCompleteUnbonding         = types.NewMsgCompleteUnbonding
 	NewMsgBeginRedelegate           = types.NewMsgBeginRedelegate
-	NewMsgCompleteRedelegate        = types.NewMsgCompleteRedelegate
 )
 
 const (
+	ModuleName            = types.ModuleName
+	DoNotModifyDesc       = types.DoNotModifyDesc
 	DefaultCodespace      = types.DefaultCodespace
 	CodeInvalidValidator  = types.CodeInvalidValidator
 	CodeInvalidDelegation = types.CodeInvalidDelegation
@@ -90,15 +105,21 @@ const (
 )
 
 var (
-	ErrNilValidatorAddr      = types.ErrNilValidatorAddr
-	ErrNoValidatorFound      = types.ErrNoValidatorFound
-	ErrValidatorOwnerExists  = types.ErrValidatorOwnerExists
-	ErrValidatorPubKeyExists = types.ErrValidatorPubKeyExists
-	ErrValidatorRevoked      = types.ErrValidatorRevoked
-	ErrBadRemoveValidator    = types.ErrBadRemoveValidator
-	ErrDescriptionLength     = types.ErrDescriptionLength
-	ErrCommissionNegative    = types.ErrCommissionNegative
-	ErrCommissionHuge        = types.ErrCommissionHuge
+	ErrNilValidatorAddr       = types.ErrNilValidatorAddr
+	ErrNoValidatorFound       = types.ErrNoValidatorFound
+	ErrValidatorOwnerExists   = types.ErrValidatorOwnerExists
+	ErrValidatorPubKeyExists  = types.ErrValidatorPubKeyExists
+	ErrValidatorJailed        = types.ErrValidatorJailed
+	ErrBadRemoveValidator     = types.ErrBadRemoveValidator
+	ErrDescriptionLength      = types.ErrDescriptionLength
+	ErrDescriptionFormat      = types.ErrDescriptionFormat
+	ErrValidatorMonikerExists = types.ErrValidatorMonikerExists
+	ErrCommissionNegative     = types.ErrCommissionNegative
+	ErrCommissionHuge         = types.ErrCommissionHuge
+
+	ErrMinSelfDelegationInvalid   = types.ErrMinSelfDelegationInvalid
+	ErrMinSelfDelegationDecreased = types.ErrMinSelfDelegationDecreased
+	ErrSelfDelegationBelowMinimum = types.ErrSelfDelegationBelowMinimum
 
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
@@ -96,29 +96,69 @@ func (d Delegation) HumanReadableString() (string, error) {
 }
 
 // UnbondingDelegation reflects a delegation's passive unbonding queue.
+// it may hold multiple entries between the same delegator/validator
 type UnbondingDelegation struct {
-	DelegatorAddr  sdk.AccAddress `json:"delegator_addr"`  // delegator
-	ValidatorAddr  sdk.AccAddress `json:"validator_addr"`  // validator unbonding from owner addr
-	CreationHeight int64          `json:"creation_height"` // height which the unbonding took place
-	MinTime        int64          `json:"min_time"`        // unix time for unbonding completion
-	InitialBalance sdk.Coin       `json:"initial_balance"` // atoms initially scheduled to receive at completion
-	Balance        sdk.Coin       `json:"balance"`         // atoms to receive at completion
+	DelegatorAddr sdk.AccAddress             `json:"delegator_addr"` // delegator
+	ValidatorAddr sdk.AccAddress             `json:"validator_addr"` // validator unbonding from owner addr
+	Entries       []UnbondingDelegationEntry `json:"entries"`        // unbonding delegation entries
+}
+
+// UnbondingDelegationEntry - entry to an UnbondingDelegation
+type UnbondingDelegationEntry struct {
+	CreationHeight int64    `json:"creation_height"` // height which the unbonding took place
+	MinTime        int64    `json:"min_time"`        // unix time for unbonding completion
+	InitialBalance sdk.Coin `json:"initial_balance"` // atoms initially scheduled to receive at completion
+	Balance        sdk.Coin `json:"balance"`         // atoms to receive at completion
+}
+
+// IsMature - is the current entry mature
+func (e UnbondingDelegationEntry) IsMature(currentTime int64) bool {
+	return e.MinTime <= currentTime
+}
+
+// NewUnbondingDelegation - create a new unbonding delegation object
+func NewUnbondingDelegation(delegatorAddr, validatorAddr sdk.AccAddress,
+	creationHeight, minTime int64, balance sdk.Coin) UnbondingDelegation {
+
+	entry := NewUnbondingDelegationEntry(creationHeight, minTime, balance)
+	return UnbondingDelegation{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+		Entries:       []UnbondingDelegationEntry{entry},
+	}
+}
+
+// NewUnbondingDelegationEntry - create a new unbonding delegation entry
+func NewUnbondingDelegationEntry(creationHeight, minTime int64,
+	balance sdk.Coin) UnbondingDelegationEntry {
+
+	return UnbondingDelegationEntry{
+		CreationHeight: creationHeight,
+		MinTime:        minTime,
+		InitialBalance: balance,
+		Balance:        balance,
+	}
+}
+
+// AddEntry - append entry to the unbonding delegation
+func (d *UnbondingDelegation) AddEntry(creationHeight, minTime int64, balance sdk.Coin) {
+	entry := NewUnbondingDelegationEntry(creationHeight, minTime, balance)
+	d.Entries = append(d.Entries, entry)
+}
+
+// RemoveEntry - remove entry at index i from the unbonding delegation
+func (d *UnbondingDelegation) RemoveEntry(i int64) {
+	d.Entries = append(d.Entries[:i], d.Entries[i+1:]...)
 }
 
 type ubdValue struct {
-	CreationHeight int64
-	MinTime        int64
-	InitialBalance sdk.Coin
-	Balance        sdk.Coin
+	Entries []UnbondingDelegationEntry
 }
 
 // return the unbonding delegation without fields contained within the key for the store
 func MustMarshalUBD(cdc *wire.Codec, ubd UnbondingDelegation) []byte {
 	val := ubdValue{
-		ubd.CreationHeight,
-		ubd.MinTime,
-		ubd.InitialBalance,
-		ubd.Balance,
+		ubd.Entries,
 	}
 	return cdc.MustMarshalBinary(val)
 }
@@ -149,12 +189,9 @@ func UnmarshalUBD(cdc *wire.Codec, key, value []byte) (ubd UnbondingDelegation,
 	valAddr := sdk.AccAddress(addrs[sdk.AddrLen:])
 
 	return UnbondingDelegation{
-		DelegatorAddr:  delAddr,
-		ValidatorAddr:  valAddr,
-		CreationHeight: storeValue.CreationHeight,
-		MinTime:        storeValue.MinTime,
-		InitialBalance: storeValue.InitialBalance,
-		Balance:        storeValue.Balance,
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Entries:       storeValue.Entries,
 	}, nil
 }
 
@@ -172,45 +209,95 @@ func (d UnbondingDelegation) HumanReadableString() (string, error) {
 	resp := "Unbonding Delegation \n"
 	resp += fmt.Sprintf("Delegator: %s\n", d.DelegatorAddr)
 	resp += fmt.Sprintf("Validator: %s\n", d.ValidatorAddr)
-	resp += fmt.Sprintf("Creation height: %v\n", d.CreationHeight)
-	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
-	resp += fmt.Sprintf("Expected balance: %s", d.Balance.String())
+	for i, entry := range d.Entries {
+		resp += fmt.Sprintf("Unbonding Delegation %d: \n", i)
+		resp += fmt.Sprintf("  Creation height: %v\n", entry.CreationHeight)
+		resp += fmt.Sprintf("  Min time to unbond (unix): %v\n", entry.MinTime)
+		resp += fmt.Sprintf("  Expected balance: %s\n", entry.Balance.String())
+	}
 
 	return resp, nil
 
 }
 
+// DVPair identifies an unbonding delegation in the unbonding queue
+type DVPair struct {
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.AccAddress
+}
+
 // Redelegation reflects a delegation's passive re-delegation queue.
+// it may hold multiple entries between the same delegator/validator pair
 type Redelegation struct {
-	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`     // delegator
-	ValidatorSrcAddr sdk.AccAddress `json:"validator_src_addr"` // validator redelegation source owner addr
-	ValidatorDstAddr sdk.AccAddress `json:"validator_dst_addr"` // validator redelegation destination owner addr
-	CreationHeight   int64          `json:"creation_height"`    // height which the redelegation took place
-	MinTime          int64          `json:"min_time"`           // unix time for redelegation completion
-	InitialBalance   sdk.Coin       `json:"initial_balance"`    // initial balance when redelegation started
-	Balance          sdk.Coin       `json:"balance"`            // current balance
-	SharesSrc        sdk.Rat        `json:"shares_src"`         // amount of source shares redelegating
-	SharesDst        sdk.Rat        `json:"shares_dst"`         // amount of destination shares redelegating
+	DelegatorAddr    sdk.AccAddress      `json:"delegator_addr"`     // delegator
+	ValidatorSrcAddr sdk.AccAddress      `json:"validator_src_addr"` // validator redelegation source owner addr
+	ValidatorDstAddr sdk.AccAddress      `json:"validator_dst_addr"` // validator redelegation destination owner addr
+	Entries          []RedelegationEntry `json:"entries"`            // redelegation entries
+}
+
+// RedelegationEntry - entry to a Redelegation
+type RedelegationEntry struct {
+	CreationHeight int64    `json:"creation_height"` // height which the redelegation took place
+	MinTime        int64    `json:"min_time"`        // unix time for redelegation completion
+	InitialBalance sdk.Coin `json:"initial_balance"` // initial balance when redelegation started
+	Balance        sdk.Coin `json:"balance"`         // current balance
+	SharesSrc      sdk.Rat  `json:"shares_src"`      // amount of source shares redelegating
+	SharesDst      sdk.Rat  `json:"shares_dst"`      // amount of destination shares redelegating
+}
+
+// IsMature - is the current entry mature
+func (e RedelegationEntry) IsMature(currentTime int64) bool {
+	return e.MinTime <= currentTime
+}
+
+// NewRedelegation - create a new redelegation object
+func NewRedelegation(delegatorAddr, validatorSrcAddr, validatorDstAddr sdk.AccAddress,
+	creationHeight, minTime int64, balance sdk.Coin, sharesSrc, sharesDst sdk.Rat) Redelegation {
+
+	entry := NewRedelegationEntry(creationHeight, minTime, balance, sharesSrc, sharesDst)
+	return Redelegation{
+		DelegatorAddr:    delegatorAddr,
+		ValidatorSrcAddr: validatorSrcAddr,
+		ValidatorDstAddr: validatorDstAddr,
+		Entries:          []RedelegationEntry{entry},
+	}
+}
+
+// NewRedelegationEntry - create a new redelegation entry
+func NewRedelegationEntry(creationHeight, minTime int64, balance sdk.Coin,
+	sharesSrc, sharesDst sdk.Rat) RedelegationEntry {
+
+	return RedelegationEntry{
+		CreationHeight: creationHeight,
+		MinTime:        minTime,
+		InitialBalance: balance,
+		Balance:        balance,
+		SharesSrc:      sharesSrc,
+		SharesDst:      sharesDst,
+	}
+}
+
+// AddEntry - append entry to the redelegation
+func (d *Redelegation) AddEntry(creationHeight, minTime int64, balance sdk.Coin,
+	sharesSrc, sharesDst sdk.Rat) {
+
+	entry := NewRedelegationEntry(creationHeight, minTime, balance, sharesSrc, sharesDst)
+	d.Entries = append(d.Entries, entry)
+}
+
+// RemoveEntry - remove entry at index i from the redelegation
+func (d *Redelegation) RemoveEntry(i int64) {
+	d.Entries = append(d.Entries[:i], d.Entries[i+1:]...)
 }
 
 type redValue struct {
-	CreationHeight int64
-	MinTime        int64
-	InitialBalance sdk.Coin
-	Balance        sdk.Coin
-	SharesSrc      sdk.Rat
-	SharesDst      sdk.Rat
+	Entries []RedelegationEntry
 }
 
 // return the redelegation without fields contained within the key for the store
 func MustMarshalRED(cdc *wire.Codec, red Redelegation) []byte {
 	val := redValue{
-		red.CreationHeight,
-		red.MinTime,
-		red.InitialBalance,
-		red.Balance,
-		red.SharesSrc,
-		red.SharesDst,
+		red.Entries,
 	}
 	return cdc.MustMarshalBinary(val)
 }
@@ -245,12 +332,7 @@ func UnmarshalRED(cdc *wire.Codec, key, value []byte) (red Redelegation, err err
 		DelegatorAddr:    delAddr,
 		ValidatorSrcAddr: valSrcAddr,
 		ValidatorDstAddr: valDstAddr,
-		CreationHeight:   storeValue.CreationHeight,
-		MinTime:          storeValue.MinTime,
-		InitialBalance:   storeValue.InitialBalance,
-		Balance:          storeValue.Balance,
-		SharesSrc:        storeValue.SharesSrc,
-		SharesDst:        storeValue.SharesDst,
+		Entries:          storeValue.Entries,
 	}, nil
 }
 
@@ -269,11 +351,21 @@ func (d Redelegation) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Delegator: %s\n", d.DelegatorAddr)
 	resp += fmt.Sprintf("Source Validator: %s\n", d.ValidatorSrcAddr)
 	resp += fmt.Sprintf("Destination Validator: %s\n", d.ValidatorDstAddr)
-	resp += fmt.Sprintf("Creation height: %v\n", d.CreationHeight)
-	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
-	resp += fmt.Sprintf("Source shares: %s", d.SharesSrc.String())
-	resp += fmt.Sprintf("Destination shares: %s", d.SharesDst.String())
+	for i, entry := range d.Entries {
+		resp += fmt.Sprintf("Redelegation %d: \n", i)
+		resp += fmt.Sprintf("  Creation height: %v\n", entry.CreationHeight)
+		resp += fmt.Sprintf("  Min time to unbond (unix): %v\n", entry.MinTime)
+		resp += fmt.Sprintf("  Source shares: %s\n", entry.SharesSrc.String())
+		resp += fmt.Sprintf("  Destination shares: %s\n", entry.SharesDst.String())
+	}
 
 	return resp, nil
 
 }
+
+// DVVTriplet identifies a redelegation in the redelegation queue
+type DVVTriplet struct {
+	DelegatorAddr    sdk.AccAddress
+	ValidatorSrcAddr sdk.AccAddress
+	ValidatorDstAddr sdk.AccAddress
+}
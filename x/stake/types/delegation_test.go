@@ -57,17 +57,14 @@ func TestUnbondingDelegationEqual(t *testing.T) {
 	require.True(t, ok)
 
 	ud2.ValidatorAddr = addr3
-	ud2.MinTime = 20 * 20 * 2
+	ud2.Entries = []UnbondingDelegationEntry{{MinTime: 20 * 20 * 2}}
 
 	ok = ud1.Equal(ud2)
 	require.False(t, ok)
 }
 
 func TestUnbondingDelegationHumanReadableString(t *testing.T) {
-	ud := UnbondingDelegation{
-		DelegatorAddr: addr1,
-		ValidatorAddr: addr2,
-	}
+	ud := NewUnbondingDelegation(addr1, addr2, 0, 0, sdk.NewCoin("steak", 0))
 
 	// NOTE: Being that the validator's keypair is random, we cannot test the
 	// actual contents of the string.
@@ -91,22 +88,16 @@ func TestRedelegationEqual(t *testing.T) {
 	ok := r1.Equal(r2)
 	require.True(t, ok)
 
-	r2.SharesDst = sdk.NewRat(10)
-	r2.SharesSrc = sdk.NewRat(20)
-	r2.MinTime = 20 * 20 * 2
+	r2.Entries = []RedelegationEntry{
+		NewRedelegationEntry(0, 20*20*2, sdk.NewCoin("steak", 0), sdk.NewRat(20), sdk.NewRat(10)),
+	}
 
 	ok = r1.Equal(r2)
 	require.False(t, ok)
 }
 
 func TestRedelegationHumanReadableString(t *testing.T) {
-	r := Redelegation{
-		DelegatorAddr:    addr1,
-		ValidatorSrcAddr: addr2,
-		ValidatorDstAddr: addr3,
-		SharesDst:        sdk.NewRat(10),
-		SharesSrc:        sdk.NewRat(20),
-	}
+	r := NewRedelegation(addr1, addr2, addr3, 0, 0, sdk.NewCoin("steak", 0), sdk.NewRat(20), sdk.NewRat(10))
 
 	// NOTE: Being that the validator's keypair is random, we cannot test the
 	// actual contents of the string.
@@ -114,3 +105,14 @@ func TestRedelegationHumanReadableString(t *testing.T) {
 	require.Nil(t, err)
 	require.NotEmpty(t, valStr)
 }
+
+func TestUnbondingDelegationEntries(t *testing.T) {
+	ubd := NewUnbondingDelegation(addr1, addr2, 1, 10, sdk.NewCoin("steak", 5))
+	ubd.AddEntry(2, 20, sdk.NewCoin("steak", 7))
+	require.Equal(t, 2, len(ubd.Entries))
+	require.True(t, ubd.Entries[0].IsMature(10))
+	require.False(t, ubd.Entries[1].IsMature(10))
+
+	ubd.RemoveEntry(0)
+	require.Equal(t, []UnbondingDelegationEntry{NewUnbondingDelegationEntry(2, 20, sdk.NewCoin("steak", 7))}, ubd.Entries)
+}
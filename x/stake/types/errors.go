@@ -38,8 +38,8 @@ func ErrValidatorPubKeyExists(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator already exist for this pubkey, must use new validator pubkey")
 }
 
-func ErrValidatorRevoked(codespace sdk.CodespaceType) sdk.Error {
-	return sdk.NewError(codespace, CodeInvalidValidator, "validator for this address is currently revoked")
+func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator for this address is currently jailed")
 }
 
 func ErrBadRemoveValidator(codespace sdk.CodespaceType) sdk.Error {
@@ -51,6 +51,15 @@ func ErrDescriptionLength(codespace sdk.CodespaceType, descriptor string, got, m
 	return sdk.NewError(codespace, CodeInvalidValidator, msg)
 }
 
+func ErrDescriptionFormat(codespace sdk.CodespaceType, descriptor, reason string) sdk.Error {
+	msg := fmt.Sprintf("bad description format for %v, must not contain %v", descriptor, reason)
+	return sdk.NewError(codespace, CodeInvalidValidator, msg)
+}
+
+func ErrValidatorMonikerExists(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator already exist for this moniker, must use a new moniker")
+}
+
 func ErrCommissionNegative(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "commission must be positive")
 }
@@ -59,6 +68,18 @@ func ErrCommissionHuge(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be more than 100%")
 }
 
+func ErrMinSelfDelegationInvalid(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "minimum self delegation must be a positive integer")
+}
+
+func ErrMinSelfDelegationDecreased(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "minimum self delegation cannot be decreased")
+}
+
+func ErrSelfDelegationBelowMinimum(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator's self delegation must be greater than their minimum self delegation")
+}
+
 func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "delegator address is nil")
 }
@@ -17,8 +17,7 @@ const MaxBondDenominatorPrecision = 8
 
 // Verify interface at compile time
 var _, _, _ sdk.Msg = &MsgCreateValidator{}, &MsgEditValidator{}, &MsgDelegate{}
-var _, _ sdk.Msg = &MsgBeginUnbonding{}, &MsgCompleteUnbonding{}
-var _, _ sdk.Msg = &MsgBeginRedelegate{}, &MsgCompleteRedelegate{}
+var _, _ sdk.Msg = &MsgBeginUnbonding{}, &MsgBeginRedelegate{}
 
 // Initialize Int for the denominator
 var maximumBondingRationalDenominator = sdk.NewInt(int64(math.Pow10(MaxBondDenominatorPrecision)))
@@ -32,29 +31,35 @@ type MsgCreateValidator struct {
 	ValidatorAddr sdk.AccAddress `json:"validator_address"`
 	PubKey        crypto.PubKey  `json:"pubkey"`
 	Delegation    sdk.Coin       `json:"delegation"`
+
+	// minimum self delegation of the validator owner, the validator is
+	// jailed when its owner's delegation falls below it
+	MinSelfDelegation sdk.Int `json:"min_self_delegation"`
 }
 
 // Default way to create validator. Delegator address and validator address are the same
 func NewMsgCreateValidator(validatorAddr sdk.AccAddress, pubkey crypto.PubKey,
-	selfDelegation sdk.Coin, description Description) MsgCreateValidator {
+	selfDelegation sdk.Coin, description Description, minSelfDelegation sdk.Int) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:   description,
-		DelegatorAddr: validatorAddr,
-		ValidatorAddr: validatorAddr,
-		PubKey:        pubkey,
-		Delegation:    selfDelegation,
+		Description:       description,
+		DelegatorAddr:     validatorAddr,
+		ValidatorAddr:     validatorAddr,
+		PubKey:            pubkey,
+		Delegation:        selfDelegation,
+		MinSelfDelegation: minSelfDelegation,
 	}
 }
 
 // Creates validator msg by delegator address on behalf of validator address
 func NewMsgCreateValidatorOnBehalfOf(delegatorAddr, validatorAddr sdk.AccAddress, pubkey crypto.PubKey,
-	delegation sdk.Coin, description Description) MsgCreateValidator {
+	delegation sdk.Coin, description Description, minSelfDelegation sdk.Int) MsgCreateValidator {
 	return MsgCreateValidator{
-		Description:   description,
-		DelegatorAddr: delegatorAddr,
-		ValidatorAddr: validatorAddr,
-		PubKey:        pubkey,
-		Delegation:    delegation,
+		Description:       description,
+		DelegatorAddr:     delegatorAddr,
+		ValidatorAddr:     validatorAddr,
+		PubKey:            pubkey,
+		Delegation:        delegation,
+		MinSelfDelegation: minSelfDelegation,
 	}
 }
 
@@ -76,15 +81,17 @@ func (msg MsgCreateValidator) GetSigners() []sdk.AccAddress {
 func (msg MsgCreateValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		DelegatorAddr sdk.AccAddress `json:"delegator_address"`
-		ValidatorAddr sdk.AccAddress `json:"validator_address"`
-		PubKey        string         `json:"pubkey"`
-		Delegation    sdk.Coin       `json:"delegation"`
+		DelegatorAddr     sdk.AccAddress `json:"delegator_address"`
+		ValidatorAddr     sdk.AccAddress `json:"validator_address"`
+		PubKey            string         `json:"pubkey"`
+		Delegation        sdk.Coin       `json:"delegation"`
+		MinSelfDelegation sdk.Int        `json:"min_self_delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: msg.ValidatorAddr,
-		PubKey:        sdk.MustBech32ifyValPub(msg.PubKey),
-		Delegation:    msg.Delegation,
+		Description:       msg.Description,
+		ValidatorAddr:     msg.ValidatorAddr,
+		PubKey:            sdk.MustBech32ifyValPub(msg.PubKey),
+		Delegation:        msg.Delegation,
+		MinSelfDelegation: msg.MinSelfDelegation,
 	})
 	if err != nil {
 		panic(err)
@@ -103,10 +110,19 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if !(msg.Delegation.Amount.GT(sdk.ZeroInt())) {
 		return ErrBadDelegationAmount(DefaultCodespace)
 	}
+	if !msg.MinSelfDelegation.GT(sdk.ZeroInt()) {
+		return ErrMinSelfDelegationInvalid(DefaultCodespace)
+	}
+	if msg.Delegation.Amount.LT(msg.MinSelfDelegation) {
+		return ErrSelfDelegationBelowMinimum(DefaultCodespace)
+	}
 	empty := Description{}
 	if msg.Description == empty {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
+	if _, err := empty.UpdateDescription(msg.Description); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -116,12 +132,17 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 type MsgEditValidator struct {
 	Description
 	ValidatorAddr sdk.AccAddress `json:"address"`
+
+	// new minimum self delegation of the validator, nil if unchanged, it
+	// can only be increased
+	MinSelfDelegation *sdk.Int `json:"min_self_delegation"`
 }
 
-func NewMsgEditValidator(validatorAddr sdk.AccAddress, description Description) MsgEditValidator {
+func NewMsgEditValidator(validatorAddr sdk.AccAddress, description Description, newMinSelfDelegation *sdk.Int) MsgEditValidator {
 	return MsgEditValidator{
-		Description:   description,
-		ValidatorAddr: validatorAddr,
+		Description:       description,
+		ValidatorAddr:     validatorAddr,
+		MinSelfDelegation: newMinSelfDelegation,
 	}
 }
 
@@ -135,10 +156,12 @@ func (msg MsgEditValidator) GetSigners() []sdk.AccAddress {
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	b, err := MsgCdc.MarshalJSON(struct {
 		Description
-		ValidatorAddr sdk.AccAddress `json:"address"`
+		ValidatorAddr     sdk.AccAddress `json:"address"`
+		MinSelfDelegation *sdk.Int       `json:"min_self_delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: msg.ValidatorAddr,
+		Description:       msg.Description,
+		ValidatorAddr:     msg.ValidatorAddr,
+		MinSelfDelegation: msg.MinSelfDelegation,
 	})
 	if err != nil {
 		panic(err)
@@ -152,9 +175,15 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 	empty := Description{}
-	if msg.Description == empty {
+	if msg.Description == empty && msg.MinSelfDelegation == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+	if msg.MinSelfDelegation != nil && !msg.MinSelfDelegation.GT(sdk.ZeroInt()) {
+		return ErrMinSelfDelegationInvalid(DefaultCodespace)
+	}
+	if _, err := empty.UpdateDescription(msg.Description); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -270,52 +299,6 @@ func (msg MsgBeginRedelegate) ValidateBasic() sdk.Error {
 	return nil
 }
 
-// MsgDelegate - struct for bonding transactions
-type MsgCompleteRedelegate struct {
-	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`
-	ValidatorSrcAddr sdk.AccAddress `json:"validator_source_addr"`
-	ValidatorDstAddr sdk.AccAddress `json:"validator_destination_addr"`
-}
-
-func NewMsgCompleteRedelegate(delegatorAddr, validatorSrcAddr,
-	validatorDstAddr sdk.AccAddress) MsgCompleteRedelegate {
-
-	return MsgCompleteRedelegate{
-		DelegatorAddr:    delegatorAddr,
-		ValidatorSrcAddr: validatorSrcAddr,
-		ValidatorDstAddr: validatorDstAddr,
-	}
-}
-
-//nolint
-func (msg MsgCompleteRedelegate) Type() string { return MsgType }
-func (msg MsgCompleteRedelegate) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.DelegatorAddr}
-}
-
-// get the bytes for the message signer to sign on
-func (msg MsgCompleteRedelegate) GetSignBytes() []byte {
-	b, err := MsgCdc.MarshalJSON(msg)
-	if err != nil {
-		panic(err)
-	}
-	return sdk.MustSortJSON(b)
-}
-
-// quick validity check
-func (msg MsgCompleteRedelegate) ValidateBasic() sdk.Error {
-	if msg.DelegatorAddr == nil {
-		return ErrNilDelegatorAddr(DefaultCodespace)
-	}
-	if msg.ValidatorSrcAddr == nil {
-		return ErrNilValidatorAddr(DefaultCodespace)
-	}
-	if msg.ValidatorDstAddr == nil {
-		return ErrNilValidatorAddr(DefaultCodespace)
-	}
-	return nil
-}
-
 //______________________________________________________________________
 
 // MsgBeginUnbonding - struct for unbonding transactions
@@ -370,42 +353,3 @@ func (msg MsgBeginUnbonding) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
-
-// MsgCompleteUnbonding - struct for unbonding transactions
-type MsgCompleteUnbonding struct {
-	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
-	ValidatorAddr sdk.AccAddress `json:"validator_addr"`
-}
-
-func NewMsgCompleteUnbonding(delegatorAddr, validatorAddr sdk.AccAddress) MsgCompleteUnbonding {
-	return MsgCompleteUnbonding{
-		DelegatorAddr: delegatorAddr,
-		ValidatorAddr: validatorAddr,
-	}
-}
-
-//nolint
-func (msg MsgCompleteUnbonding) Type() string { return MsgType }
-func (msg MsgCompleteUnbonding) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.DelegatorAddr}
-}
-
-// get the bytes for the message signer to sign on
-func (msg MsgCompleteUnbonding) GetSignBytes() []byte {
-	b, err := MsgCdc.MarshalJSON(msg)
-	if err != nil {
-		panic(err)
-	}
-	return sdk.MustSortJSON(b)
-}
-
-// quick validity check
-func (msg MsgCompleteUnbonding) ValidateBasic() sdk.Error {
-	if msg.DelegatorAddr == nil {
-		return ErrNilDelegatorAddr(DefaultCodespace)
-	}
-	if msg.ValidatorAddr == nil {
-		return ErrNilValidatorAddr(DefaultCodespace)
-	}
-	return nil
-}
@@ -22,21 +22,26 @@ func TestMsgCreateValidator(t *testing.T) {
 		validatorAddr                             sdk.AccAddress
 		pubkey                                    crypto.PubKey
 		bond                                      sdk.Coin
+		minSelfDelegation                         sdk.Int
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, pk1, coinPos, true},
-		{"partial description", "", "", "c", "", addr1, pk1, coinPos, true},
-		{"empty description", "", "", "", "", addr1, pk1, coinPos, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, pk1, coinPos, false},
-		{"empty pubkey", "a", "b", "c", "d", addr1, emptyPubkey, coinPos, true},
-		{"empty bond", "a", "b", "c", "d", addr1, pk1, coinZero, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
-		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, false},
+		{"basic good", "a", "b", "c", "d", addr1, pk1, coinPos, sdk.OneInt(), true},
+		{"partial description", "", "", "c", "", addr1, pk1, coinPos, sdk.OneInt(), true},
+		{"empty description", "", "", "", "", addr1, pk1, coinPos, sdk.OneInt(), false},
+		{"empty address", "a", "b", "c", "d", emptyAddr, pk1, coinPos, sdk.OneInt(), false},
+		{"empty pubkey", "a", "b", "c", "d", addr1, emptyPubkey, coinPos, sdk.OneInt(), true},
+		{"empty bond", "a", "b", "c", "d", addr1, pk1, coinZero, sdk.OneInt(), false},
+		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, sdk.OneInt(), false},
+		{"negative bond", "a", "b", "c", "d", addr1, pk1, coinNeg, sdk.OneInt(), false},
+		{"bond equal to min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, coinPos.Amount, true},
+		{"bond below min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, coinPos.Amount.AddRaw(1), false},
+		{"zero min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, sdk.ZeroInt(), false},
+		{"negative min self delegation", "a", "b", "c", "d", addr1, pk1, coinPos, sdk.NewInt(-1), false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description)
+		msg := NewMsgCreateValidator(tc.validatorAddr, tc.pubkey, tc.bond, description, tc.minSelfDelegation)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -47,20 +52,26 @@ func TestMsgCreateValidator(t *testing.T) {
 
 // test ValidateBasic for MsgEditValidator
 func TestMsgEditValidator(t *testing.T) {
+	newMinSelfDelegation := sdk.NewInt(10)
+	zero := sdk.ZeroInt()
+
 	tests := []struct {
 		name, moniker, identity, website, details string
 		validatorAddr                             sdk.AccAddress
+		minSelfDelegation                         *sdk.Int
 		expectPass                                bool
 	}{
-		{"basic good", "a", "b", "c", "d", addr1, true},
-		{"partial description", "", "", "c", "", addr1, true},
-		{"empty description", "", "", "", "", addr1, false},
-		{"empty address", "a", "b", "c", "d", emptyAddr, false},
+		{"basic good", "a", "b", "c", "d", addr1, nil, true},
+		{"partial description", "", "", "c", "", addr1, nil, true},
+		{"empty description", "", "", "", "", addr1, nil, false},
+		{"empty address", "a", "b", "c", "d", emptyAddr, nil, false},
+		{"min self delegation only", "", "", "", "", addr1, &newMinSelfDelegation, true},
+		{"zero min self delegation", "a", "b", "c", "d", addr1, &zero, false},
 	}
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgEditValidator(tc.validatorAddr, description)
+		msg := NewMsgEditValidator(tc.validatorAddr, description, tc.minSelfDelegation)
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -92,7 +103,7 @@ func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 
 	for _, tc := range tests {
 		description := NewDescription(tc.moniker, tc.identity, tc.website, tc.details)
-		msg := NewMsgCreateValidatorOnBehalfOf(tc.delegatorAddr, tc.validatorAddr, tc.validatorPubKey, tc.bond, description)
+		msg := NewMsgCreateValidatorOnBehalfOf(tc.delegatorAddr, tc.validatorAddr, tc.validatorPubKey, tc.bond, description, sdk.OneInt())
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
 		} else {
@@ -100,11 +111,11 @@ func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 		}
 	}
 
-	msg := NewMsgCreateValidator(addr1, pk1, coinPos, Description{})
+	msg := NewMsgCreateValidator(addr1, pk1, coinPos, Description{}, sdk.OneInt())
 	addrs := msg.GetSigners()
 	require.Equal(t, []sdk.AccAddress{addr1}, addrs, "Signers on default msg is wrong")
 
-	msg = NewMsgCreateValidatorOnBehalfOf(addr2, addr1, pk1, coinPos, Description{})
+	msg = NewMsgCreateValidatorOnBehalfOf(addr2, addr1, pk1, coinPos, Description{}, sdk.OneInt())
 	addrs = msg.GetSigners()
 	require.Equal(t, []sdk.AccAddress{addr2, addr1}, addrs, "Signers for onbehalfof msg is wrong")
 }
@@ -164,31 +175,6 @@ func TestMsgBeginRedelegate(t *testing.T) {
 	}
 }
 
-// test ValidateBasic for MsgUnbond
-func TestMsgCompleteRedelegate(t *testing.T) {
-	tests := []struct {
-		name             string
-		delegatorAddr    sdk.AccAddress
-		validatorSrcAddr sdk.AccAddress
-		validatorDstAddr sdk.AccAddress
-		expectPass       bool
-	}{
-		{"regular", addr1, addr2, addr3, true},
-		{"empty delegator", emptyAddr, addr1, addr3, false},
-		{"empty source validator", addr1, emptyAddr, addr3, false},
-		{"empty destination validator", addr1, addr2, emptyAddr, false},
-	}
-
-	for _, tc := range tests {
-		msg := NewMsgCompleteRedelegate(tc.delegatorAddr, tc.validatorSrcAddr, tc.validatorDstAddr)
-		if tc.expectPass {
-			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
-		} else {
-			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
-		}
-	}
-}
-
 // test ValidateBasic for MsgUnbond
 func TestMsgBeginUnbonding(t *testing.T) {
 	tests := []struct {
@@ -214,26 +200,3 @@ func TestMsgBeginUnbonding(t *testing.T) {
 		}
 	}
 }
-
-// test ValidateBasic for MsgUnbond
-func TestMsgCompleteUnbonding(t *testing.T) {
-	tests := []struct {
-		name          string
-		delegatorAddr sdk.AccAddress
-		validatorAddr sdk.AccAddress
-		expectPass    bool
-	}{
-		{"regular", addr1, addr2, true},
-		{"empty delegator", emptyAddr, addr1, false},
-		{"empty validator", addr1, emptyAddr, false},
-	}
-
-	for _, tc := range tests {
-		msg := NewMsgCompleteUnbonding(tc.delegatorAddr, tc.validatorAddr)
-		if tc.expectPass {
-			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
-		} else {
-			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
-		}
-	}
-}
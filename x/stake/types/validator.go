@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -13,7 +15,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 )
 
-const doNotModifyDescVal = "[do-not-modify]"
+// DoNotModifyDesc is the value of a description field which is kept as is
+// when the description is updated
+const DoNotModifyDesc = "[do-not-modify]"
 
 // Validator defines the total amount of bond shares and their exchange rate to
 // coins. Accumulation of interest is modelled as an in increase in the
@@ -23,9 +27,10 @@ const doNotModifyDescVal = "[do-not-modify]"
 // exchange rate. Voting power can be calculated as total bonds multiplied by
 // exchange rate.
 type Validator struct {
-	Owner   sdk.AccAddress `json:"owner"`   // sender of BondTx - UnbondTx returns here
-	PubKey  crypto.PubKey  `json:"pub_key"` // pubkey of validator
-	Revoked bool           `json:"revoked"` // has the validator been revoked from bonded status?
+	Owner       sdk.AccAddress `json:"owner"`        // sender of BondTx - UnbondTx returns here
+	PubKey      crypto.PubKey  `json:"pub_key"`      // pubkey of validator
+	Jailed      bool           `json:"jailed"`       // has the validator been jailed from bonded status?
+	JailedUntil int64          `json:"jailed_until"` // timestamp the validator cannot be unjailed until
 
 	Status          sdk.BondStatus `json:"status"`           // validator status (bonded/unbonding/unbonded)
 	Tokens          sdk.Rat        `json:"tokens"`           // delegated tokens (incl. self-delegation)
@@ -35,6 +40,7 @@ type Validator struct {
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
+	MinSelfDelegation  sdk.Int     `json:"min_self_delegation"`   // minimum tokens the owner must self delegate to avoid being jailed
 
 	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
 	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
@@ -50,7 +56,8 @@ func NewValidator(owner sdk.AccAddress, pubKey crypto.PubKey, description Descri
 	return Validator{
 		Owner:                 owner,
 		PubKey:                pubKey,
-		Revoked:               false,
+		Jailed:                false,
+		JailedUntil:           int64(0),
 		Status:                sdk.Unbonded,
 		Tokens:                sdk.ZeroRat(),
 		DelegatorShares:       sdk.ZeroRat(),
@@ -58,6 +65,7 @@ func NewValidator(owner sdk.AccAddress, pubKey crypto.PubKey, description Descri
 		BondHeight:            int64(0),
 		BondIntraTxCounter:    int16(0),
 		ProposerRewardPool:    sdk.Coins{},
+		MinSelfDelegation:     sdk.OneInt(),
 		Commission:            sdk.ZeroRat(),
 		CommissionMax:         sdk.ZeroRat(),
 		CommissionChangeRate:  sdk.ZeroRat(),
@@ -69,7 +77,8 @@ func NewValidator(owner sdk.AccAddress, pubKey crypto.PubKey, description Descri
 // what's kept in the store value
 type validatorValue struct {
 	PubKey                crypto.PubKey
-	Revoked               bool
+	Jailed                bool
+	JailedUntil           int64
 	Status                sdk.BondStatus
 	Tokens                sdk.Rat
 	DelegatorShares       sdk.Rat
@@ -77,6 +86,7 @@ type validatorValue struct {
 	BondHeight            int64
 	BondIntraTxCounter    int16
 	ProposerRewardPool    sdk.Coins
+	MinSelfDelegation     sdk.Int
 	Commission            sdk.Rat
 	CommissionMax         sdk.Rat
 	CommissionChangeRate  sdk.Rat
@@ -88,7 +98,8 @@ type validatorValue struct {
 func MustMarshalValidator(cdc *wire.Codec, validator Validator) []byte {
 	val := validatorValue{
 		PubKey:                validator.PubKey,
-		Revoked:               validator.Revoked,
+		Jailed:                validator.Jailed,
+		JailedUntil:           validator.JailedUntil,
 		Status:                validator.Status,
 		Tokens:                validator.Tokens,
 		DelegatorShares:       validator.DelegatorShares,
@@ -96,6 +107,7 @@ func MustMarshalValidator(cdc *wire.Codec, validator Validator) []byte {
 		BondHeight:            validator.BondHeight,
 		BondIntraTxCounter:    validator.BondIntraTxCounter,
 		ProposerRewardPool:    validator.ProposerRewardPool,
+		MinSelfDelegation:     validator.MinSelfDelegation,
 		Commission:            validator.Commission,
 		CommissionMax:         validator.CommissionMax,
 		CommissionChangeRate:  validator.CommissionChangeRate,
@@ -131,7 +143,8 @@ func UnmarshalValidator(cdc *wire.Codec, ownerAddr, value []byte) (validator Val
 	return Validator{
 		Owner:                 ownerAddr,
 		PubKey:                storeValue.PubKey,
-		Revoked:               storeValue.Revoked,
+		Jailed:                storeValue.Jailed,
+		JailedUntil:           storeValue.JailedUntil,
 		Tokens:                storeValue.Tokens,
 		Status:                storeValue.Status,
 		DelegatorShares:       storeValue.DelegatorShares,
@@ -139,6 +152,7 @@ func UnmarshalValidator(cdc *wire.Codec, ownerAddr, value []byte) (validator Val
 		BondHeight:            storeValue.BondHeight,
 		BondIntraTxCounter:    storeValue.BondIntraTxCounter,
 		ProposerRewardPool:    storeValue.ProposerRewardPool,
+		MinSelfDelegation:     storeValue.MinSelfDelegation,
 		Commission:            storeValue.Commission,
 		CommissionMax:         storeValue.CommissionMax,
 		CommissionChangeRate:  storeValue.CommissionChangeRate,
@@ -159,13 +173,15 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp := "Validator \n"
 	resp += fmt.Sprintf("Owner: %s\n", v.Owner)
 	resp += fmt.Sprintf("Validator: %s\n", bechVal)
-	resp += fmt.Sprintf("Revoked: %v\n", v.Revoked)
+	resp += fmt.Sprintf("Jailed: %v\n", v.Jailed)
+	resp += fmt.Sprintf("Jailed Until: %d\n", v.JailedUntil)
 	resp += fmt.Sprintf("Status: %s\n", sdk.BondStatusToString(v.Status))
 	resp += fmt.Sprintf("Tokens: %s\n", v.Tokens.FloatString())
 	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.FloatString())
 	resp += fmt.Sprintf("Description: %s\n", v.Description)
 	resp += fmt.Sprintf("Bond Height: %d\n", v.BondHeight)
 	resp += fmt.Sprintf("Proposer Reward Pool: %s\n", v.ProposerRewardPool.String())
+	resp += fmt.Sprintf("Minimum Self Delegation: %s\n", v.MinSelfDelegation.String())
 	resp += fmt.Sprintf("Commission: %s\n", v.Commission.String())
 	resp += fmt.Sprintf("Max Commission Rate: %s\n", v.CommissionMax.String())
 	resp += fmt.Sprintf("Commission Change Rate: %s\n", v.CommissionChangeRate.String())
@@ -179,9 +195,10 @@ func (v Validator) HumanReadableString() (string, error) {
 
 // validator struct for bech output
 type BechValidator struct {
-	Owner   sdk.AccAddress `json:"owner"`   // in bech32
-	PubKey  string         `json:"pub_key"` // in bech32
-	Revoked bool           `json:"revoked"` // has the validator been revoked from bonded status?
+	Owner       sdk.AccAddress `json:"owner"`        // in bech32
+	PubKey      string         `json:"pub_key"`      // in bech32
+	Jailed      bool           `json:"jailed"`       // has the validator been jailed from bonded status?
+	JailedUntil int64          `json:"jailed_until"` // timestamp the validator cannot be unjailed until
 
 	Status          sdk.BondStatus `json:"status"`           // validator status (bonded/unbonding/unbonded)
 	Tokens          sdk.Rat        `json:"tokens"`           // delegated tokens (incl. self-delegation)
@@ -191,6 +208,7 @@ type BechValidator struct {
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
+	MinSelfDelegation  sdk.Int     `json:"min_self_delegation"`   // minimum tokens the owner must self delegate to avoid being jailed
 
 	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
 	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
@@ -209,9 +227,10 @@ func (v Validator) Bech32Validator() (BechValidator, error) {
 	}
 
 	return BechValidator{
-		Owner:   v.Owner,
-		PubKey:  bechValPubkey,
-		Revoked: v.Revoked,
+		Owner:       v.Owner,
+		PubKey:      bechValPubkey,
+		Jailed:      v.Jailed,
+		JailedUntil: v.JailedUntil,
 
 		Status:          v.Status,
 		Tokens:          v.Tokens,
@@ -221,6 +240,7 @@ func (v Validator) Bech32Validator() (BechValidator, error) {
 		BondHeight:         v.BondHeight,
 		BondIntraTxCounter: v.BondIntraTxCounter,
 		ProposerRewardPool: v.ProposerRewardPool,
+		MinSelfDelegation:  v.MinSelfDelegation,
 
 		Commission:            v.Commission,
 		CommissionMax:         v.CommissionMax,
@@ -243,6 +263,7 @@ func (v Validator) Equal(c2 Validator) bool {
 		v.DelegatorShares.Equal(c2.DelegatorShares) &&
 		v.Description == c2.Description &&
 		v.ProposerRewardPool.IsEqual(c2.ProposerRewardPool) &&
+		v.MinSelfDelegation.Equal(c2.MinSelfDelegation) &&
 		v.Commission.Equal(c2.Commission) &&
 		v.CommissionMax.Equal(c2.CommissionMax) &&
 		v.CommissionChangeRate.Equal(c2.CommissionChangeRate) &&
@@ -268,28 +289,33 @@ func NewDescription(moniker, identity, website, details string) Description {
 	}
 }
 
-// UpdateDescription updates the fields of a given description. An error is
-// returned if the resulting description contains an invalid length.
+// UpdateDescription updates the fields of a given description, the fields of
+// d2 set to DoNotModifyDesc are kept as is. An error is returned if the
+// resulting description contains an invalid length or format.
 func (d Description) UpdateDescription(d2 Description) (Description, sdk.Error) {
-	if d.Moniker == doNotModifyDescVal {
+	if d2.Moniker == DoNotModifyDesc {
 		d2.Moniker = d.Moniker
 	}
-	if d.Identity == doNotModifyDescVal {
+	if d2.Identity == DoNotModifyDesc {
 		d2.Identity = d.Identity
 	}
-	if d.Website == doNotModifyDescVal {
+	if d2.Website == DoNotModifyDesc {
 		d2.Website = d.Website
 	}
-	if d.Details == doNotModifyDescVal {
+	if d2.Details == DoNotModifyDesc {
 		d2.Details = d.Details
 	}
 
-	return Description{
+	d2, err := Description{
 		Moniker:  d2.Moniker,
 		Identity: d2.Identity,
 		Website:  d2.Website,
 		Details:  d2.Details,
 	}.EnsureLength()
+	if err != nil {
+		return d2, err
+	}
+	return d2.EnsureFormat()
 }
 
 // EnsureLength ensures the length of a validator's description.
@@ -310,6 +336,26 @@ func (d Description) EnsureLength() (Description, sdk.Error) {
 	return d, nil
 }
 
+// EnsureFormat ensures the moniker, identity and website of a validator's
+// description hold no control characters and the moniker no surrounding
+// whitespace.
+func (d Description) EnsureFormat() (Description, sdk.Error) {
+	if strings.TrimSpace(d.Moniker) != d.Moniker {
+		return d, ErrDescriptionFormat(DefaultCodespace, "moniker", "surrounding whitespace")
+	}
+	if strings.IndexFunc(d.Moniker, unicode.IsControl) != -1 {
+		return d, ErrDescriptionFormat(DefaultCodespace, "moniker", "control characters")
+	}
+	if strings.IndexFunc(d.Identity, unicode.IsControl) != -1 {
+		return d, ErrDescriptionFormat(DefaultCodespace, "identity", "control characters")
+	}
+	if strings.IndexFunc(d.Website, unicode.IsControl) != -1 {
+		return d, ErrDescriptionFormat(DefaultCodespace, "website", "control characters")
+	}
+
+	return d, nil
+}
+
 // ABCIValidator returns an abci.Validator from a staked validator type.
 func (v Validator) ABCIValidator() abci.Validator {
 	return abci.Validator{
@@ -428,12 +474,14 @@ func (v Validator) BondedTokens() sdk.Rat {
 var _ sdk.Validator = Validator{}
 
 // nolint - for sdk.Validator
-func (v Validator) GetRevoked() bool            { return v.Revoked }
-func (v Validator) GetMoniker() string          { return v.Description.Moniker }
-func (v Validator) GetStatus() sdk.BondStatus   { return v.Status }
-func (v Validator) GetOwner() sdk.AccAddress    { return v.Owner }
-func (v Validator) GetPubKey() crypto.PubKey    { return v.PubKey }
-func (v Validator) GetPower() sdk.Rat           { return v.BondedTokens() }
-func (v Validator) GetTokens() sdk.Rat          { return v.Tokens }
-func (v Validator) GetDelegatorShares() sdk.Rat { return v.DelegatorShares }
-func (v Validator) GetBondHeight() int64        { return v.BondHeight }
+func (v Validator) GetJailed() bool               { return v.Jailed }
+func (v Validator) GetJailedUntil() int64         { return v.JailedUntil }
+func (v Validator) GetMoniker() string            { return v.Description.Moniker }
+func (v Validator) GetStatus() sdk.BondStatus     { return v.Status }
+func (v Validator) GetOwner() sdk.AccAddress      { return v.Owner }
+func (v Validator) GetPubKey() crypto.PubKey      { return v.PubKey }
+func (v Validator) GetPower() sdk.Rat             { return v.BondedTokens() }
+func (v Validator) GetTokens() sdk.Rat            { return v.Tokens }
+func (v Validator) GetDelegatorShares() sdk.Rat   { return v.DelegatorShares }
+func (v Validator) GetMinSelfDelegation() sdk.Int { return v.MinSelfDelegation }
+func (v Validator) GetBondHeight() int64          { return v.BondHeight }
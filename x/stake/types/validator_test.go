@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -25,19 +26,45 @@ func TestValidatorEqual(t *testing.T) {
 
 func TestUpdateDescription(t *testing.T) {
 	d1 := Description{
-		Moniker:  doNotModifyDescVal,
-		Identity: doNotModifyDescVal,
-		Website:  doNotModifyDescVal,
-		Details:  doNotModifyDescVal,
+		Moniker:  "validator",
+		Identity: "identity",
+		Website:  "https://validator.cosmos",
+		Details:  "Test validator",
 	}
+
+	// fields set to DoNotModifyDesc are kept, others are replaced
 	d2 := Description{
-		Website: "https://validator.cosmos",
-		Details: "Test validator",
+		Moniker:  DoNotModifyDesc,
+		Identity: DoNotModifyDesc,
+		Website:  "https://new.validator.cosmos",
+		Details:  "",
 	}
-
 	d, err := d1.UpdateDescription(d2)
 	require.Nil(t, err)
-	require.Equal(t, d, d1)
+	require.Equal(t, "validator", d.Moniker)
+	require.Equal(t, "identity", d.Identity)
+	require.Equal(t, "https://new.validator.cosmos", d.Website)
+	require.Equal(t, "", d.Details)
+
+	// updating nothing keeps the description as is
+	d, err = d1.UpdateDescription(NewDescription(DoNotModifyDesc, DoNotModifyDesc, DoNotModifyDesc, DoNotModifyDesc))
+	require.Nil(t, err)
+	require.Equal(t, d1, d)
+
+	tests := []struct {
+		name string
+		d2   Description
+	}{
+		{"moniker too long", NewDescription(strings.Repeat("a", 71), "", "", "")},
+		{"details too long", NewDescription(DoNotModifyDesc, "", "", strings.Repeat("a", 281))},
+		{"moniker surrounding whitespace", NewDescription(" validator", "", "", "")},
+		{"moniker control characters", NewDescription("valid\nator", "", "", "")},
+		{"website control characters", NewDescription(DoNotModifyDesc, "", "https://\tvalidator", "")},
+	}
+	for _, tc := range tests {
+		_, err := d1.UpdateDescription(tc.d2)
+		require.NotNil(t, err, tc.name)
+	}
 }
 
 func TestABCIValidator(t *testing.T) {
@@ -170,10 +197,8 @@ func TestRemoveDelShares(t *testing.T) {
 		DelegatorShares: delShares,
 	}
 	pool := Pool{
-		BondedTokens:      sdk.NewRat(248305),
-		LooseTokens:       sdk.NewRat(232147),
-		InflationLastTime: 0,
-		Inflation:         sdk.NewRat(7, 100),
+		BondedTokens: sdk.NewRat(248305),
+		LooseTokens:  sdk.NewRat(232147),
 	}
 	shares := sdk.NewRat(29)
 	_, newPool, tokens := validator.RemoveDelShares(pool, shares)
@@ -218,10 +243,8 @@ func TestPossibleOverflow(t *testing.T) {
 		DelegatorShares: delShares,
 	}
 	pool := Pool{
-		LooseTokens:       sdk.NewRat(100),
-		BondedTokens:      poolTokens,
-		InflationLastTime: 0,
-		Inflation:         sdk.NewRat(7, 100),
+		LooseTokens:  sdk.NewRat(100),
+		BondedTokens: poolTokens,
 	}
 	tokens := int64(71)
 	msg := fmt.Sprintf("validator %#v", validator)